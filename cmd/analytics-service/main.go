@@ -14,11 +14,14 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/health"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
 	analyticsRepo "github.com/MHK-26/pod_platfrom_go/pkg/analytics/repository/postgres"
 	analyticsUsecase "github.com/MHK-26/pod_platfrom_go/pkg/analytics/usecase"
 	analyticsHttp "github.com/MHK-26/pod_platfrom_go/pkg/analytics/delivery/http"
+	analyticsContent "github.com/MHK-26/pod_platfrom_go/pkg/analytics/content"
 	authUsecase "github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
 )
 
@@ -32,6 +35,9 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to load config", logger.Field("error", err))
 	}
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid config", logger.Field("error", err))
+	}
 
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
@@ -43,40 +49,58 @@ func main() {
 	}
 	defer database.CloseDB(db)
 
+	// Initialize the metrics registry served on /metrics
+	registry := metrics.NewRegistry()
+
 	// Initialize repositories
 	analyticsRepository := analyticsRepo.NewRepository(db)
 
+	// Connect to the content service for podcast and episode titles
+	var contentClient analyticsUsecase.ContentClient
+	var contentHealthCheck health.CheckFunc
+	if cfg.Analytics.ContentServiceAddr != "" {
+		client, err := analyticsContent.NewClient(cfg.Analytics.ContentServiceAddr)
+		if err != nil {
+			logger.Fatal("Failed to create content service client", logger.Field("error", err))
+		}
+		defer client.Close()
+		contentClient = client
+		contentHealthCheck = client.HealthCheck
+	}
+
 	// Initialize usecases
-	analyticsUC := analyticsUsecase.NewUsecase(analyticsRepository, cfg, 10*time.Second)
-	authUC := authUsecase.NewUsecase(nil, cfg, 10*time.Second) // We only need token verification
+	analyticsUC := analyticsUsecase.NewUsecase(analyticsRepository, contentClient, registry, cfg, 10*time.Second)
+	authUC := authUsecase.NewUsecase(nil, nil, nil, nil, cfg, 10*time.Second) // We only need token verification
 
 	// Initialize router
 	router := gin.New()
 
+	// Take client IPs from X-Forwarded-For only when our own proxies set it, as clients could
+	// otherwise pick the IP they are limited and logged by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", logger.Field("error", err))
+	}
+
 	// Middlewares
 	router.Use(middleware.LoggingMiddleware())
+	router.Use(middleware.MetricsMiddleware(metrics.NewHTTPMetrics(registry)))
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
+	router.Use(middleware.CORS(&cfg.CORS))
+	router.Use(middleware.ConcurrencyLimitMiddleware(authUC, cfg.Server.MaxConcurrentRequests, cfg.Server.ConcurrencyLimitExemptions))
 
 	// Auth middleware
 	authMiddleware := middleware.AuthMiddleware(authUC)
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		err := database.PostgresHealthCheck(db)
-		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status":  "error",
-				"message": "Database connection failed",
-			})
-			return
-		}
+	// Metrics endpoint
+	router.GET("/metrics", metrics.Handler(registry))
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "analytics-service",
-		})
-	})
+	// Health check endpoints
+	healthChecker := health.NewChecker("analytics-service")
+	healthChecker.AddRequired("postgres", db.PingContext)
+	if contentHealthCheck != nil {
+		healthChecker.AddOptional("content-service", contentHealthCheck)
+	}
+	healthChecker.RegisterRoutes(router)
 
 	// Initialize HTTP handlers
 	analyticsHandler := analyticsHttp.NewHandler(analyticsUC)
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,20 +13,40 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	auditRepo "github.com/MHK-26/pod_platfrom_go/pkg/audit/repository/postgres"
+	auditUsecase "github.com/MHK-26/pod_platfrom_go/pkg/audit/usecase"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/delivery/http/handlers"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/repository/postgres"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/health"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 )
 
+// revokedTokenCleanupInterval is how often expired revoked tokens are deleted
+const revokedTokenCleanupInterval = time.Hour
+
 func main() {
+	// Define command line flags
+	promoteAdmin := flag.String("promote-admin", "", "Only make the account with this email an admin, verified or not, and exit")
+	flag.Parse()
+
+	// Initialize logger
+	logger.Initialize("auth-service", "info")
+	defer logger.Close()
+
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid config: %v", err)
+	}
 
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
@@ -37,19 +58,62 @@ func main() {
 	}
 	defer database.CloseDB(db)
 
+	// Initialize the metrics registry served on /metrics
+	registry := metrics.NewRegistry()
+
 	// Initialize repository
 	repo := postgres.NewRepository(db)
 
-	// Initialize usecase
-	usecase := usecase.NewUsecase(repo, cfg, 10*time.Second)
+	// Initialize file storage
+	store, err := storage.NewService(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Initialize usecases
+	auditUC := auditUsecase.NewUsecase(auditRepo.NewRepository(db), cfg, 10*time.Second)
+	// Emails are sent through the configured mail server, or else logged. The logs only hold
+	// their one-time links in debug mode.
+	mailer := usecase.NewLogMailer(cfg.Server.Mode == "debug")
+	if cfg.Auth.SMTPAddr != "" {
+		mailer = usecase.NewSMTPMailer(cfg.Auth.SMTPAddr, cfg.Auth.SMTPUsername, cfg.Auth.SMTPPassword, cfg.Auth.MailFrom)
+	} else if cfg.Server.Mode == "release" {
+		log.Printf("SMTP_ADDR is not set, so verification and password reset emails are not sent")
+	}
+	usecase := usecase.NewUsecase(repo, auditUC, store, mailer, cfg, 10*time.Second)
+
+	// If promote-admin flag is set, promote the account and exit
+	if *promoteAdmin != "" {
+		if err := usecase.PromoteAdmin(context.Background(), *promoteAdmin); err != nil {
+			log.Fatalf("Failed to promote admin: %v", err)
+		}
+		log.Printf("Account %s is an admin", *promoteAdmin)
+		return
+	}
+
+	// Promote the configured admins, who can't be created through registration
+	if err := usecase.BootstrapAdmins(context.Background(), cfg.Auth.AdminEmails); err != nil {
+		log.Fatalf("Failed to bootstrap admins: %v", err)
+	}
 
 	// Initialize router
 	router := gin.Default()
 
+	// Take client IPs from X-Forwarded-For only when our own proxies set it, as clients could
+	// otherwise pick the IP they are limited and logged by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", logger.Field("error", err))
+	}
+
 	// Middleware
+	// Request bodies hold passwords, so requests are logged by gin.Logger rather than
+	// middleware.LoggingMiddleware; the request ID still reaches the usecase logs
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(gin.Logger())
+	router.Use(middleware.MetricsMiddleware(metrics.NewHTTPMetrics(registry)))
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
+	router.Use(middleware.CORS(&cfg.CORS))
+	router.Use(middleware.ConcurrencyLimitMiddleware(usecase, cfg.Server.MaxConcurrentRequests, cfg.Server.ConcurrencyLimitExemptions))
 
 	// Auth middleware
 	authMiddleware := middleware.AuthMiddleware(usecase)
@@ -57,22 +121,14 @@ func main() {
 	// Initialize handlers
 	handler := handlers.NewHandler(usecase)
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		err := database.PostgresHealthCheck(db)
-		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status": "error",
-				"message": "Database connection failed",
-			})
-			return
-		}
+	// Metrics endpoint
+	router.GET("/metrics", metrics.Handler(registry))
 
-		c.JSON(http.StatusOK, gin.H{
-			"status": "ok",
-			"service": "auth-service",
-		})
-	})
+	// Health check endpoints
+	healthChecker := health.NewChecker("auth-service")
+	healthChecker.AddRequired("postgres", db.PingContext)
+	healthChecker.AddRequired("storage", store.HealthCheck)
+	healthChecker.RegisterRoutes(router)
 
 	// Register routes
 	v1 := router.Group("/api/v1")
@@ -95,6 +151,29 @@ func main() {
 		}
 	}()
 
+	// Periodically delete revoked refresh tokens that have expired
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	defer stopCleanup()
+	go func() {
+		ticker := time.NewTicker(revokedTokenCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+			case <-cleanupCtx.Done():
+				return
+			}
+
+			deleted, err := usecase.DeleteExpiredRevokedTokens(cleanupCtx)
+			if err != nil {
+				logger.Error("Failed to delete expired revoked tokens", logger.Field("error", err))
+			} else if deleted > 0 {
+				logger.Info("Deleted expired revoked tokens", logger.Field("total", deleted))
+			}
+		}
+	}()
+
 	// Wait for interrupt signal to gracefully shut down the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
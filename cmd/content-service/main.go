@@ -3,31 +3,57 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/health"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 	
+	auditHttp "github.com/MHK-26/pod_platfrom_go/pkg/audit/delivery/http"
+	auditRepo "github.com/MHK-26/pod_platfrom_go/pkg/audit/repository/postgres"
+	auditUsecase "github.com/MHK-26/pod_platfrom_go/pkg/audit/usecase"
 	authUsecase "github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
+	contentModels "github.com/MHK-26/pod_platfrom_go/pkg/content/models"
 	contentRepo "github.com/MHK-26/pod_platfrom_go/pkg/content/repository/postgres"
 	contentUsecase "github.com/MHK-26/pod_platfrom_go/pkg/content/usecase"
 	contentHttp "github.com/MHK-26/pod_platfrom_go/pkg/content/delivery/http"
+	contentGrpc "github.com/MHK-26/pod_platfrom_go/pkg/content/delivery/grpc"
 	contentRSS "github.com/MHK-26/pod_platfrom_go/pkg/content/rss"
 	contentSync "github.com/MHK-26/pod_platfrom_go/pkg/content/sync"
+	contentNotification "github.com/MHK-26/pod_platfrom_go/pkg/content/notification"
+	contentWebhook "github.com/MHK-26/pod_platfrom_go/pkg/content/webhook"
+	pb "github.com/MHK-26/pod_platfrom_go/api/proto/content"
+	"google.golang.org/grpc"
+	grpcHealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// backgroundShutdownTimeout is how long shutdown waits for the background jobs to stop
+const backgroundShutdownTimeout = 30 * time.Second
+
+// maxSyncCheckInterval is the longest time between checks for podcasts due for a sync,
+// which bounds how late a podcast with a short interval of its own is synced
+const maxSyncCheckInterval = 15 * time.Minute
+
 func main() {
 	// Define command line flags
 	syncRSS := flag.Bool("sync-rss", false, "Only perform RSS feed synchronization and exit")
+	forceSync := flag.Bool("force", false, "With -sync-rss, sync every podcast rather than only those due for a sync")
+	refreshListenCounts := flag.Bool("refresh-listen-counts", false, "Only recount the listens of each podcast and exit")
 	flag.Parse()
 
 	// Initialize logger
@@ -39,6 +65,9 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to load config", logger.Field("error", err))
 	}
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid config", logger.Field("error", err))
+	}
 
 	// Connect to database
 	db, err := database.NewPostgresDB(&cfg.DB)
@@ -47,18 +76,32 @@ func main() {
 	}
 	defer database.CloseDB(db)
 
+	// Initialize the metrics registry served on /metrics
+	registry := metrics.NewRegistry()
+
 	// Initialize repositories
 	contentRepository := contentRepo.NewRepository(db)
+	auditRepository := auditRepo.NewRepository(db)
 
 	// Initialize RSS parser
 	rssParser := contentRSS.NewParser(30 * time.Second)
 
-	// Initialize sync service
-	syncService := contentSync.NewService(contentRepository, rssParser, db)
+	// Initialize sync service, which stores new-episode notifications for subscribers and
+	// queues events for podcasters' webhooks
+	notifier := contentNotification.NewStoreNotifier(contentRepository)
+	webhookService := contentWebhook.NewService(contentRepository, cfg)
+	syncService := contentSync.NewService(contentRepository, rssParser, db, cfg, notifier, webhookService, registry)
+
+	// Initialize file storage
+	store, err := storage.NewService(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize storage", logger.Field("error", err))
+	}
 
 	// Initialize usecases
-	contentUC := contentUsecase.NewUsecase(contentRepository, syncService, cfg, 10*time.Second)
-	authUC := authUsecase.NewUsecase(nil, cfg, 10*time.Second) // We only need token verification
+	auditUC := auditUsecase.NewUsecase(auditRepository, cfg, 10*time.Second)
+	contentUC := contentUsecase.NewUsecase(contentRepository, syncService, auditUC, store, cfg, 10*time.Second)
+	authUC := authUsecase.NewUsecase(nil, nil, nil, nil, cfg, 10*time.Second) // We only need token verification
 
 	// If sync-rss flag is set, perform sync and exit
 	if *syncRSS {
@@ -67,7 +110,7 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
 		defer cancel()
 		
-		results, err := contentUC.SyncAllPodcasts(ctx)
+		results, err := contentUC.SyncAllPodcasts(ctx, *forceSync)
 		if err != nil {
 			logger.Fatal("Failed to sync podcasts", logger.Field("error", err))
 		}
@@ -96,43 +139,62 @@ func main() {
 		return
 	}
 
+	// If refresh-listen-counts flag is set, recount podcast listens and exit
+	if *refreshListenCounts {
+		logger.Info("Starting listen count refresh")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		defer cancel()
+
+		updated, err := contentUC.RefreshPodcastListenCounts(ctx)
+		if err != nil {
+			logger.Fatal("Failed to refresh listen counts", logger.Field("error", err))
+		}
+
+		logger.Info("Listen count refresh completed", logger.Field("updated", updated))
+		return
+	}
+
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
 
 	// Initialize router
 	router := gin.New()
 
+	// Take client IPs from X-Forwarded-For only when our own proxies set it, as clients could
+	// otherwise pick the IP they are limited and logged by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", logger.Field("error", err))
+	}
+
 	// Middlewares
 	router.Use(middleware.LoggingMiddleware())
+	router.Use(middleware.MetricsMiddleware(metrics.NewHTTPMetrics(registry)))
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
+	router.Use(middleware.CORS(&cfg.CORS))
+	router.Use(middleware.ConcurrencyLimitMiddleware(authUC, cfg.Server.MaxConcurrentRequests, cfg.Server.ConcurrencyLimitExemptions))
 
 	// Auth middleware
 	authMiddleware := middleware.AuthMiddleware(authUC)
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		err := database.PostgresHealthCheck(db)
-		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status":  "error",
-				"message": "Database connection failed",
-			})
-			return
-		}
+	// Metrics endpoint
+	router.GET("/metrics", metrics.Handler(registry))
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "content-service",
-		})
-	})
+	// Health check endpoints
+	healthChecker := health.NewChecker("content-service")
+	healthChecker.AddRequired("postgres", db.PingContext)
+	healthChecker.AddRequired("storage", store.HealthCheck)
+	healthChecker.RegisterRoutes(router)
 
 	// Initialize HTTP handlers
 	contentHandler := contentHttp.NewHandler(contentUC)
+	auditHandler := auditHttp.NewHandler(auditUC)
 
 	// Register routes
 	v1 := router.Group("/api/v1")
-	contentHandler.RegisterRoutes(v1, authMiddleware)
+	contentHandler.RegisterRoutes(v1, authMiddleware, middleware.OptionalAuthMiddleware(authUC))
+	contentHandler.RegisterShortLinkRoutes(router)
+	auditHandler.RegisterRoutes(v1, authMiddleware)
 
 	// Start server
 	srv := &http.Server{
@@ -150,32 +212,144 @@ func main() {
 			logger.Fatal("Failed to start server", logger.Field("error", err))
 		}
 	}()
+
+	// Setup gRPC server
+	grpcPort := cfg.Content.GRPCPort
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		logger.Fatal("Failed to listen for gRPC", logger.Field("error", err))
+	}
+
+	grpcServer := grpc.NewServer()
+	grpcHandler := contentGrpc.NewHandler(contentUC)
+	pb.RegisterContentServiceServer(grpcServer, grpcHandler)
+	healthpb.RegisterHealthServer(grpcServer, grpcHealth.NewServer())
+
+	// Start the gRPC server in a goroutine
+	go func() {
+		logger.Info("Content gRPC service listening", logger.Field("port", grpcPort))
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Fatal("Failed to start gRPC server", logger.Field("error", err))
+		}
+	}()
 	
+	// Background jobs run until shutdown cancels their context, and are waited for before exiting
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	var backgroundJobs sync.WaitGroup
+
 	// Start a background goroutine to sync RSS feeds periodically
+	backgroundJobs.Add(1)
 	go func() {
+		defer backgroundJobs.Done()
+		
 		// Wait for initial delay before starting
-		time.Sleep(1 * time.Minute)
+		select {
+		case <-time.After(cfg.Content.SyncInitialDelay):
+		case <-backgroundCtx.Done():
+			return
+		}
 		
-		// Create a ticker to run every 6 hours
-		ticker := time.NewTicker(6 * time.Hour)
+		// Check for due podcasts often enough for those with intervals shorter than the default
+		checkInterval := cfg.Content.SyncInterval
+		if checkInterval > maxSyncCheckInterval {
+			checkInterval = maxSyncCheckInterval
+		}
+		ticker := time.NewTicker(checkInterval)
 		defer ticker.Stop()
 		
 		// Run sync once at startup
-		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
 		logger.Info("Running initial RSS feed sync")
-		_, err := contentUC.SyncAllPodcasts(ctx)
-		if err != nil {
-			logger.Error("Failed to sync podcasts", logger.Field("error", err))
-		}
-		cancel()
+		syncAllPodcasts(backgroundCtx, contentUC)
 		
 		// Run sync at regular intervals
-		for range ticker.C {
-			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
-			logger.Info("Running scheduled RSS feed sync")
-			_, err := contentUC.SyncAllPodcasts(ctx)
+		for {
+			select {
+			case <-ticker.C:
+				logger.Info("Running scheduled RSS feed sync")
+				syncAllPodcasts(backgroundCtx, contentUC)
+			case <-backgroundCtx.Done():
+				return
+			}
+		}
+	}()
+
+	// Start a background goroutine to recount podcast listens, which listings sorted by listens
+	// read, so the sort lags new listens by at most the refresh interval
+	if cfg.Content.ListenCountRefresh > 0 {
+		backgroundJobs.Add(1)
+		go func() {
+			defer backgroundJobs.Done()
+			
+			ticker := time.NewTicker(cfg.Content.ListenCountRefresh)
+			defer ticker.Stop()
+			
+			for {
+				select {
+				case <-ticker.C:
+				case <-backgroundCtx.Done():
+					return
+				}
+				
+				ctx, cancel := context.WithTimeout(backgroundCtx, 30*time.Minute)
+				updated, err := contentUC.RefreshPodcastListenCounts(ctx)
+				if err != nil {
+					logger.Error("Failed to refresh listen counts", logger.Field("error", err))
+				} else if updated > 0 {
+					logger.Info("Refreshed listen counts", logger.Field("updated", updated))
+				}
+				cancel()
+			}
+		}()
+	}
+
+	// Start a background goroutine to retry failed feed syncs with backoff
+	backgroundJobs.Add(1)
+	go func() {
+		defer backgroundJobs.Done()
+		
+		ticker := time.NewTicker(1 * time.Minute)
+		defer ticker.Stop()
+		
+		for {
+			select {
+			case <-ticker.C:
+			case <-backgroundCtx.Done():
+				return
+			}
+			
+			ctx, cancel := context.WithTimeout(backgroundCtx, 30*time.Minute)
+			results, err := contentUC.RetryFailedSyncs(ctx)
 			if err != nil {
-				logger.Error("Failed to sync podcasts", logger.Field("error", err))
+				logger.Error("Failed to retry podcast syncs", logger.Field("error", err))
+			} else if len(results) > 0 {
+				logger.Info("Retried failed RSS feed syncs", logger.Field("total", len(results)))
+			}
+			cancel()
+		}
+	}()
+
+	// Start a background goroutine to deliver queued webhook events, retrying failed deliveries
+	backgroundJobs.Add(1)
+	go func() {
+		defer backgroundJobs.Done()
+		
+		ticker := time.NewTicker(15 * time.Second)
+		defer ticker.Stop()
+		
+		for {
+			select {
+			case <-ticker.C:
+			case <-backgroundCtx.Done():
+				return
+			}
+			
+			ctx, cancel := context.WithTimeout(backgroundCtx, 5*time.Minute)
+			attempted, err := webhookService.DeliverPending(ctx)
+			if err != nil {
+				logger.Error("Failed to deliver webhook events", logger.Field("error", err))
+			} else if attempted > 0 {
+				logger.Info("Attempted webhook deliveries", logger.Field("total", attempted))
 			}
 			cancel()
 		}
@@ -187,6 +361,10 @@ func main() {
 	<-quit
 	logger.Info("Shutting down server...")
 
+	// Stop the background jobs. A running sync starts no further podcasts, and the podcasts it
+	// is in the middle of roll back their transactions rather than being cut off by the exit.
+	stopBackground()
+
 	// Create a deadline for the shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -196,5 +374,37 @@ func main() {
 		logger.Fatal("Server forced to shutdown", logger.Field("error", err))
 	}
 
+	// Shut down the gRPC server
+	grpcServer.GracefulStop()
+
+	// Wait for the background jobs to wind down
+	backgroundDone := make(chan struct{})
+	go func() {
+		backgroundJobs.Wait()
+		close(backgroundDone)
+	}()
+	select {
+	case <-backgroundDone:
+	case <-time.After(backgroundShutdownTimeout):
+		logger.Warn("Background jobs did not stop in time, abandoning them",
+			logger.Field("timeout", backgroundShutdownTimeout))
+	}
+
 	logger.Info("Server exiting")
+}
+
+// syncAllPodcasts runs a full RSS feed sync, which shutdown may interrupt through ctx
+func syncAllPodcasts(ctx context.Context, contentUC contentUsecase.Usecase) {
+	syncCtx, cancel := context.WithTimeout(ctx, 1*time.Hour)
+	defer cancel()
+	
+	_, err := contentUC.SyncAllPodcasts(syncCtx, false)
+	switch {
+	case errors.Is(err, contentModels.ErrFullSyncInProgress):
+		logger.Info("Skipping scheduled RSS feed sync while a full sync is in progress")
+	case err != nil && ctx.Err() != nil:
+		logger.Warn("RSS feed sync interrupted by shutdown")
+	case err != nil:
+		logger.Error("Failed to sync podcasts", logger.Field("error", err))
+	}
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
@@ -13,9 +14,12 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/cache"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/health"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
 	authUsecase "github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
 	recommendationRepo "github.com/MHK-26/pod_platfrom_go/pkg/recommendation/repository/postgres"
@@ -24,9 +28,16 @@ import (
 	recommendationGrpc "github.com/MHK-26/pod_platfrom_go/pkg/recommendation/delivery/grpc"
 	pb "github.com/MHK-26/pod_platfrom_go/api/proto/recommendation"
 	"google.golang.org/grpc"
+	grpcHealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
+	// Define command line flags
+	computeTrending := flag.Bool("compute-trending", false, "Only compute the trending podcasts of each time range and exit")
+	computeSimilarity := flag.Bool("compute-similarity", false, "Only compute the similar podcasts of new and changed podcasts and exit")
+	flag.Parse()
+
 	// Initialize logger
 	logger.Initialize("recommendation-service", "info")
 	defer logger.Close()
@@ -36,6 +47,9 @@ func main() {
 	if err != nil {
 		logger.Fatal("Failed to load config", logger.Field("error", err))
 	}
+	if err := cfg.Validate(); err != nil {
+		logger.Fatal("Invalid config", logger.Field("error", err))
+	}
 
 	// Set Gin mode
 	gin.SetMode(cfg.Server.Mode)
@@ -47,45 +61,82 @@ func main() {
 	}
 	defer database.CloseDB(db)
 
+	// Initialize the metrics registry served on /metrics
+	registry := metrics.NewRegistry()
+
 	// Initialize repositories
 	recommendationRepository := recommendationRepo.NewRepository(db)
 
+	// Initialize the recommendation cache, a no-op when Redis isn't configured
+	recommendationCache := cache.NewCache(&cfg.Redis)
+
 	// Initialize usecases
-	recommendationUC := recommendationUsecase.NewUsecase(recommendationRepository, cfg, 10*time.Second)
-	authUC := authUsecase.NewUsecase(nil, cfg, 10*time.Second) // We only need token verification
+	recommendationUC := recommendationUsecase.NewUsecase(recommendationRepository, recommendationCache, cfg, 10*time.Second)
+	authUC := authUsecase.NewUsecase(nil, nil, nil, nil, cfg, 10*time.Second) // We only need token verification
+
+	// If compute-trending flag is set, precompute trending podcasts and exit
+	if *computeTrending {
+		logger.Info("Starting trending computation")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+		defer cancel()
+
+		if err := recommendationUC.RefreshTrending(ctx); err != nil {
+			logger.Fatal("Failed to compute trending podcasts", logger.Field("error", err))
+		}
+
+		logger.Info("Trending computation completed")
+		return
+	}
+
+	// If compute-similarity flag is set, recompute similar podcasts and exit
+	if *computeSimilarity {
+		logger.Info("Starting similarity computation")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Hour)
+		defer cancel()
+
+		if err := recommendationUC.RefreshSimilarPodcasts(ctx); err != nil {
+			logger.Fatal("Failed to compute similar podcasts", logger.Field("error", err))
+		}
+
+		logger.Info("Similarity computation completed")
+		return
+	}
 
 	// Setup HTTP server
 	router := gin.New()
+
+	// Take client IPs from X-Forwarded-For only when our own proxies set it, as clients could
+	// otherwise pick the IP they are limited and logged by
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", logger.Field("error", err))
+	}
+
 	router.Use(middleware.LoggingMiddleware())
+	router.Use(middleware.MetricsMiddleware(metrics.NewHTTPMetrics(registry)))
 	router.Use(gin.Recovery())
-	router.Use(middleware.CORS())
+	router.Use(middleware.CORS(&cfg.CORS))
+	router.Use(middleware.ConcurrencyLimitMiddleware(authUC, cfg.Server.MaxConcurrentRequests, cfg.Server.ConcurrencyLimitExemptions))
 
 	// Auth middleware
 	authMiddleware := middleware.AuthMiddleware(authUC)
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		err := database.PostgresHealthCheck(db)
-		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{
-				"status":  "error",
-				"message": "Database connection failed",
-			})
-			return
-		}
+	// Metrics endpoint
+	router.GET("/metrics", metrics.Handler(registry))
 
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": "recommendation-service",
-		})
-	})
+	// Health check endpoints
+	healthChecker := health.NewChecker("recommendation-service")
+	healthChecker.AddRequired("postgres", db.PingContext)
+	healthChecker.AddOptional("redis", recommendationCache.Ping)
+	healthChecker.RegisterRoutes(router)
 
 	// Initialize HTTP handlers
 	recommendationHandler := recommendationHttp.NewHandler(recommendationUC)
 
 	// Register HTTP routes
 	v1 := router.Group("/api/v1")
-	recommendationHandler.RegisterRoutes(v1, authMiddleware)
+	recommendationHandler.RegisterRoutes(v1, authMiddleware, middleware.OptionalAuthMiddleware(authUC))
 
 	// Start HTTP server
 	httpSrv := &http.Server{
@@ -114,6 +165,7 @@ func main() {
 	grpcServer := grpc.NewServer()
 	grpcHandler := recommendationGrpc.NewHandler(recommendationUC)
 	pb.RegisterRecommendationServiceServer(grpcServer, grpcHandler)
+	healthpb.RegisterHealthServer(grpcServer, grpcHealth.NewServer())
 
 	// Start the gRPC server in a goroutine
 	go func() {
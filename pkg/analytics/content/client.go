@@ -0,0 +1,70 @@
+// pkg/analytics/content/client.go
+package content
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	pb "github.com/MHK-26/pod_platfrom_go/api/proto/content"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Client looks up podcast and episode metadata through the content service's gRPC interface
+type Client struct {
+	conn   *grpc.ClientConn
+	client pb.ContentServiceClient
+	health healthpb.HealthClient
+}
+
+// NewClient creates a client for the content service's gRPC server at addr (host:port).
+// The connection is established lazily, so the content service needn't be up yet.
+func NewClient(addr string) (*Client, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create content service client: %w", err)
+	}
+
+	return &Client{
+		conn:   conn,
+		client: pb.NewContentServiceClient(conn),
+		health: healthpb.NewHealthClient(conn),
+	}, nil
+}
+
+// GetPodcastTitle gets the title of a podcast
+func (c *Client) GetPodcastTitle(ctx context.Context, podcastID uuid.UUID) (string, error) {
+	podcast, err := c.client.GetPodcast(ctx, &pb.GetPodcastRequest{Id: podcastID.String()})
+	if err != nil {
+		return "", err
+	}
+	return podcast.Title, nil
+}
+
+// GetEpisodeTitle gets the title of an episode
+func (c *Client) GetEpisodeTitle(ctx context.Context, episodeID uuid.UUID) (string, error) {
+	episode, err := c.client.GetEpisode(ctx, &pb.GetEpisodeRequest{Id: episodeID.String()})
+	if err != nil {
+		return "", err
+	}
+	return episode.Title, nil
+}
+
+// HealthCheck checks the content service reports itself as serving
+func (c *Client) HealthCheck(ctx context.Context) error {
+	response, err := c.health.Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if response.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("content service is %s", response.Status)
+	}
+	return nil
+}
+
+// Close closes the connection to the content service
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
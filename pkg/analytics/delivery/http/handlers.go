@@ -2,6 +2,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -37,11 +38,15 @@ func NewHandler(usecase usecase.Usecase) *Handler {
 // @Router /analytics/track-listen [post]
 func (h *Handler) TrackListen(c *gin.Context) {
 	var req models.TrackListenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
+	// Classify the requesting client when the player doesn't report its own user agent
+	if req.UserAgent == "" {
+		req.UserAgent = c.Request.UserAgent()
+	}
+
 	// Get authenticated user ID if available
 	userID, exists := c.Get("user_id")
 	if exists {
@@ -66,7 +71,7 @@ func (h *Handler) TrackListen(c *gin.Context) {
 
 // GetEpisodeAnalytics godoc
 // @Summary Get episode analytics
-// @Description Get analytics for a specific episode
+// @Description Get analytics for a specific episode (owning podcaster only)
 // @Tags analytics
 // @Accept json
 // @Produce json
@@ -79,6 +84,7 @@ func (h *Handler) TrackListen(c *gin.Context) {
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /analytics/episodes/{episode_id} [get]
 func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
@@ -90,6 +96,19 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 		return
 	}
 
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
 	// Parse query parameters
 	startDateStr := c.DefaultQuery("start_date", "")
 	endDateStr := c.DefaultQuery("end_date", "")
@@ -106,7 +125,7 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to 30 days ago
-		startDate = time.Now().AddDate(0, 0, -30)
+		startDate = time.Now().UTC().AddDate(0, 0, -30)
 	}
 
 	if endDateStr != "" {
@@ -117,7 +136,7 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to now
-		endDate = time.Now()
+		endDate = time.Now().UTC()
 	}
 
 	// Prepare analytics params
@@ -126,10 +145,21 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 		EndDate:   endDate,
 		Interval:  interval,
 	}
+	if !utils.Validate(c, &params) {
+		return
+	}
 
 	// Get episode analytics
-	analytics, err := h.usecase.GetEpisodeAnalytics(c.Request.Context(), episodeID, params)
+	analytics, err := h.usecase.GetEpisodeAnalytics(c.Request.Context(), episodeID, userIDParsed, params)
 	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to view analytics for this episode")
+			return
+		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get episode analytics")
 		return
 	}
@@ -139,7 +169,7 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 
 // GetPodcastAnalytics godoc
 // @Summary Get podcast analytics
-// @Description Get analytics for a specific podcast
+// @Description Get analytics for a specific podcast (owning podcaster only)
 // @Tags analytics
 // @Accept json
 // @Produce json
@@ -152,6 +182,7 @@ func (h *Handler) GetEpisodeAnalytics(c *gin.Context) {
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /analytics/podcasts/{podcast_id} [get]
 func (h *Handler) GetPodcastAnalytics(c *gin.Context) {
@@ -163,6 +194,19 @@ func (h *Handler) GetPodcastAnalytics(c *gin.Context) {
 		return
 	}
 
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
 	// Parse query parameters
 	startDateStr := c.DefaultQuery("start_date", "")
 	endDateStr := c.DefaultQuery("end_date", "")
@@ -179,7 +223,7 @@ func (h *Handler) GetPodcastAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to 30 days ago
-		startDate = time.Now().AddDate(0, 0, -30)
+		startDate = time.Now().UTC().AddDate(0, 0, -30)
 	}
 
 	if endDateStr != "" {
@@ -190,7 +234,7 @@ func (h *Handler) GetPodcastAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to now
-		endDate = time.Now()
+		endDate = time.Now().UTC()
 	}
 
 	// Prepare analytics params
@@ -199,10 +243,21 @@ func (h *Handler) GetPodcastAnalytics(c *gin.Context) {
 		EndDate:   endDate,
 		Interval:  interval,
 	}
+	if !utils.Validate(c, &params) {
+		return
+	}
 
 	// Get podcast analytics
-	analytics, err := h.usecase.GetPodcastAnalytics(c.Request.Context(), podcastID, params)
+	analytics, err := h.usecase.GetPodcastAnalytics(c.Request.Context(), podcastID, userIDParsed, params)
 	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to view analytics for this podcast")
+			return
+		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get podcast analytics")
 		return
 	}
@@ -260,7 +315,7 @@ func (h *Handler) GetPodcasterAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to 30 days ago
-		startDate = time.Now().AddDate(0, 0, -30)
+		startDate = time.Now().UTC().AddDate(0, 0, -30)
 	}
 
 	if endDateStr != "" {
@@ -271,7 +326,7 @@ func (h *Handler) GetPodcasterAnalytics(c *gin.Context) {
 		}
 	} else {
 		// Default to now
-		endDate = time.Now()
+		endDate = time.Now().UTC()
 	}
 
 	// Prepare analytics params
@@ -299,7 +354,8 @@ func (h *Handler) GetPodcasterAnalytics(c *gin.Context) {
 // @Security BearerAuth
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Page size (default: 20)"
-// @Success 200 {object} utils.PaginationResponse
+// @Param cursor query string false "Opts into cursor pagination; empty for the first page, then the next_cursor of the previous page"
+// @Success 200 {object} utils.PaginatedResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /analytics/history [get]
@@ -320,6 +376,22 @@ func (h *Handler) GetListeningHistory(c *gin.Context) {
 	// Get pagination parameters
 	params := utils.GetPaginationParams(c)
 
+	cursor, useCursor, err := utils.GetCursorParam(c)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	if useCursor {
+		history, next, err := h.usecase.GetListeningHistoryAfter(c.Request.Context(), userIDParsed, cursor, params.PageSize)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get listening history")
+			return
+		}
+
+		utils.RespondWithCursorPagination(c, history, next, params.PageSize)
+		return
+	}
+
 	// Get listening history
 	history, totalCount, err := h.usecase.GetListeningHistory(c.Request.Context(), userIDParsed, params.Page, params.PageSize)
 	if err != nil {
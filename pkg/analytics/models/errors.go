@@ -0,0 +1,12 @@
+// pkg/analytics/models/errors.go
+package models
+
+import "github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
+
+// Errors returned by the analytics repository and usecase
+var (
+	ErrPodcastNotFound = errs.NotFound("podcast not found")
+	ErrEpisodeNotFound = errs.NotFound("episode not found")
+
+	ErrNotAuthorized = errs.NotAuthorized("not authorized")
+)
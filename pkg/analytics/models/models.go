@@ -18,13 +18,15 @@ type ListenEvent struct {
 	Completed   bool      `json:"completed" db:"completed"`
 	IPAddress   string    `json:"ip_address" db:"ip_address"`
 	UserAgent   string    `json:"user_agent" db:"user_agent"`
+	ClientName  string    `json:"client_name" db:"client_name"`
+	DeviceType  string    `json:"device_type" db:"device_type"`
 	CountryCode string    `json:"country_code" db:"country_code"`
 	City        string    `json:"city" db:"city"`
 }
 
 // TrackListenRequest represents a request to track a listen event
 type TrackListenRequest struct {
-	ListenerID  uuid.UUID `json:"listener_id" validate:"required"`
+	ListenerID  uuid.UUID `json:"listener_id"` // the authenticated user, unset for anonymous listens
 	EpisodeID   uuid.UUID `json:"episode_id" validate:"required"`
 	Source      string    `json:"source" validate:"required,oneof=mobile web embed"`
 	Duration    int       `json:"duration" validate:"required,min=1"`
@@ -37,10 +39,10 @@ type TrackListenRequest struct {
 
 // ListenStats represents listening statistics
 type ListenStats struct {
-	TotalListens         int     `json:"total_listens"`
-	UniqueListeners      int     `json:"unique_listeners"`
-	AverageListenDuration float64 `json:"average_listen_duration"`
-	CompletionRate       float64 `json:"completion_rate"`
+	TotalListens         int     `json:"total_listens" db:"total_listens"`
+	UniqueListeners      int     `json:"unique_listeners" db:"unique_listeners"`
+	AverageListenDuration float64 `json:"average_listen_duration" db:"average_listen_duration"`
+	CompletionRate       float64 `json:"completion_rate" db:"completion_rate"`
 }
 
 // EpisodeAnalytics represents analytics for an episode
@@ -48,6 +50,7 @@ type EpisodeAnalytics struct {
 	EpisodeID           uuid.UUID   `json:"episode_id"`
 	Title               string      `json:"title"`
 	ListenStats         ListenStats `json:"listen_stats"`
+	SessionStats        ListenStats `json:"session_stats"` // listen events stitched into listening sessions
 	ListensByDay        []TimePoint `json:"listens_by_day"`
 	ListensBySource     []SourceStat `json:"listens_by_source"`
 	ListensByCountry    []GeoStat   `json:"listens_by_country"`
@@ -60,6 +63,7 @@ type PodcastAnalytics struct {
 	PodcastID          uuid.UUID     `json:"podcast_id"`
 	Title              string        `json:"title"`
 	ListenStats        ListenStats   `json:"listen_stats"`
+	SessionStats       ListenStats   `json:"session_stats"` // listen events stitched into listening sessions
 	ListensByDay       []TimePoint   `json:"listens_by_day"`
 	ListensByEpisode   []EpisodeStat `json:"listens_by_episode"`
 	ListensBySource    []SourceStat  `json:"listens_by_source"`
@@ -79,6 +83,7 @@ type PodcasterAnalytics struct {
 	SubscribersByDay   []TimePoint    `json:"subscribers_by_day"`
 	ListensByCountry   []GeoStat      `json:"listens_by_country"`
 	ListensByDevice    []DeviceStat   `json:"listens_by_device"`
+	ListensByClient    []ClientStat   `json:"listens_by_client"`
 }
 
 // TimePoint represents a data point with a timestamp
@@ -89,12 +94,12 @@ type TimePoint struct {
 
 // EpisodeStat represents statistics for an episode
 type EpisodeStat struct {
-	EpisodeID           uuid.UUID `json:"episode_id"`
-	Title               string    `json:"title"`
-	Listens             int       `json:"listens"`
-	UniqueListeners     int       `json:"unique_listeners"`
-	AverageListenDuration float64  `json:"average_listen_duration"`
-	CompletionRate      float64   `json:"completion_rate"`
+	EpisodeID           uuid.UUID `json:"episode_id" db:"episode_id"`
+	Title               string    `json:"title" db:"title"`
+	Listens             int       `json:"listens" db:"listens"`
+	UniqueListeners     int       `json:"unique_listeners" db:"unique_listeners"`
+	AverageListenDuration float64  `json:"average_listen_duration" db:"average_listen_duration"`
+	CompletionRate      float64   `json:"completion_rate" db:"completion_rate"`
 }
 
 // PodcastStat represents statistics for a podcast
@@ -121,8 +126,14 @@ type GeoStat struct {
 
 // DeviceStat represents statistics for a device type
 type DeviceStat struct {
-	DeviceType string `json:"device_type"`
-	Count      int    `json:"count"`
+	DeviceType string `json:"device_type" db:"device_type"`
+	Count      int    `json:"count" db:"count"`
+}
+
+// ClientStat represents statistics for a listening client app
+type ClientStat struct {
+	ClientName string `json:"client_name" db:"client_name"`
+	Count      int    `json:"count" db:"count"`
 }
 
 // ListeningHistoryItem represents an item in the listening history
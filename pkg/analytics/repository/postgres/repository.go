@@ -6,11 +6,12 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
-	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/pagination"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
 )
 
 // Repository defines the methods for the analytics repository
@@ -20,6 +21,11 @@ type Repository interface {
 	GetPodcastListens(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams) (*models.ListenStats, []models.TimePoint, []models.EpisodeStat, error)
 	GetPodcasterListens(ctx context.Context, podcasterID uuid.UUID, params models.AnalyticsParams) (*models.PodcasterAnalytics, error)
 	GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ListeningHistoryItem, int, error)
+	GetListeningHistoryAfter(ctx context.Context, listenerID uuid.UUID, after *pagination.Cursor, limit int) ([]*models.ListeningHistoryItem, error)
+	GetEpisodeSessionStats(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams, sessionGap time.Duration) (*models.ListenStats, error)
+	GetPodcastSessionStats(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams, sessionGap time.Duration) (*models.ListenStats, error)
+	GetPodcastOwnerID(ctx context.Context, podcastID uuid.UUID) (uuid.UUID, error)
+	GetEpisodeOwnerID(ctx context.Context, episodeID uuid.UUID) (uuid.UUID, error)
 }
 
 type repository struct {
@@ -36,9 +42,9 @@ func (r *repository) TrackListen(ctx context.Context, event *models.ListenEvent)
 	query := `
 		INSERT INTO listen_events (
 			id, listener_id, episode_id, source, started_at, duration, completed,
-			ip_address, user_agent, country_code, city
+			ip_address, user_agent, country_code, city, client_name, device_type
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 		) RETURNING id
 	`
 
@@ -47,7 +53,7 @@ func (r *repository) TrackListen(ctx context.Context, event *models.ListenEvent)
 	}
 
 	if event.StartedAt.IsZero() {
-		event.StartedAt = time.Now()
+		event.StartedAt = time.Now().UTC()
 	}
 
 	err := r.db.QueryRowContext(
@@ -64,6 +70,8 @@ func (r *repository) TrackListen(ctx context.Context, event *models.ListenEvent)
 		event.UserAgent,
 		event.CountryCode,
 		event.City,
+		event.ClientName,
+		event.DeviceType,
 	).Scan(&event.ID)
 
 	// Also update playback history
@@ -97,13 +105,15 @@ func (r *repository) TrackListen(ctx context.Context, event *models.ListenEvent)
 
 // GetEpisodeListens gets listen statistics for an episode
 func (r *repository) GetEpisodeListens(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams) (*models.ListenStats, []models.TimePoint, error) {
+	defer database.TrackSlowQuery("analytics.GetEpisodeListens")()
+
 	// Get episode stats
 	statsQuery := `
 		SELECT 
 			COUNT(*) as total_listens,
 			COUNT(DISTINCT listener_id) as unique_listeners,
-			AVG(duration) as average_listen_duration,
-			(SUM(CASE WHEN completed THEN 1 ELSE 0 END)::float / COUNT(*)) * 100 as completion_rate
+			COALESCE(AVG(duration), 0) as average_listen_duration,
+			COALESCE((SUM(CASE WHEN completed THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*), 0)) * 100, 0) as completion_rate
 		FROM listen_events
 		WHERE episode_id = $1
 		AND started_at BETWEEN $2 AND $3
@@ -116,70 +126,18 @@ func (r *repository) GetEpisodeListens(ctx context.Context, episodeID uuid.UUID,
 	}
 
 	// Get timeseries data
-	var timeFormat string
-	var groupBy string
-	
-	switch params.Interval {
-	case "week":
-		timeFormat = "YYYY-IW" // ISO week
-		groupBy = "date_trunc('week', started_at)"
-	case "month":
-		timeFormat = "YYYY-MM"
-		groupBy = "date_trunc('month', started_at)"
-	default: // day
-		timeFormat = "YYYY-MM-DD"
-		groupBy = "date_trunc('day', started_at)"
-	}
-
-	timeSeriesQuery := `
-		SELECT 
-			to_char(${groupBy}, '${timeFormat}') as day_str,
-			${groupBy} as timestamp,
-			COUNT(*) as count
-		FROM listen_events
-		WHERE episode_id = $1
-		AND started_at BETWEEN $2 AND $3
-		GROUP BY day_str, ${groupBy}
-		ORDER BY ${groupBy}
-	`
-
-	// Replace placeholders
-	timeSeriesQuery = strings.ReplaceAll(timeSeriesQuery, "${groupBy}", groupBy)
-	timeSeriesQuery = strings.ReplaceAll(timeSeriesQuery, "${timeFormat}", timeFormat)
-	timeSeriesQuery = sqlx.Rebind(sqlx.DOLLAR, timeSeriesQuery)
-	
-
-	rows, err := r.db.QueryxContext(ctx, timeSeriesQuery, episodeID, params.StartDate, params.EndDate)
+	timePoints, err := r.getListenTimeSeries(ctx, params, "FROM listen_events le WHERE le.episode_id = $1", episodeID)
 	if err != nil {
 		return &stats, nil, err
 	}
-	defer rows.Close()
-
-	var timePoints []models.TimePoint
-	for rows.Next() {
-		var tp struct {
-			DayStr    string    `db:"day_str"`
-			Timestamp time.Time `db:"timestamp"`
-			Count     int       `db:"count"`
-		}
-		if err := rows.StructScan(&tp); err != nil {
-			return &stats, nil, err
-		}
-		timePoints = append(timePoints, models.TimePoint{
-			Timestamp: tp.Timestamp,
-			Value:     tp.Count,
-		})
-	}
-
-	if err := rows.Err(); err != nil {
-		return &stats, nil, err
-	}
 
 	return &stats, timePoints, nil
 }
 
 // GetPodcasterListens gets listen statistics for all podcasts by a podcaster
 func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.UUID, params models.AnalyticsParams) (*models.PodcasterAnalytics, error) {
+	defer database.TrackSlowQuery("analytics.GetPodcasterListens")()
+
 	// Initialize the result
 	result := &models.PodcasterAnalytics{
 		PodcasterID: podcasterID,
@@ -210,36 +168,15 @@ func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.U
 	}
 
 	// Get listens by day
-	listensByDayQuery := `
-		SELECT 
-			date_trunc('day', le.started_at) as timestamp,
-			COUNT(*) as value
-		FROM listen_events le
-		JOIN episodes e ON le.episode_id = e.id
-		JOIN podcasts p ON e.podcast_id = p.id
-		WHERE p.podcaster_id = $1
-		AND le.started_at BETWEEN $2 AND $3
-		GROUP BY timestamp
-		ORDER BY timestamp
-	`
-
-	rows, err := r.db.QueryxContext(ctx, listensByDayQuery, podcasterID, params.StartDate, params.EndDate)
+	result.ListensByDay, err = r.getListenTimeSeries(
+		ctx,
+		params,
+		"FROM listen_events le JOIN episodes e ON le.episode_id = e.id JOIN podcasts p ON e.podcast_id = p.id WHERE p.podcaster_id = $1",
+		podcasterID,
+	)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var tp models.TimePoint
-		if err := rows.StructScan(&tp); err != nil {
-			return nil, err
-		}
-		result.ListensByDay = append(result.ListensByDay, tp)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
 
 	// Get listens by podcast
 	listensByPodcastQuery := `
@@ -257,7 +194,7 @@ func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.U
 		ORDER BY listens DESC
 	`
 
-	rows, err = r.db.QueryxContext(ctx, listensByPodcastQuery, podcasterID, params.StartDate, params.EndDate)
+	rows, err := r.db.QueryxContext(ctx, listensByPodcastQuery, podcasterID, params.StartDate, params.EndDate)
 	if err != nil {
 		return nil, err
 	}
@@ -311,21 +248,14 @@ func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.U
 	// Get listens by device
 	listensByDeviceQuery := `
 		SELECT 
-			CASE 
-				WHEN le.user_agent LIKE '%Android%' THEN 'Android'
-				WHEN le.user_agent LIKE '%iPhone%' THEN 'iPhone'
-				WHEN le.user_agent LIKE '%iPad%' THEN 'iPad'
-				WHEN le.user_agent LIKE '%Windows%' THEN 'Windows'
-				WHEN le.user_agent LIKE '%Mac%' THEN 'Mac'
-				ELSE 'Other'
-			END as device_type,
+			le.device_type,
 			COUNT(*) as count
 		FROM listen_events le
 		JOIN episodes e ON le.episode_id = e.id
 		JOIN podcasts p ON e.podcast_id = p.id
 		WHERE p.podcaster_id = $1
 		AND le.started_at BETWEEN $2 AND $3
-		GROUP BY device_type
+		GROUP BY le.device_type
 		ORDER BY count DESC
 	`
 
@@ -347,6 +277,38 @@ func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.U
 		return nil, err
 	}
 
+	// Get listens by client app
+	listensByClientQuery := `
+		SELECT 
+			le.client_name,
+			COUNT(*) as count
+		FROM listen_events le
+		JOIN episodes e ON le.episode_id = e.id
+		JOIN podcasts p ON e.podcast_id = p.id
+		WHERE p.podcaster_id = $1
+		AND le.started_at BETWEEN $2 AND $3
+		GROUP BY le.client_name
+		ORDER BY count DESC
+	`
+
+	rows, err = r.db.QueryxContext(ctx, listensByClientQuery, podcasterID, params.StartDate, params.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cs models.ClientStat
+		if err := rows.StructScan(&cs); err != nil {
+			return nil, err
+		}
+		result.ListensByClient = append(result.ListensByClient, cs)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	// Get total subscribers
 	subscribersQuery := `
 		SELECT COUNT(*) 
@@ -365,6 +327,8 @@ func (r *repository) GetPodcasterListens(ctx context.Context, podcasterID uuid.U
 
 // GetListeningHistory gets the listening history for a user
 func (r *repository) GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ListeningHistoryItem, int, error) {
+	defer database.TrackSlowQuery("analytics.GetListeningHistory")()
+
 	// Get total count
 	countQuery := `
 		SELECT COUNT(*)
@@ -409,13 +373,15 @@ func (r *repository) GetListeningHistory(ctx context.Context, listenerID uuid.UU
 
 // GetPodcastListens gets listen statistics for a podcast
 func (r *repository) GetPodcastListens(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams) (*models.ListenStats, []models.TimePoint, []models.EpisodeStat, error) {
+	defer database.TrackSlowQuery("analytics.GetPodcastListens")()
+
 	// Get podcast stats
 	statsQuery := `
 		SELECT 
 			COUNT(*) as total_listens,
 			COUNT(DISTINCT listener_id) as unique_listeners,
-			AVG(duration) as average_listen_duration,
-			(SUM(CASE WHEN completed THEN 1 ELSE 0 END)::float / COUNT(*)) * 100 as completion_rate
+			COALESCE(AVG(duration), 0) as average_listen_duration,
+			COALESCE((SUM(CASE WHEN completed THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*), 0)) * 100, 0) as completion_rate
 		FROM listen_events le
 		JOIN episodes e ON le.episode_id = e.id
 		WHERE e.podcast_id = $1
@@ -429,57 +395,15 @@ func (r *repository) GetPodcastListens(ctx context.Context, podcastID uuid.UUID,
 	}
 
 	// Get timeseries data
-	var timeFormat string
-	var groupBy string
-	
-	switch params.Interval {
-	case "week":
-		timeFormat = "YYYY-IW" // ISO week
-		groupBy = "date_trunc('week', le.started_at)"
-	case "month":
-		timeFormat = "YYYY-MM"
-		groupBy = "date_trunc('month', le.started_at)"
-	default: // day
-		timeFormat = "YYYY-MM-DD"
-		groupBy = "date_trunc('day', le.started_at)"
-	}
-
-	timeSeriesQuery := fmt.Sprintf(`
-		SELECT 
-			%s as timestamp,
-			COUNT(*) as count
-		FROM listen_events le
-		JOIN episodes e ON le.episode_id = e.id
-		WHERE e.podcast_id = $1
-		AND le.started_at BETWEEN $2 AND $3
-		GROUP BY timestamp
-		ORDER BY timestamp
-	`, groupBy)
-
-	rows, err := r.db.QueryxContext(ctx, timeSeriesQuery, podcastID, params.StartDate, params.EndDate)
+	timePoints, err := r.getListenTimeSeries(
+		ctx,
+		params,
+		"FROM listen_events le JOIN episodes e ON le.episode_id = e.id WHERE e.podcast_id = $1",
+		podcastID,
+	)
 	if err != nil {
 		return &stats, nil, nil, err
 	}
-	defer rows.Close()
-
-	var timePoints []models.TimePoint
-	for rows.Next() {
-		var tp struct {
-			Timestamp time.Time `db:"timestamp"`
-			Count     int       `db:"count"`
-		}
-		if err := rows.StructScan(&tp); err != nil {
-			return &stats, nil, nil, err
-		}
-		timePoints = append(timePoints, models.TimePoint{
-			Timestamp: tp.Timestamp,
-			Value:     tp.Count,
-		})
-	}
-
-	if err := rows.Err(); err != nil {
-		return &stats, nil, nil, err
-	}
 
 	// Get episode stats
 	episodeStatsQuery := `
@@ -487,8 +411,9 @@ func (r *repository) GetPodcastListens(ctx context.Context, podcastID uuid.UUID,
 			e.id as episode_id,
 			e.title,
 			COUNT(le.*) as listens,
-			AVG(le.duration) as average_listen_duration,
-			(SUM(CASE WHEN le.completed THEN 1 ELSE 0 END)::float / COUNT(*)) * 100 as completion_rate
+			COUNT(DISTINCT le.listener_id) as unique_listeners,
+			COALESCE(AVG(le.duration), 0) as average_listen_duration,
+			COALESCE((SUM(CASE WHEN le.completed THEN 1 ELSE 0 END)::float / NULLIF(COUNT(le.id), 0)) * 100, 0) as completion_rate
 		FROM episodes e
 		LEFT JOIN listen_events le ON e.id = le.episode_id
 		AND le.started_at BETWEEN $2 AND $3
@@ -497,13 +422,13 @@ func (r *repository) GetPodcastListens(ctx context.Context, podcastID uuid.UUID,
 		ORDER BY listens DESC
 	`
 
-	rows, err = r.db.QueryxContext(ctx, episodeStatsQuery, podcastID, params.StartDate, params.EndDate)
+	rows, err := r.db.QueryxContext(ctx, episodeStatsQuery, podcastID, params.StartDate, params.EndDate)
 	if err != nil {
 		return &stats, timePoints, nil, err
 	}
 	defer rows.Close()
 
-	var episodeStats []models.EpisodeStat
+	episodeStats := []models.EpisodeStat{}
 	for rows.Next() {
 		var es models.EpisodeStat
 		if err := rows.StructScan(&es); err != nil {
@@ -517,4 +442,208 @@ func (r *repository) GetPodcastListens(ctx context.Context, podcastID uuid.UUID,
 	}
 
 	return &stats, timePoints, episodeStats, nil
-}
\ No newline at end of file
+}
+
+// GetEpisodeSessionStats gets listen statistics for an episode counting listening sessions instead of raw events
+func (r *repository) GetEpisodeSessionStats(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams, sessionGap time.Duration) (*models.ListenStats, error) {
+	defer database.TrackSlowQuery("analytics.GetEpisodeSessionStats")()
+
+	return r.getSessionStats(ctx, "le.episode_id = $1", episodeID, params, sessionGap)
+}
+
+// GetPodcastSessionStats gets listen statistics for a podcast counting listening sessions instead of raw events
+func (r *repository) GetPodcastSessionStats(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams, sessionGap time.Duration) (*models.ListenStats, error) {
+	defer database.TrackSlowQuery("analytics.GetPodcastSessionStats")()
+
+	return r.getSessionStats(ctx, "e.podcast_id = $1", podcastID, params, sessionGap)
+}
+
+// getSessionStats stitches the listen events matching the scope condition into sessions.
+// Clients send an event each time a listener pauses or scrubs, so events of the same listener
+// on the same episode starting within sessionGap of the end of the previous one are merged,
+// summing their listened durations. Anonymous events cannot be linked and stay separate sessions.
+func (r *repository) getSessionStats(ctx context.Context, scope string, scopeID uuid.UUID, params models.AnalyticsParams, sessionGap time.Duration) (*models.ListenStats, error) {
+	query := fmt.Sprintf(`
+		WITH events AS (
+			SELECT
+				COALESCE(le.listener_id, le.id) as session_key,
+				le.listener_id,
+				le.episode_id,
+				le.started_at,
+				COALESCE(le.duration, 0) as duration,
+				COALESCE(le.completed, FALSE) as completed,
+				LAG(le.started_at + COALESCE(le.duration, 0) * INTERVAL '1 second') OVER (
+					PARTITION BY COALESCE(le.listener_id, le.id), le.episode_id
+					ORDER BY le.started_at
+				) as previous_end
+			FROM listen_events le
+			JOIN episodes e ON le.episode_id = e.id
+			WHERE %s
+			AND le.started_at BETWEEN $2 AND $3
+		),
+		numbered AS (
+			SELECT
+				*,
+				SUM(CASE WHEN previous_end IS NULL OR started_at > previous_end + $4 * INTERVAL '1 second' THEN 1 ELSE 0 END) OVER (
+					PARTITION BY session_key, episode_id
+					ORDER BY started_at
+				) as session_number
+			FROM events
+		),
+		sessions AS (
+			SELECT
+				listener_id,
+				SUM(duration) as duration,
+				BOOL_OR(completed) as completed
+			FROM numbered
+			GROUP BY session_key, episode_id, session_number, listener_id
+		)
+		SELECT
+			COUNT(*) as total_listens,
+			COUNT(DISTINCT listener_id) as unique_listeners,
+			COALESCE(AVG(duration), 0) as average_listen_duration,
+			COALESCE((SUM(CASE WHEN completed THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*), 0)) * 100, 0) as completion_rate
+		FROM sessions
+	`, scope)
+
+	var stats models.ListenStats
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		scopeID,
+		params.StartDate,
+		params.EndDate,
+		int(sessionGap.Seconds()),
+	).Scan(&stats.TotalListens, &stats.UniqueListeners, &stats.AverageListenDuration, &stats.CompletionRate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetListeningHistoryAfter gets up to limit history items of a user following the cursor, ordered
+// by (updated_at, episode_id) descending. An episode listened to again moves to the top of the
+// history, so one a client has already paged past is not repeated.
+func (r *repository) GetListeningHistoryAfter(ctx context.Context, listenerID uuid.UUID, after *pagination.Cursor, limit int) ([]*models.ListeningHistoryItem, error) {
+	defer database.TrackSlowQuery("analytics.GetListeningHistoryAfter")()
+
+	whereClause := "WHERE ph.listener_id = $1"
+	args := []interface{}{listenerID}
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		whereClause += " AND (ph.updated_at, ph.episode_id) < ($2, $3)"
+	}
+
+	historyQuery := fmt.Sprintf(`
+		SELECT 
+			ph.episode_id,
+			e.title as episode_title,
+			e.podcast_id,
+			p.title as podcast_title,
+			ph.updated_at as listened_at,
+			ph.position as duration,
+			ph.completed,
+			COALESCE(e.cover_image_url, p.cover_image_url) as cover_image_url
+		FROM playback_history ph
+		JOIN episodes e ON ph.episode_id = e.id
+		JOIN podcasts p ON e.podcast_id = p.id
+		%s
+		ORDER BY ph.updated_at DESC, ph.episode_id DESC
+		LIMIT $%d
+	`, whereClause, len(args)+1)
+
+	var history []*models.ListeningHistoryItem
+	err := r.db.SelectContext(ctx, &history, historyQuery, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// GetPodcastOwnerID gets the ID of the podcaster who owns a podcast
+func (r *repository) GetPodcastOwnerID(ctx context.Context, podcastID uuid.UUID) (uuid.UUID, error) {
+	query := `SELECT podcaster_id FROM podcasts WHERE id = $1`
+
+	var ownerID uuid.UUID
+	err := r.db.GetContext(ctx, &ownerID, query, podcastID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, models.ErrPodcastNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return ownerID, nil
+}
+
+// GetEpisodeOwnerID gets the ID of the podcaster who owns an episode's podcast
+func (r *repository) GetEpisodeOwnerID(ctx context.Context, episodeID uuid.UUID) (uuid.UUID, error) {
+	query := `
+		SELECT p.podcaster_id
+		FROM episodes e
+		JOIN podcasts p ON e.podcast_id = p.id
+		WHERE e.id = $1
+	`
+
+	var ownerID uuid.UUID
+	err := r.db.GetContext(ctx, &ownerID, query, episodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, models.ErrEpisodeNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return ownerID, nil
+}
+
+// timeSeriesUnit returns the date_trunc unit for an analytics interval, defaulting to day
+func timeSeriesUnit(interval string) string {
+	switch interval {
+	case "week", "month":
+		return interval
+	default:
+		return "day"
+	}
+}
+
+// getListenTimeSeries counts listen events per interval across the whole requested range.
+// Buckets come from generate_series so intervals without listens are returned with a zero
+// count instead of being left out. The source clause selects listen_events as le and
+// filters on $1; the date range is bound to $2 and $3.
+func (r *repository) getListenTimeSeries(ctx context.Context, params models.AnalyticsParams, source string, id uuid.UUID) ([]models.TimePoint, error) {
+	unit := timeSeriesUnit(params.Interval)
+
+	query := fmt.Sprintf(`
+		WITH buckets AS (
+			SELECT generate_series(
+				date_trunc('%[1]s', $2::timestamptz),
+				date_trunc('%[1]s', $3::timestamptz),
+				interval '1 %[1]s'
+			) as bucket
+		),
+		counts AS (
+			SELECT 
+				date_trunc('%[1]s', le.started_at) as bucket,
+				COUNT(*) as count
+			%[2]s
+			AND le.started_at BETWEEN $2 AND $3
+			GROUP BY 1
+		)
+		SELECT 
+			b.bucket as timestamp,
+			COALESCE(c.count, 0) as value
+		FROM buckets b
+		LEFT JOIN counts c ON c.bucket = b.bucket
+		ORDER BY b.bucket
+	`, unit, source)
+
+	timePoints := []models.TimePoint{}
+	if err := r.db.SelectContext(ctx, &timePoints, query, id, params.StartDate, params.EndDate); err != nil {
+		return nil, err
+	}
+
+	return timePoints, nil
+}
@@ -0,0 +1,138 @@
+// pkg/analytics/repository/postgres/repository_test.go
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/models"
+)
+
+// newMockRepository returns a repository backed by sqlmock, failing the test on unmet expectations
+func newMockRepository(t *testing.T) (*repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+
+	return &repository{db: sqlx.NewDb(db, "postgres")}, mock
+}
+
+// Postgres answers the stats queries over an empty range with one row: zero counts and,
+// thanks to the COALESCEs, zero averages instead of NULLs
+var (
+	statsColumns   = []string{"total_listens", "unique_listeners", "average_listen_duration", "completion_rate"}
+	noListensQuery = `COALESCE\(AVG\(.*duration\), 0\) as average_listen_duration`
+)
+
+// emptyRange is a three day range without listens
+var emptyRange = models.AnalyticsParams{
+	StartDate: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	EndDate:   time.Date(2024, 3, 3, 23, 59, 59, 0, time.UTC),
+}
+
+// expectEmptyTimeSeries expects a time series query answering a zero count for each day of emptyRange
+func expectEmptyTimeSeries(mock sqlmock.Sqlmock) {
+	rows := sqlmock.NewRows([]string{"timestamp", "value"})
+	for day := emptyRange.StartDate; day.Before(emptyRange.EndDate); day = day.AddDate(0, 0, 1) {
+		rows.AddRow(day, 0)
+	}
+	mock.ExpectQuery("generate_series").WillReturnRows(rows)
+}
+
+// checkZeroTimeSeries checks that every day of emptyRange is present with a zero count
+func checkZeroTimeSeries(t *testing.T, timePoints []models.TimePoint) {
+	t.Helper()
+
+	if len(timePoints) != 3 {
+		t.Fatalf("got %d time points, want one per day", len(timePoints))
+	}
+	for _, point := range timePoints {
+		if point.Value != 0 {
+			t.Errorf("time point %v = %d, want 0", point.Timestamp, point.Value)
+		}
+	}
+}
+
+func TestGetEpisodeListensWithoutListens(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	episodeID := uuid.New()
+
+	mock.ExpectQuery(noListensQuery).WithArgs(episodeID, emptyRange.StartDate, emptyRange.EndDate).
+		WillReturnRows(sqlmock.NewRows(statsColumns).AddRow(0, 0, 0.0, 0.0))
+	expectEmptyTimeSeries(mock)
+
+	stats, timePoints, err := repo.GetEpisodeListens(context.Background(), episodeID, emptyRange)
+	if err != nil {
+		t.Fatalf("GetEpisodeListens() error = %v", err)
+	}
+
+	if *stats != (models.ListenStats{}) {
+		t.Errorf("stats = %+v, want all zero", *stats)
+	}
+	checkZeroTimeSeries(t, timePoints)
+}
+
+func TestGetPodcastListensWithoutListens(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	podcastID, episodeID := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(noListensQuery).WithArgs(podcastID, emptyRange.StartDate, emptyRange.EndDate).
+		WillReturnRows(sqlmock.NewRows(statsColumns).AddRow(0, 0, 0.0, 0.0))
+	expectEmptyTimeSeries(mock)
+	// Episodes without listens are still listed, with zero stats
+	mock.ExpectQuery("LEFT JOIN listen_events").WithArgs(podcastID, emptyRange.StartDate, emptyRange.EndDate).
+		WillReturnRows(sqlmock.NewRows([]string{"episode_id", "title", "listens", "unique_listeners", "average_listen_duration", "completion_rate"}).
+			AddRow(episodeID, "The Confluence", 0, 0, 0.0, 0.0))
+
+	stats, timePoints, episodeStats, err := repo.GetPodcastListens(context.Background(), podcastID, emptyRange)
+	if err != nil {
+		t.Fatalf("GetPodcastListens() error = %v", err)
+	}
+
+	if *stats != (models.ListenStats{}) {
+		t.Errorf("stats = %+v, want all zero", *stats)
+	}
+	checkZeroTimeSeries(t, timePoints)
+	want := models.EpisodeStat{EpisodeID: episodeID, Title: "The Confluence"}
+	if len(episodeStats) != 1 || episodeStats[0] != want {
+		t.Errorf("episode stats = %+v, want %+v", episodeStats, want)
+	}
+}
+
+func TestGetPodcastListensWithoutEpisodes(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	podcastID := uuid.New()
+
+	mock.ExpectQuery(noListensQuery).WillReturnRows(sqlmock.NewRows(statsColumns).AddRow(0, 0, 0.0, 0.0))
+	expectEmptyTimeSeries(mock)
+	mock.ExpectQuery("LEFT JOIN listen_events").WillReturnRows(sqlmock.NewRows([]string{"episode_id", "title", "listens"}))
+
+	stats, timePoints, episodeStats, err := repo.GetPodcastListens(context.Background(), podcastID, emptyRange)
+	if err != nil {
+		t.Fatalf("GetPodcastListens() error = %v", err)
+	}
+
+	// The response lists no episodes rather than null
+	body, err := json.Marshal(models.PodcastAnalytics{ListenStats: *stats, ListensByDay: timePoints, ListensByEpisode: episodeStats})
+	if err != nil {
+		t.Fatalf("failed to encode analytics: %v", err)
+	}
+	if !strings.Contains(string(body), `"listens_by_episode":[]`) {
+		t.Errorf("analytics = %s, want an empty listens_by_episode", body)
+	}
+}
@@ -0,0 +1,103 @@
+// pkg/analytics/uaparser/uaparser.go
+package uaparser
+
+import (
+	"strings"
+
+	"github.com/mssola/useragent"
+)
+
+// Device types stored with listen events
+const (
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceDesktop = "desktop"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+// UnknownClient is the client name used when a user agent can't be identified
+const UnknownClient = "Unknown"
+
+// Client is the normalized client and device behind a user agent
+type Client struct {
+	Name       string
+	DeviceType string
+}
+
+// podcastApp is a known podcast app and the user agent fragments identifying it.
+// Apps only available on phones set device, as their user agents often omit the platform.
+type podcastApp struct {
+	name       string
+	signatures []string
+	device     string
+}
+
+// podcastApps lists podcast app signatures, matched against the lower-cased user agent.
+// Apple Podcasts comes last as its fragments also appear in other iOS apps' user agents.
+var podcastApps = []podcastApp{
+	{name: "Overcast", signatures: []string{"overcast"}, device: DeviceMobile},
+	{name: "Pocket Casts", signatures: []string{"pocket casts", "pocketcasts"}},
+	{name: "Spotify", signatures: []string{"spotify"}},
+	{name: "Castro", signatures: []string{"castro"}, device: DeviceMobile},
+	{name: "Castbox", signatures: []string{"castbox"}},
+	{name: "Podcast Addict", signatures: []string{"podcastaddict", "podcast addict"}},
+	{name: "AntennaPod", signatures: []string{"antennapod"}},
+	{name: "Google Podcasts", signatures: []string{"googlepodcasts", "google-podcast"}},
+	{name: "Apple Podcasts", signatures: []string{"applecoremedia", "itms", "podcasts/"}},
+}
+
+// Classify identifies the client and device type of a user agent. Known podcast apps
+// are reported by name; other clients fall back to the browser name.
+func Classify(userAgent string) Client {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return Client{Name: UnknownClient, DeviceType: DeviceUnknown}
+	}
+
+	ua := useragent.New(userAgent)
+	client := Client{DeviceType: deviceType(ua, userAgent)}
+
+	lower := strings.ToLower(userAgent)
+	for _, app := range podcastApps {
+		for _, signature := range app.signatures {
+			if strings.Contains(lower, signature) {
+				client.Name = app.name
+				if client.DeviceType == DeviceUnknown && app.device != "" {
+					client.DeviceType = app.device
+				}
+				return client
+			}
+		}
+	}
+
+	if ua.Bot() {
+		client.DeviceType = DeviceBot
+	}
+
+	client.Name, _ = ua.Browser()
+	if client.Name == "" {
+		client.Name = UnknownClient
+	}
+
+	return client
+}
+
+// deviceType derives the device type from the parsed user agent
+func deviceType(ua *useragent.UserAgent, userAgent string) string {
+	lower := strings.ToLower(userAgent)
+	switch {
+	case strings.Contains(lower, "ipad") || strings.Contains(lower, "tablet"):
+		return DeviceTablet
+	case ua.Mobile() || strings.Contains(lower, "iphone") || strings.Contains(lower, "android"):
+		return DeviceMobile
+	}
+
+	os := strings.ToLower(ua.OS())
+	switch {
+	case strings.HasPrefix(os, "windows"), strings.Contains(os, "mac os"), strings.Contains(os, "linux"), strings.Contains(os, "cros"):
+		return DeviceDesktop
+	}
+
+	return DeviceUnknown
+}
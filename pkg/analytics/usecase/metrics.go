@@ -0,0 +1,32 @@
+// pkg/analytics/usecase/metrics.go
+package usecase
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/uaparser"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
+)
+
+// analyticsMetrics are the collectors of the analytics usecase
+type analyticsMetrics struct {
+	listenEventsTotal *prometheus.CounterVec
+}
+
+// newAnalyticsMetrics creates the analytics collectors and registers them with reg, unless reg is nil
+func newAnalyticsMetrics(reg prometheus.Registerer) *analyticsMetrics {
+	m := &analyticsMetrics{
+		listenEventsTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "analytics",
+			Name:      "listen_events_total",
+			Help:      "Number of listen events ingested, by device type.",
+		}, []string{"device_type"}),
+	}
+
+	for _, deviceType := range []string{uaparser.DeviceMobile, uaparser.DeviceTablet, uaparser.DeviceDesktop, uaparser.DeviceBot, uaparser.DeviceUnknown} {
+		m.listenEventsTotal.WithLabelValues(deviceType)
+	}
+
+	return m
+}
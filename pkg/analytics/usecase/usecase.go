@@ -6,30 +6,46 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/models"
 	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/repository/postgres"
+	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/uaparser"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/pagination"
 )
 
 // Usecase defines the methods for the analytics usecase
 type Usecase interface {
 	TrackListen(ctx context.Context, req *models.TrackListenRequest) (*models.ListenEvent, error)
-	GetEpisodeAnalytics(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams) (*models.EpisodeAnalytics, error)
-	GetPodcastAnalytics(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams) (*models.PodcastAnalytics, error)
+	GetEpisodeAnalytics(ctx context.Context, episodeID, requesterID uuid.UUID, params models.AnalyticsParams) (*models.EpisodeAnalytics, error)
+	GetPodcastAnalytics(ctx context.Context, podcastID, requesterID uuid.UUID, params models.AnalyticsParams) (*models.PodcastAnalytics, error)
 	GetPodcasterAnalytics(ctx context.Context, podcasterID uuid.UUID, params models.AnalyticsParams) (*models.PodcasterAnalytics, error)
 	GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ListeningHistoryItem, int, error)
+	GetListeningHistoryAfter(ctx context.Context, listenerID uuid.UUID, after *pagination.Cursor, pageSize int) ([]*models.ListeningHistoryItem, *pagination.Cursor, error)
+}
+
+// ContentClient looks up metadata of podcasts and episodes, which the content service owns
+type ContentClient interface {
+	GetPodcastTitle(ctx context.Context, podcastID uuid.UUID) (string, error)
+	GetEpisodeTitle(ctx context.Context, episodeID uuid.UUID) (string, error)
 }
 
 type usecase struct {
 	repo           postgres.Repository
+	content        ContentClient
+	metrics        *analyticsMetrics
 	cfg            *config.Config
 	contextTimeout time.Duration
 }
 
-// NewUsecase creates a new analytics usecase
-func NewUsecase(repo postgres.Repository, cfg *config.Config, timeout time.Duration) Usecase {
+// NewUsecase creates a new analytics usecase, registering its metrics with reg. Titles are
+// left empty when content is nil.
+func NewUsecase(repo postgres.Repository, content ContentClient, reg prometheus.Registerer, cfg *config.Config, timeout time.Duration) Usecase {
 	return &usecase{
 		repo:           repo,
+		content:        content,
+		metrics:        newAnalyticsMetrics(reg),
 		cfg:            cfg,
 		contextTimeout: timeout,
 	}
@@ -40,6 +56,7 @@ func (u *usecase) TrackListen(ctx context.Context, req *models.TrackListenReques
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	client := uaparser.Classify(req.UserAgent)
 	event := &models.ListenEvent{
 		ListenerID:  req.ListenerID,
 		EpisodeID:   req.EpisodeID,
@@ -48,59 +65,90 @@ func (u *usecase) TrackListen(ctx context.Context, req *models.TrackListenReques
 		Completed:   req.Completed,
 		IPAddress:   req.IPAddress,
 		UserAgent:   req.UserAgent,
+		ClientName:  client.Name,
+		DeviceType:  client.DeviceType,
 		CountryCode: req.CountryCode,
 		City:        req.City,
-		StartedAt:   time.Now(),
+		StartedAt:   time.Now().UTC(),
 	}
 
 	err := u.repo.TrackListen(ctx, event)
 	if err != nil {
 		return nil, err
 	}
+	u.metrics.listenEventsTotal.WithLabelValues(event.DeviceType).Inc()
 
 	return event, nil
 }
 
-// GetEpisodeAnalytics gets analytics for an episode
-func (u *usecase) GetEpisodeAnalytics(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams) (*models.EpisodeAnalytics, error) {
+// GetEpisodeAnalytics gets analytics for an episode, which only the podcaster who owns it may see
+func (u *usecase) GetEpisodeAnalytics(ctx context.Context, episodeID, requesterID uuid.UUID, params models.AnalyticsParams) (*models.EpisodeAnalytics, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	// Check if user is authorized to view the episode's analytics
+	ownerID, err := u.repo.GetEpisodeOwnerID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != requesterID {
+		return nil, models.ErrNotAuthorized
+	}
+
 	// Get listen stats and timeseries
 	stats, timePoints, err := u.repo.GetEpisodeListens(ctx, episodeID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Get episode details from content service
-	// For now, we'll create a placeholder
+	// Get the same stats with events stitched into listening sessions
+	sessionStats, err := u.repo.GetEpisodeSessionStats(ctx, episodeID, params, u.cfg.Analytics.SessionGap)
+	if err != nil {
+		return nil, err
+	}
+
 	analytics := &models.EpisodeAnalytics{
 		EpisodeID:      episodeID,
-		Title:          "Episode Title", // Should be fetched from content service
+		Title:          u.episodeTitle(ctx, episodeID),
 		ListenStats:    *stats,
+		SessionStats:   *sessionStats,
 		ListensByDay:   timePoints,
 	}
 
 	return analytics, nil
 }
 
-// GetPodcastAnalytics gets analytics for a podcast
-func (u *usecase) GetPodcastAnalytics(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams) (*models.PodcastAnalytics, error) {
+// GetPodcastAnalytics gets analytics for a podcast, which only the podcaster who owns it may see
+func (u *usecase) GetPodcastAnalytics(ctx context.Context, podcastID, requesterID uuid.UUID, params models.AnalyticsParams) (*models.PodcastAnalytics, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
+	// Check if user is authorized to view the podcast's analytics
+	ownerID, err := u.repo.GetPodcastOwnerID(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != requesterID {
+		return nil, models.ErrNotAuthorized
+	}
+
 	// Get listen stats, timeseries, and episode stats
 	stats, timePoints, episodeStats, err := u.repo.GetPodcastListens(ctx, podcastID, params)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Get podcast details from content service
-	// For now, we'll create a placeholder
+	// Get the same stats with events stitched into listening sessions
+	sessionStats, err := u.repo.GetPodcastSessionStats(ctx, podcastID, params, u.cfg.Analytics.SessionGap)
+	if err != nil {
+		return nil, err
+	}
+
 	analytics := &models.PodcastAnalytics{
 		PodcastID:       podcastID,
-		Title:           "Podcast Title", // Should be fetched from content service
+		Title:           u.podcastTitle(ctx, podcastID),
 		ListenStats:     *stats,
+		SessionStats:    *sessionStats,
 		ListensByDay:    timePoints,
 		ListensByEpisode: episodeStats,
 	}
@@ -128,4 +176,57 @@ func (u *usecase) GetListeningHistory(ctx context.Context, listenerID uuid.UUID,
 	defer cancel()
 
 	return u.repo.GetListeningHistory(ctx, listenerID, page, pageSize)
-}
\ No newline at end of file
+}
+
+// GetListeningHistoryAfter gets the page of a user's listening history following the cursor,
+// along with the cursor of the next page if there is one
+func (u *usecase) GetListeningHistoryAfter(ctx context.Context, listenerID uuid.UUID, after *pagination.Cursor, pageSize int) ([]*models.ListeningHistoryItem, *pagination.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	// Fetch one item more than the page holds to tell whether there is a next page
+	history, err := u.repo.GetListeningHistoryAfter(ctx, listenerID, after, pageSize+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *pagination.Cursor
+	if len(history) > pageSize {
+		history = history[:pageSize]
+		last := history[len(history)-1]
+		next = &pagination.Cursor{Time: last.ListenedAt, ID: last.EpisodeID.String()}
+	}
+
+	return history, next, nil
+}
+
+// podcastTitle gets the title of a podcast from the content service. The analytics are still
+// worth returning when it can't be reached, so failures only leave the title empty.
+func (u *usecase) podcastTitle(ctx context.Context, podcastID uuid.UUID) string {
+	if u.content == nil {
+		return ""
+	}
+
+	title, err := u.content.GetPodcastTitle(ctx, podcastID)
+	if err != nil {
+		logger.Warn("Failed to get podcast title from content service",
+			logger.Field("podcast_id", podcastID.String()), logger.Field("error", err))
+		return ""
+	}
+	return title
+}
+
+// episodeTitle gets the title of an episode from the content service, or an empty title on failure
+func (u *usecase) episodeTitle(ctx context.Context, episodeID uuid.UUID) string {
+	if u.content == nil {
+		return ""
+	}
+
+	title, err := u.content.GetEpisodeTitle(ctx, episodeID)
+	if err != nil {
+		logger.Warn("Failed to get episode title from content service",
+			logger.Field("episode_id", episodeID.String()), logger.Field("error", err))
+		return ""
+	}
+	return title
+}
@@ -0,0 +1,110 @@
+// pkg/audit/delivery/http/handlers.go
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/audit/usecase"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Handler is the HTTP handler for the audit log
+type Handler struct {
+	usecase usecase.Usecase
+}
+
+// NewHandler creates a new audit handler
+func NewHandler(usecase usecase.Usecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// ListAuditLog godoc
+// @Summary Query the audit log
+// @Description Get audit entries of sensitive admin and owner actions, newest first (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "Actor user ID"
+// @Param action query string false "Action (e.g. podcast.delete)"
+// @Param target_type query string false "Target type (podcast, episode, user)"
+// @Param target_id query string false "Target ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD), exclusive"
+// @Param sort query string false "Sort field (created_at, action, target_type, actor_id)"
+// @Param order query string false "Sort order (asc, desc; default: desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/audit-log [get]
+func (h *Handler) ListAuditLog(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	params := models.AuditLogParams{
+		ActorID:    c.Query("actor_id"),
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Sort:       c.Query("sort"),
+		Order:      c.Query("order"),
+		Page:       pagination.Page,
+		PageSize:   pagination.PageSize,
+	}
+
+	if params.ActorID != "" {
+		if _, err := uuid.Parse(params.ActorID); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid actor ID")
+			return
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid from date format")
+			return
+		}
+		params.From = fromDate
+	}
+
+	if to := c.Query("to"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid to date format")
+			return
+		}
+		params.To = toDate
+	}
+
+	entries, totalCount, err := h.usecase.ListEntries(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidSortField) {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid sort field")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch audit log")
+		return
+	}
+
+	utils.RespondWithPagination(c, entries, totalCount, params.Page, params.PageSize)
+}
+
+// RegisterRoutes registers all the audit routes
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin")
+	admin.Use(authMiddleware, middleware.RoleMiddleware("admin"))
+	{
+		admin.GET("/audit-log", h.ListAuditLog)
+	}
+}
@@ -0,0 +1,9 @@
+// pkg/audit/models/errors.go
+package models
+
+import "github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
+
+// Errors returned by the audit repository and usecase
+var (
+	ErrInvalidSortField = errs.Validation("invalid sort field")
+)
@@ -0,0 +1,91 @@
+// pkg/audit/models/models.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audit actions
+const (
+	ActionPodcastUpdate      = "podcast.update"
+	ActionPodcastDelete      = "podcast.delete"
+	ActionPodcastSyncResume  = "podcast.sync_resume"
+	ActionPodcastSuspend     = "podcast.suspend"
+	ActionPodcastUnsuspend   = "podcast.unsuspend"
+	ActionEpisodeBulkDelete  = "episode.bulk_delete"
+	ActionEpisodeTakedown    = "episode.takedown"
+	ActionCommentModerate    = "comment.moderate"
+	ActionCommentRemove      = "comment.remove"
+	ActionReportResolve      = "report.resolve"
+	ActionUserPasswordChange = "user.password_change"
+	ActionUserPasswordReset  = "user.password_reset"
+	ActionUserProfileUpdate  = "user.profile_update"
+	ActionUserPromoteAdmin   = "user.promote_admin"
+	ActionUserDelete         = "user.delete"
+)
+
+// Audit target types
+const (
+	TargetPodcast = "podcast"
+	TargetEpisode = "episode"
+	TargetComment = "comment"
+	TargetReport  = "report"
+	TargetUser    = "user"
+)
+
+// AuditEntry represents a recorded sensitive action
+type AuditEntry struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	ActorID    *uuid.UUID      `json:"actor_id" db:"actor_id"`
+	Action     string          `json:"action" db:"action"`
+	TargetType string          `json:"target_type" db:"target_type"`
+	TargetID   string          `json:"target_id" db:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before_data"`
+	After      json.RawMessage `json:"after,omitempty" db:"after_data"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
+
+// NewAuditEntry creates an audit entry, encoding the before and after states as JSON.
+// Either state may be nil, e.g. there is no after state for a deletion.
+func NewAuditEntry(actorID uuid.UUID, action, targetType, targetID string, before, after interface{}) *AuditEntry {
+	entry := &AuditEntry{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     toJSON(before),
+		After:      toJSON(after),
+	}
+	if actorID != uuid.Nil {
+		entry.ActorID = &actorID
+	}
+	return entry
+}
+
+// AuditLogParams represents parameters for querying the audit log
+type AuditLogParams struct {
+	ActorID    string    `form:"actor_id"`
+	Action     string    `form:"action"`
+	TargetType string    `form:"target_type"`
+	TargetID   string    `form:"target_id"`
+	From       time.Time `form:"from"`
+	To         time.Time `form:"to"`
+	Sort       string    `form:"sort"`  // created_at, action, target_type or actor_id
+	Order      string    `form:"order"` // asc or desc
+	Page       int       `form:"page,default=1"`
+	PageSize   int       `form:"page_size,default=20"`
+}
+
+// toJSON encodes a state snapshot, returning nil when there is nothing to record
+func toJSON(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
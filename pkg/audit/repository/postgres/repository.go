@@ -0,0 +1,166 @@
+// pkg/audit/repository/postgres/repository.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repository defines the methods for the audit repository
+type Repository interface {
+	CreateEntry(ctx context.Context, entry *models.AuditEntry) error
+	CreateEntryTx(ctx context.Context, tx *sqlx.Tx, entry *models.AuditEntry) error
+	ListEntries(ctx context.Context, params models.AuditLogParams) ([]*models.AuditEntry, int, error)
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository creates a new audit repository
+func NewRepository(db *sqlx.DB) Repository {
+	return &repository{db: db}
+}
+
+const insertEntryQuery = `
+	INSERT INTO audit_log (
+		id, actor_id, action, target_type, target_id, before_data, after_data, created_at
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8
+	)
+`
+
+// CreateEntry records an audit entry
+func (r *repository) CreateEntry(ctx context.Context, entry *models.AuditEntry) error {
+	prepareEntry(entry)
+	_, err := r.db.ExecContext(ctx, insertEntryQuery, entryArgs(entry)...)
+	return err
+}
+
+// CreateEntryTx records an audit entry within the transaction of the audited mutation
+func (r *repository) CreateEntryTx(ctx context.Context, tx *sqlx.Tx, entry *models.AuditEntry) error {
+	prepareEntry(entry)
+	_, err := tx.ExecContext(ctx, insertEntryQuery, entryArgs(entry)...)
+	return err
+}
+
+// sortColumns whitelists the columns audit entries can be sorted by
+var sortColumns = map[string]string{
+	"created_at":  "created_at",
+	"action":      "action",
+	"target_type": "target_type",
+	"actor_id":    "actor_id",
+}
+
+// ListEntries lists audit entries matching the given filters, newest first unless sorted otherwise
+func (r *repository) ListEntries(ctx context.Context, params models.AuditLogParams) ([]*models.AuditEntry, int, error) {
+	sortColumn, ok := sortColumns[params.Sort]
+	if params.Sort == "" {
+		sortColumn, ok = sortColumns["created_at"], true
+	}
+	if !ok {
+		return nil, 0, models.ErrInvalidSortField
+	}
+	order := "DESC"
+	if strings.EqualFold(params.Order, "asc") {
+		order = "ASC"
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if params.ActorID != "" {
+		addCondition("actor_id = $%d", params.ActorID)
+	}
+	if params.Action != "" {
+		addCondition("action = $%d", params.Action)
+	}
+	if params.TargetType != "" {
+		addCondition("target_type = $%d", params.TargetType)
+	}
+	if params.TargetID != "" {
+		addCondition("target_id = $%d", params.TargetID)
+	}
+	if !params.From.IsZero() {
+		addCondition("created_at >= $%d", params.From)
+	}
+	if !params.To.IsZero() {
+		addCondition("created_at < $%d", params.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM audit_log " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get entries with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT
+			id, actor_id, action, target_type, target_id, before_data, after_data, created_at
+		FROM audit_log
+		%s
+		ORDER BY %s %s, id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, sortColumn, order, len(args)+1, len(args)+2)
+
+	var entries []*models.AuditEntry
+	err = r.db.SelectContext(ctx, &entries, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, totalCount, nil
+}
+
+// prepareEntry fills in the ID and creation time of a new entry
+func prepareEntry(entry *models.AuditEntry) {
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+}
+
+// entryArgs returns the insert arguments for an entry
+func entryArgs(entry *models.AuditEntry) []interface{} {
+	return []interface{}{
+		entry.ID,
+		entry.ActorID,
+		entry.Action,
+		entry.TargetType,
+		entry.TargetID,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		entry.CreatedAt,
+	}
+}
+
+// nullableJSON converts an empty JSON document to NULL
+func nullableJSON(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,55 @@
+// pkg/audit/usecase/usecase.go
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/audit/repository/postgres"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+)
+
+// Usecase defines the methods for the audit usecase
+type Usecase interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	ListEntries(ctx context.Context, params models.AuditLogParams) ([]*models.AuditEntry, int, error)
+}
+
+type usecase struct {
+	repo           postgres.Repository
+	cfg            *config.Config
+	contextTimeout time.Duration
+}
+
+// NewUsecase creates a new audit usecase
+func NewUsecase(repo postgres.Repository, cfg *config.Config, timeout time.Duration) Usecase {
+	return &usecase{
+		repo:           repo,
+		cfg:            cfg,
+		contextTimeout: timeout,
+	}
+}
+
+// Record records an audit entry
+func (u *usecase) Record(ctx context.Context, entry *models.AuditEntry) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.repo.CreateEntry(ctx, entry)
+}
+
+// ListEntries lists audit entries matching the given filters
+func (u *usecase) ListEntries(ctx context.Context, params models.AuditLogParams) ([]*models.AuditEntry, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+
+	return u.repo.ListEntries(ctx, params)
+}
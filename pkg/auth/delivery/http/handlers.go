@@ -2,6 +2,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/models"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/utils"
 )
 
@@ -33,26 +35,27 @@ func NewHandler(usecase usecase.Usecase) *Handler {
 // @Param request body models.RegisterRequest true "Register Request"
 // @Success 201 {object} models.User
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /auth/register [post]
 func (h *Handler) Register(c *gin.Context) {
 	var req models.RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	user, err := h.usecase.Register(c.Request.Context(), &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			utils.RespondWithError(c, http.StatusBadRequest, err.Error())
+		if errors.Is(err, models.ErrEmailExists) || errors.Is(err, models.ErrUsernameExists) {
+			utils.RespondWithDomainError(c, err, err.Error())
 			return
 		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to register user")
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	// The new user is exposed through the profile endpoint
+	utils.RespondWithCreated(c, "/api/v1/auth/profile", user)
 }
 
 // Login godoc
@@ -69,14 +72,13 @@ func (h *Handler) Register(c *gin.Context) {
 // @Router /auth/login [post]
 func (h *Handler) Login(c *gin.Context) {
 	var req models.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	tokenResponse, err := h.usecase.Login(c.Request.Context(), &req)
+	tokenResponse, err := h.usecase.Login(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid credentials") {
+		if errors.Is(err, models.ErrInvalidCredentials) {
 			utils.RespondWithError(c, http.StatusUnauthorized, "Invalid credentials")
 			return
 		}
@@ -101,12 +103,11 @@ func (h *Handler) Login(c *gin.Context) {
 // @Router /auth/social-login [post]
 func (h *Handler) SocialLogin(c *gin.Context) {
 	var req models.SocialLoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	tokenResponse, err := h.usecase.SocialLogin(c.Request.Context(), &req)
+	tokenResponse, err := h.usecase.SocialLogin(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to login with social provider")
 		return
@@ -129,12 +130,11 @@ func (h *Handler) SocialLogin(c *gin.Context) {
 // @Router /auth/refresh-token [post]
 func (h *Handler) RefreshToken(c *gin.Context) {
 	var req models.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
-	tokenResponse, err := h.usecase.RefreshToken(c.Request.Context(), &req)
+	tokenResponse, err := h.usecase.RefreshToken(c.Request.Context(), &req, clientInfo(c))
 	if err != nil {
 		utils.RespondWithError(c, http.StatusUnauthorized, "Invalid or expired refresh token")
 		return
@@ -143,6 +143,155 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 	c.JSON(http.StatusOK, tokenResponse)
 }
 
+// Logout godoc
+// @Summary Log out
+// @Description Revoke a refresh token so it can no longer be used to get access tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.LogoutRequest true "Logout Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	var req models.LogoutRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.usecase.Logout(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidRefreshToken) {
+			utils.RespondWithError(c, http.StatusUnauthorized, "Invalid or expired refresh token")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll godoc
+// @Summary Log out everywhere
+// @Description Revoke every refresh token of the authenticated user, ending all their sessions
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204 "No Content"
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	if err := h.usecase.RevokeAllSessions(c.Request.Context(), userIDParsed); err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListSessions godoc
+// @Summary List sessions
+// @Description Get the devices the authenticated user is logged in on, most recently used first. The session of the request is marked current.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} models.Session
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/sessions [get]
+func (h *Handler) ListSessions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	// Tokens issued before sessions were tracked have no session
+	var currentSessionID uuid.UUID
+	if sessionID, exists := c.Get("session_id"); exists {
+		currentSessionID, _ = uuid.Parse(sessionID.(string))
+	}
+
+	sessions, err := h.usecase.ListSessions(c.Request.Context(), userIDParsed, currentSessionID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to list sessions")
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Log the authenticated user out of one device. Its refresh token stops working right away and its access token when it expires.
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	err = h.usecase.RevokeSession(c.Request.Context(), userIDParsed, sessionID)
+	if err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			utils.RespondWithDomainError(c, err, "Session not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetProfile godoc
 // @Summary Get user profile
 // @Description Get authenticated user profile
@@ -171,7 +320,11 @@ func (h *Handler) GetProfile(c *gin.Context) {
 
 	user, err := h.usecase.GetUserByID(c.Request.Context(), uuid)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusNotFound, "User not found")
+		if errors.Is(err, models.ErrUserNotFound) {
+			utils.RespondWithDomainError(c, err, "User not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get profile")
 		return
 	}
 
@@ -206,8 +359,7 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 	}
 
 	var req models.UpdateProfileRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -220,6 +372,56 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// UploadProfileImage godoc
+// @Summary Upload profile image
+// @Description Upload a new profile image for the authenticated user, replacing the current one
+// @Tags auth
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param image formData file true "Profile image (jpg, jpeg, png or gif)"
+// @Success 200 {object} models.User
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/profile/image [post]
+func (h *Handler) UploadProfileImage(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	uuid, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Image file is required")
+		return
+	}
+
+	user, err := h.usecase.UploadProfileImage(c.Request.Context(), uuid, fileHeader)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrFileTypeNotAllowed):
+			utils.RespondWithDomainError(c, err, "Image must be a jpg, jpeg, png or gif file")
+		case errors.Is(err, storage.ErrFileTooLarge):
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, "Image file is too large")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to upload profile image")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
 // ChangePassword godoc
 // @Summary Change password
 // @Description Change authenticated user password
@@ -248,15 +450,14 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 	}
 
 	var req models.ChangePasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	err = h.usecase.ChangePassword(c.Request.Context(), uuid, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "incorrect old password") {
-			utils.RespondWithError(c, http.StatusBadRequest, "Incorrect old password")
+		if errors.Is(err, models.ErrIncorrectOldPassword) {
+			utils.RespondWithDomainError(c, err, "Incorrect old password")
 			return
 		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to change password")
@@ -266,6 +467,55 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Delete the authenticated user's account after confirming their password. Their podcasts are marked deleted and their comments are kept without an author; their playlists, likes, subscriptions, history and reviews are removed.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DeleteAccountRequest true "Delete Account Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/account [delete]
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	err = h.usecase.DeleteAccount(c.Request.Context(), userIDParsed, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrIncorrectPassword):
+			utils.RespondWithDomainError(c, err, "Incorrect password")
+		case errors.Is(err, models.ErrUserNotFound):
+			utils.RespondWithDomainError(c, err, "User not found")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete account")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // ForgotPassword godoc
 // @Summary Forgot password
 // @Description Request password reset email
@@ -279,8 +529,7 @@ func (h *Handler) ChangePassword(c *gin.Context) {
 // @Router /auth/forgot-password [post]
 func (h *Handler) ForgotPassword(c *gin.Context) {
 	var req models.ForgotPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -307,14 +556,31 @@ func (h *Handler) ForgotPassword(c *gin.Context) {
 // @Router /auth/reset-password [post]
 func (h *Handler) ResetPassword(c *gin.Context) {
 	var req models.ResetPasswordRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	if len(req.NewPassword) < 6 {
+		utils.RespondWithError(c, http.StatusBadRequest, "Password must be at least 6 characters")
+		return
+	}
+	if req.NewPassword != req.ConfirmPassword {
+		utils.RespondWithError(c, http.StatusBadRequest, "Passwords do not match")
 		return
 	}
 
 	err := h.usecase.ResetPassword(c.Request.Context(), &req)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to reset password")
+		switch {
+		case errors.Is(err, models.ErrInvalidResetToken):
+			utils.RespondWithDomainError(c, err, "Invalid reset token")
+		case errors.Is(err, models.ErrResetTokenExpired):
+			utils.RespondWithDomainError(c, err, "Reset token has expired")
+		case errors.Is(err, models.ErrResetTokenUsed):
+			utils.RespondWithDomainError(c, err, "Reset token has already been used")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to reset password")
+		}
 		return
 	}
 
@@ -334,17 +600,52 @@ func (h *Handler) ResetPassword(c *gin.Context) {
 // @Router /auth/verify-email [post]
 func (h *Handler) VerifyEmail(c *gin.Context) {
 	var req models.VerifyEmailRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
 	err := h.usecase.VerifyEmail(c.Request.Context(), &req)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to verify email")
+		switch {
+		case errors.Is(err, models.ErrInvalidVerificationToken):
+			utils.RespondWithDomainError(c, err, "Invalid verification token")
+		case errors.Is(err, models.ErrVerificationTokenExpired):
+			utils.RespondWithDomainError(c, err, "Verification token has expired")
+		case errors.Is(err, models.ErrVerificationTokenUsed):
+			utils.RespondWithDomainError(c, err, "Verification token has already been used")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to verify email")
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend verification email
+// @Description Send a new email verification link to an unverified account
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.ResendVerificationRequest true "Resend Verification Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /auth/resend-verification [post]
+func (h *Handler) ResendVerificationEmail(c *gin.Context) {
+	var req models.ResendVerificationRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	err := h.usecase.ResendVerificationEmail(c.Request.Context(), &req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to process request")
 		return
 	}
 
+	// Always return success for security reasons, even if email doesn't exist
 	c.Status(http.StatusNoContent)
 }
 
@@ -356,9 +657,11 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.Han
 		auth.POST("/login", h.Login)
 		auth.POST("/social-login", h.SocialLogin)
 		auth.POST("/refresh-token", h.RefreshToken)
+		auth.POST("/logout", h.Logout)
 		auth.POST("/forgot-password", h.ForgotPassword)
 		auth.POST("/reset-password", h.ResetPassword)
 		auth.POST("/verify-email", h.VerifyEmail)
+		auth.POST("/resend-verification", h.ResendVerificationEmail)
 
 		// Protected routes
 		protected := auth.Group("")
@@ -366,7 +669,28 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.Han
 		{
 			protected.GET("/profile", h.GetProfile)
 			protected.PUT("/profile", h.UpdateProfile)
+			protected.POST("/profile/image", h.UploadProfileImage)
 			protected.POST("/change-password", h.ChangePassword)
+			protected.POST("/logout-all", h.LogoutAll)
+			protected.GET("/sessions", h.ListSessions)
+			protected.DELETE("/sessions/:id", h.RevokeSession)
+			protected.DELETE("/account", h.DeleteAccount)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// clientInfo describes the client of a request for its session. The country comes from the
+// CF-IPCountry header set by Cloudflare, when the API runs behind it.
+func clientInfo(c *gin.Context) models.ClientInfo {
+	client := models.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	// XX and T1 stand for unknown countries and Tor
+	if country := strings.ToUpper(c.GetHeader("CF-IPCountry")); len(country) == 2 && country != "XX" && country != "T1" {
+		client.CountryCode = country
+	}
+
+	return client
+}
@@ -0,0 +1,33 @@
+// pkg/auth/models/errors.go
+package models
+
+import (
+	"errors"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
+)
+
+// Errors returned by the auth repository and usecase
+var (
+	ErrUserNotFound              = errs.NotFound("user not found")
+	ErrResetTokenNotFound        = errs.NotFound("reset token not found")
+	ErrVerificationTokenNotFound = errs.NotFound("verification token not found")
+	ErrSessionNotFound           = errs.NotFound("session not found")
+
+	ErrEmailExists    = errs.Conflict("email already exists")
+	ErrUsernameExists = errs.Conflict("username already exists")
+
+	ErrIncorrectOldPassword     = errs.Validation("incorrect old password")
+	ErrIncorrectPassword        = errs.Validation("incorrect password")
+	ErrInvalidResetToken        = errs.Validation("invalid reset token")
+	ErrResetTokenUsed           = errs.Validation("reset token already used")
+	ErrResetTokenExpired        = errs.Validation("reset token expired")
+	ErrInvalidVerificationToken = errs.Validation("invalid verification token")
+	ErrVerificationTokenUsed    = errs.Validation("verification token already used")
+	ErrVerificationTokenExpired = errs.Validation("verification token expired")
+
+	// Authentication failures, which are answered with 401 rather than the 403 of ErrNotAuthorized
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrTokenRevoked        = errors.New("token revoked")
+)
@@ -16,16 +16,61 @@ type User struct {
 	FullName       string     `json:"full_name" db:"full_name"`
 	Bio            string     `json:"bio" db:"bio"`
 	ProfileImageURL string    `json:"profile_image_url" db:"profile_image_url"`
+	ProfileImagePath string   `json:"-" db:"profile_image_path"`
 	UserType       string     `json:"user_type" db:"user_type"`
 	AuthProvider   string     `json:"auth_provider" db:"auth_provider"`
 	AuthProviderID string     `json:"auth_provider_id" db:"auth_provider_id"`
 	IsVerified     bool       `json:"is_verified" db:"is_verified"`
 	PreferredLanguage string  `json:"preferred_language" db:"preferred_language"`
+	HideExplicit   bool       `json:"hide_explicit" db:"hide_explicit"`
 	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
 	LastLoginAt    *time.Time `json:"last_login_at" db:"last_login_at"`
 }
 
+// PasswordResetToken represents an issued password reset token.
+// Only the SHA-256 hash of the token sent to the user is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// EmailVerificationToken represents an issued email verification token.
+// Only the SHA-256 hash of the token sent to the user is stored.
+type EmailVerificationToken struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	UserID    uuid.UUID  `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Session represents a login of a user on a device, which lasts as long as its refresh tokens
+type Session struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	UserID      uuid.UUID  `json:"-" db:"user_id"`
+	UserAgent   string     `json:"user_agent" db:"user_agent"`
+	IPAddress   string     `json:"ip_address" db:"ip_address"`
+	CountryCode string     `json:"country_code,omitempty" db:"country_code"` // approximate location
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt  time.Time  `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt   time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt   *time.Time `json:"-" db:"revoked_at"`
+	Current     bool       `json:"current" db:"-"` // the session of the request
+}
+
+// ClientInfo describes the client a request comes from, as recorded for sessions
+type ClientInfo struct {
+	IPAddress   string
+	UserAgent   string
+	CountryCode string
+}
+
 // LoginRequest represents a login request
 type LoginRequest struct {
 	Email    string `json:"email" validate:"required,email"`
@@ -59,9 +104,10 @@ type TokenResponse struct {
 
 // IDTokenPayload represents the payload of the ID token
 type IDTokenPayload struct {
-	UserID   uuid.UUID `json:"user_id"`
-	Email    string    `json:"email"`
-	UserType string    `json:"user_type"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	UserType  string    `json:"user_type"`
+	SessionID uuid.UUID `json:"session_id"` // nil for tokens issued before sessions
 }
 
 // RefreshTokenRequest represents a refresh token request
@@ -69,6 +115,17 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+// LogoutRequest represents a logout request
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// DeleteAccountRequest represents a request to delete the current user's account. Accounts
+// signed in with Google or Apple have no password to confirm.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
 // ChangePasswordRequest represents a change password request
 type ChangePasswordRequest struct {
 	OldPassword    string `json:"old_password" validate:"required"`
@@ -93,9 +150,15 @@ type VerifyEmailRequest struct {
 	Token string `json:"token" validate:"required"`
 }
 
+// ResendVerificationRequest represents a request to resend the verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
 // UpdateProfileRequest represents an update profile request
 type UpdateProfileRequest struct {
 	FullName         string `json:"full_name"`
 	Bio              string `json:"bio"`
 	PreferredLanguage string `json:"preferred_language"`
+	HideExplicit     *bool  `json:"hide_explicit,omitempty"` // leaves explicit content out of listings, search and recommendations
 }
\ No newline at end of file
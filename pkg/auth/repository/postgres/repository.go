@@ -4,7 +4,6 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,7 +21,26 @@ type Repository interface {
 	UpdateUser(ctx context.Context, user *models.User) error
 	UpdateLastLogin(ctx context.Context, userID uuid.UUID) error
 	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateProfileImage(ctx context.Context, userID uuid.UUID, imageURL, imagePath string) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error
+	GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error)
+	RedeemPasswordResetToken(ctx context.Context, id, userID uuid.UUID, passwordHash string) error
+	CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error
+	GetEmailVerificationToken(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error)
+	RedeemEmailVerificationToken(ctx context.Context, id, userID uuid.UUID) error
+	SetUserVerified(ctx context.Context, userID uuid.UUID) error
+	SetUserType(ctx context.Context, userID uuid.UUID, userType string) error
+	RevokeToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error)
+	RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) error
+	GetTokensRevokedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error)
+	CreateSession(ctx context.Context, session *models.Session) error
+	GetSession(ctx context.Context, id uuid.UUID) (*models.Session, error)
+	TouchSession(ctx context.Context, id uuid.UUID, client models.ClientInfo, expiresAt time.Time) error
+	ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, id, userID uuid.UUID) error
 }
 
 type repository struct {
@@ -50,7 +68,7 @@ func (r *repository) CreateUser(ctx context.Context, user *models.User) error {
 		user.ID = uuid.New()
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
@@ -81,8 +99,8 @@ func (r *repository) GetUserByID(ctx context.Context, id uuid.UUID) (*models.Use
 	var user models.User
 	query := `
 		SELECT
-			id, email, username, password_hash, full_name, bio, profile_image_url,
-			user_type, auth_provider, auth_provider_id, is_verified, preferred_language,
+			id, email, username, password_hash, full_name, bio, profile_image_url, profile_image_path,
+			user_type, auth_provider, auth_provider_id, is_verified, preferred_language, hide_explicit,
 			created_at, updated_at, last_login_at
 		FROM users
 		WHERE id = $1
@@ -91,7 +109,7 @@ func (r *repository) GetUserByID(ctx context.Context, id uuid.UUID) (*models.Use
 	err := r.db.GetContext(ctx, &user, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, models.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -104,8 +122,8 @@ func (r *repository) GetUserByEmail(ctx context.Context, email string) (*models.
 	var user models.User
 	query := `
 		SELECT
-			id, email, username, password_hash, full_name, bio, profile_image_url,
-			user_type, auth_provider, auth_provider_id, is_verified, preferred_language,
+			id, email, username, password_hash, full_name, bio, profile_image_url, profile_image_path,
+			user_type, auth_provider, auth_provider_id, is_verified, preferred_language, hide_explicit,
 			created_at, updated_at, last_login_at
 		FROM users
 		WHERE email = $1
@@ -114,7 +132,7 @@ func (r *repository) GetUserByEmail(ctx context.Context, email string) (*models.
 	err := r.db.GetContext(ctx, &user, query, email)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, models.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -127,8 +145,8 @@ func (r *repository) GetUserByUsername(ctx context.Context, username string) (*m
 	var user models.User
 	query := `
 		SELECT
-			id, email, username, password_hash, full_name, bio, profile_image_url,
-			user_type, auth_provider, auth_provider_id, is_verified, preferred_language,
+			id, email, username, password_hash, full_name, bio, profile_image_url, profile_image_path,
+			user_type, auth_provider, auth_provider_id, is_verified, preferred_language, hide_explicit,
 			created_at, updated_at, last_login_at
 		FROM users
 		WHERE username = $1
@@ -137,7 +155,7 @@ func (r *repository) GetUserByUsername(ctx context.Context, username string) (*m
 	err := r.db.GetContext(ctx, &user, query, username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, models.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -150,8 +168,8 @@ func (r *repository) GetUserByAuthProvider(ctx context.Context, provider, provid
 	var user models.User
 	query := `
 		SELECT
-			id, email, username, password_hash, full_name, bio, profile_image_url,
-			user_type, auth_provider, auth_provider_id, is_verified, preferred_language,
+			id, email, username, password_hash, full_name, bio, profile_image_url, profile_image_path,
+			user_type, auth_provider, auth_provider_id, is_verified, preferred_language, hide_explicit,
 			created_at, updated_at, last_login_at
 		FROM users
 		WHERE auth_provider = $1 AND auth_provider_id = $2
@@ -160,7 +178,7 @@ func (r *repository) GetUserByAuthProvider(ctx context.Context, provider, provid
 	err := r.db.GetContext(ctx, &user, query, provider, providerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("user not found")
+			return nil, models.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -183,11 +201,12 @@ func (r *repository) UpdateUser(ctx context.Context, user *models.User) error {
 			auth_provider_id = $9,
 			is_verified = $10,
 			preferred_language = $11,
-			updated_at = $12
+			hide_explicit = $12,
+			updated_at = $13
 		WHERE id = $1
 	`
 
-	user.UpdatedAt = time.Now()
+	user.UpdatedAt = time.Now().UTC()
 
 	_, err := r.db.ExecContext(
 		ctx,
@@ -203,6 +222,7 @@ func (r *repository) UpdateUser(ctx context.Context, user *models.User) error {
 		user.AuthProviderID,
 		user.IsVerified,
 		user.PreferredLanguage,
+		user.HideExplicit,
 		user.UpdatedAt,
 	)
 
@@ -217,7 +237,7 @@ func (r *repository) UpdateLastLogin(ctx context.Context, userID uuid.UUID) erro
 		WHERE id = $1
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := r.db.ExecContext(ctx, query, userID, now)
 	return err
 }
@@ -230,14 +250,401 @@ func (r *repository) UpdatePassword(ctx context.Context, userID uuid.UUID, passw
 		WHERE id = $1
 	`
 
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := r.db.ExecContext(ctx, query, userID, passwordHash, now)
 	return err
 }
 
-// DeleteUser deletes a user
+// UpdateProfileImage sets a user's profile image URL and the storage path of the uploaded file
+func (r *repository) UpdateProfileImage(ctx context.Context, userID uuid.UUID, imageURL, imagePath string) error {
+	query := `
+		UPDATE users
+		SET profile_image_url = $2, profile_image_path = $3, updated_at = $4
+		WHERE id = $1
+	`
+
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, query, userID, imageURL, imagePath, now)
+	return err
+}
+
+// SetUserVerified marks a user's email as verified
+func (r *repository) SetUserVerified(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET is_verified = TRUE, updated_at = $2
+		WHERE id = $1
+	`
+
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, query, userID, now)
+	return err
+}
+
+// SetUserType changes a user's type, e.g. to make them an admin
+func (r *repository) SetUserType(ctx context.Context, userID uuid.UUID, userType string) error {
+	query := `
+		UPDATE users
+		SET user_type = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	now := time.Now().UTC()
+	_, err := r.db.ExecContext(ctx, query, userID, userType, now)
+	return err
+}
+
+// DeleteUser deletes a user's account. What others may still refer to is kept without them:
+// their podcasts are marked deleted and lose their owner, and their comments lose their
+// author. Their playlists, likes, subscriptions, history, reviews and reports go with the
+// account.
 func (r *repository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statements := []string{
+		`UPDATE podcasts SET status = 'deleted', podcaster_id = NULL, updated_at = NOW() WHERE podcaster_id = $1`,
+		`UPDATE comments SET user_id = NULL WHERE user_id = $1`,
+		`DELETE FROM playlists WHERE user_id = $1`,
+		`DELETE FROM likes WHERE listener_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, id); err != nil {
+			return err
+		}
+	}
+
+	// Everything else referring to the user is removed by the foreign keys
+	result, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrUserNotFound
+	}
+
+	return tx.Commit()
+}
+
+// CreatePasswordResetToken stores a new password reset token
+func (r *repository) CreatePasswordResetToken(ctx context.Context, token *models.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (
+			id, user_id, token_hash, expires_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5
+		)
+	`
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetPasswordResetToken gets a password reset token by the hash of the token
+func (r *repository) GetPasswordResetToken(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1
+	`
+
+	var token models.PasswordResetToken
+	err := r.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrResetTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RedeemPasswordResetToken marks a password reset token as used and sets the user's new
+// password, both or neither. Only one caller can redeem a token, the others get an error,
+// so a token cannot be redeemed twice.
+func (r *repository) RedeemPasswordResetToken(ctx context.Context, id, userID uuid.UUID, passwordHash string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+
+	result, err := tx.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, id, now)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return models.ErrResetTokenUsed
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET password_hash = $2, updated_at = $3 WHERE id = $1`, userID, passwordHash, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CreateEmailVerificationToken stores a new email verification token
+func (r *repository) CreateEmailVerificationToken(ctx context.Context, token *models.EmailVerificationToken) error {
+	query := `
+		INSERT INTO email_verification_tokens (
+			id, user_id, token_hash, expires_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5
+		)
+	`
+
+	if token.ID == uuid.Nil {
+		token.ID = uuid.New()
+	}
+	token.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.ExecContext(ctx, query, token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetEmailVerificationToken gets an email verification token by the hash of the token
+func (r *repository) GetEmailVerificationToken(ctx context.Context, tokenHash string) (*models.EmailVerificationToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM email_verification_tokens
+		WHERE token_hash = $1
+	`
+
+	var token models.EmailVerificationToken
+	err := r.db.GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrVerificationTokenNotFound
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// RedeemEmailVerificationToken marks an email verification token as used and the user's email
+// as verified, both or neither. Only one caller can redeem a token, the others get an error,
+// so a token cannot be redeemed twice.
+func (r *repository) RedeemEmailVerificationToken(ctx context.Context, id, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+
+	result, err := tx.ExecContext(ctx, `UPDATE email_verification_tokens SET used_at = $2 WHERE id = $1 AND used_at IS NULL`, id, now)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return models.ErrVerificationTokenUsed
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET is_verified = TRUE, updated_at = $2 WHERE id = $1`, userID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RevokeToken revokes a single token by its jti
+func (r *repository) RevokeToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query, jti, userID, expiresAt, time.Now().UTC())
 	return err
-}
\ No newline at end of file
+}
+
+// IsTokenRevoked checks if a token was revoked
+func (r *repository) IsTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`
+
+	var revoked bool
+	err := r.db.GetContext(ctx, &revoked, query, jti)
+	return revoked, err
+}
+
+// DeleteExpiredRevokedTokens deletes the revoked tokens that expired before now
+func (r *repository) DeleteExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// RevokeAllUserTokens revokes every token issued to a user so far, ending all their sessions
+func (r *repository) RevokeAllUserTokens(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET tokens_revoked_at = $2 WHERE id = $1`, userID, now); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE sessions SET revoked_at = $2 WHERE user_id = $1 AND revoked_at IS NULL`, userID, now); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetTokensRevokedAt gets the time up to which all tokens of a user are revoked, nil if never
+func (r *repository) GetTokensRevokedAt(ctx context.Context, userID uuid.UUID) (*time.Time, error) {
+	query := `SELECT tokens_revoked_at FROM users WHERE id = $1`
+
+	var revokedAt *time.Time
+	err := r.db.GetContext(ctx, &revokedAt, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return revokedAt, nil
+}
+
+// CreateSession stores a new session
+func (r *repository) CreateSession(ctx context.Context, session *models.Session) error {
+	query := `
+		INSERT INTO sessions (
+			id, user_id, user_agent, ip_address, country_code, created_at, last_used_at, expires_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $6, $7
+		)
+	`
+
+	if session.ID == uuid.Nil {
+		session.ID = uuid.New()
+	}
+	session.CreatedAt = time.Now().UTC()
+	session.LastUsedAt = session.CreatedAt
+
+	_, err := r.db.ExecContext(
+		ctx,
+		query,
+		session.ID,
+		session.UserID,
+		session.UserAgent,
+		session.IPAddress,
+		session.CountryCode,
+		session.CreatedAt,
+		session.ExpiresAt,
+	)
+	return err
+}
+
+// GetSession gets a session by ID, whether or not it is still active
+func (r *repository) GetSession(ctx context.Context, id uuid.UUID) (*models.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, country_code, created_at, last_used_at, expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1
+	`
+
+	var session models.Session
+	err := r.db.GetContext(ctx, &session, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+// TouchSession records that a session was used to refresh its tokens, from which client,
+// and until when the new refresh token lasts
+func (r *repository) TouchSession(ctx context.Context, id uuid.UUID, client models.ClientInfo, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET last_used_at = $2, user_agent = $3, ip_address = $4, country_code = $5, expires_at = $6
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, time.Now().UTC(), client.UserAgent, client.IPAddress, client.CountryCode, expiresAt)
+	return err
+}
+
+// ListActiveSessions lists the sessions of a user that are neither revoked nor expired,
+// most recently used first
+func (r *repository) ListActiveSessions(ctx context.Context, userID uuid.UUID) ([]*models.Session, error) {
+	query := `
+		SELECT id, user_id, user_agent, ip_address, country_code, created_at, last_used_at, expires_at, revoked_at
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2
+		ORDER BY last_used_at DESC
+	`
+
+	var sessions []*models.Session
+	if err := r.db.SelectContext(ctx, &sessions, query, userID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes an active session of a user
+func (r *repository) RevokeSession(ctx context.Context, id, userID uuid.UUID) error {
+	query := `
+		UPDATE sessions
+		SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrSessionNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// pkg/auth/usecase/mailer.go
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"go.uber.org/zap"
+)
+
+// Email is a message to a user, such as the link to verify their email address
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer sends emails to users
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// smtpMailer sends emails through a mail server
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer sending emails from the given address through the mail server
+// at addr (host:port). The connection is upgraded with STARTTLS when the server offers it;
+// the username and password, if any, are only sent over TLS or to localhost.
+func NewSMTPMailer(addr, username, password, from string) Mailer {
+	mailer := smtpMailer{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send sends an email as plain text
+func (m smtpMailer) Send(ctx context.Context, email Email) error {
+	// Line breaks in a header would let its value add headers of its own
+	headerValue := func(value string) string {
+		return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	}
+
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		headerValue(m.from),
+		headerValue(email.To),
+		mime.QEncoding.Encode("UTF-8", headerValue(email.Subject)),
+		email.Body)
+
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{email.To}, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// logMailer writes emails to the log instead of sending them
+type logMailer struct {
+	logBody bool
+}
+
+// NewLogMailer creates a mailer that logs emails, for development and for deployments
+// without a mail server. Bodies hold one-time tokens, so they are only logged with logBody,
+// which is meant for local development.
+func NewLogMailer(logBody bool) Mailer {
+	return logMailer{logBody: logBody}
+}
+
+// Send logs an email
+func (m logMailer) Send(ctx context.Context, email Email) error {
+	fields := []zap.Field{
+		logger.Field("to", email.To),
+		logger.Field("subject", email.Subject),
+	}
+	if m.logBody {
+		fields = append(fields, logger.Field("body", email.Body))
+	}
+	logger.FromContext(ctx).Info("Email not sent, no mail server configured", fields...)
+	return nil
+}
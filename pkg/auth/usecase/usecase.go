@@ -3,43 +3,76 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"mime/multipart"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	auditModels "github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	auditUsecase "github.com/MHK-26/pod_platfrom_go/pkg/audit/usecase"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/models"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/repository/postgres"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// How long one-time tokens sent by email can be redeemed
+const (
+	passwordResetTokenExpiry     = time.Hour
+	emailVerificationTokenExpiry = 24 * time.Hour
+)
+
 // Usecase defines the methods for the auth usecase
 type Usecase interface {
 	Register(ctx context.Context, req *models.RegisterRequest) (*models.User, error)
-	Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error)
-	SocialLogin(ctx context.Context, req *models.SocialLoginRequest) (*models.TokenResponse, error)
-	RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.TokenResponse, error)
+	Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.TokenResponse, error)
+	SocialLogin(ctx context.Context, req *models.SocialLoginRequest, client models.ClientInfo) (*models.TokenResponse, error)
+	RefreshToken(ctx context.Context, req *models.RefreshTokenRequest, client models.ClientInfo) (*models.TokenResponse, error)
+	Logout(ctx context.Context, req *models.LogoutRequest) error
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID) error
+	ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*models.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
 	VerifyToken(ctx context.Context, token string) (*models.IDTokenPayload, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*models.User, error)
 	ChangePassword(ctx context.Context, userID uuid.UUID, req *models.ChangePasswordRequest) error
 	ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error
 	ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error
 	VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error
+	ResendVerificationEmail(ctx context.Context, req *models.ResendVerificationRequest) error
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) (*models.User, error)
+	UploadProfileImage(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*models.User, error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, req *models.DeleteAccountRequest) error
+	BootstrapAdmins(ctx context.Context, emails []string) error
+	PromoteAdmin(ctx context.Context, email string) error
+	DeleteExpiredRevokedTokens(ctx context.Context) (int64, error)
 }
 
 type usecase struct {
 	repo           postgres.Repository
+	audit          auditUsecase.Usecase
+	storage        storage.Service
+	mailer         Mailer
 	cfg            *config.Config
 	contextTimeout time.Duration
 }
 
 // NewUsecase creates a new auth usecase
-func NewUsecase(repo postgres.Repository, cfg *config.Config, timeout time.Duration) Usecase {
+func NewUsecase(repo postgres.Repository, audit auditUsecase.Usecase, store storage.Service, mailer Mailer, cfg *config.Config, timeout time.Duration) Usecase {
 	return &usecase{
 		repo:           repo,
+		audit:          audit,
+		storage:        store,
+		mailer:         mailer,
 		cfg:            cfg,
 		contextTimeout: timeout,
 	}
@@ -53,13 +86,13 @@ func (u *usecase) Register(ctx context.Context, req *models.RegisterRequest) (*m
 	// Check if email already exists
 	existingUser, err := u.repo.GetUserByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("email already exists")
+		return nil, models.ErrEmailExists
 	}
 
 	// Check if username already exists
 	existingUser, err = u.repo.GetUserByUsername(ctx, req.Username)
 	if err == nil && existingUser != nil {
-		return nil, errors.New("username already exists")
+		return nil, models.ErrUsernameExists
 	}
 
 	// Hash password
@@ -84,20 +117,26 @@ func (u *usecase) Register(ctx context.Context, req *models.RegisterRequest) (*m
 		return nil, err
 	}
 
-	// TODO: Send verification email
+	// The account exists at this point, so a failure here must not fail the registration;
+	// the user can ask for a new verification email
+	if err := u.sendVerificationEmail(ctx, user); err != nil {
+		logger.FromContext(ctx).Warn("Failed to send verification email",
+			logger.Field("user_id", user.ID),
+			logger.Field("error", err))
+	}
 
 	return user, nil
 }
 
 // Login logs in a user
-func (u *usecase) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
+func (u *usecase) Login(ctx context.Context, req *models.LoginRequest, client models.ClientInfo) (*models.TokenResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	// Get user by email
 	user, err := u.repo.GetUserByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, models.ErrInvalidCredentials
 	}
 
 	// Check if user is using email as auth provider
@@ -108,7 +147,9 @@ func (u *usecase) Login(ctx context.Context, req *models.LoginRequest) (*models.
 	// Check password
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password))
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		logger.FromContext(ctx).Warn("Login with wrong password",
+			logger.Field("user_id", user.ID))
+		return nil, models.ErrInvalidCredentials
 	}
 
 	// Update last login
@@ -116,17 +157,11 @@ func (u *usecase) Login(ctx context.Context, req *models.LoginRequest) (*models.
 		return nil, err
 	}
 
-	// Generate tokens
-	tokenResponse, err := u.generateTokens(user)
-	if err != nil {
-		return nil, err
-	}
-
-	return tokenResponse, nil
+	return u.startSession(ctx, user, client)
 }
 
 // SocialLogin performs a social login
-func (u *usecase) SocialLogin(ctx context.Context, req *models.SocialLoginRequest) (*models.TokenResponse, error) {
+func (u *usecase) SocialLogin(ctx context.Context, req *models.SocialLoginRequest, client models.ClientInfo) (*models.TokenResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
@@ -178,62 +213,122 @@ func (u *usecase) SocialLogin(ctx context.Context, req *models.SocialLoginReques
 		return nil, err
 	}
 
-	// Generate tokens
-	tokenResponse, err := u.generateTokens(user)
-	if err != nil {
-		return nil, err
-	}
-
-	return tokenResponse, nil
+	return u.startSession(ctx, user, client)
 }
 
 // RefreshToken refreshes an access token
-func (u *usecase) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest) (*models.TokenResponse, error) {
+func (u *usecase) RefreshToken(ctx context.Context, req *models.RefreshTokenRequest, client models.ClientInfo) (*models.TokenResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
 	// Verify refresh token
-	token, err := jwt.Parse(req.RefreshToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(u.cfg.JWT.RefreshSecret), nil
-	})
-
-	if err != nil || !token.Valid {
-		return nil, errors.New("invalid refresh token")
+	claims, err := u.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
 	}
 
-	// Extract claims
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, errors.New("invalid token claims")
+	// Reject tokens revoked by logging out, individually or everywhere
+	revoked, err := u.repo.IsTokenRevoked(ctx, claims.jti)
+	if err != nil {
+		return nil, err
 	}
-
-	// Extract user ID
-	userIDStr, ok := claims["user_id"].(string)
-	if !ok {
-		return nil, errors.New("invalid user ID in token")
+	if revoked {
+		return nil, models.ErrTokenRevoked
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	revokedAt, err := u.repo.GetTokensRevokedAt(ctx, claims.userID)
 	if err != nil {
-		return nil, errors.New("invalid user ID format")
+		return nil, err
+	}
+	if revokedAt != nil && !claims.issuedAt.After(*revokedAt) {
+		return nil, models.ErrTokenRevoked
 	}
 
 	// Get user
-	user, err := u.repo.GetUserByID(ctx, userID)
+	user, err := u.repo.GetUserByID(ctx, claims.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens issued before sessions were tracked start one
+	if claims.sessionID == uuid.Nil {
+		return u.startSession(ctx, user, client)
+	}
+
+	session, err := u.repo.GetSession(ctx, claims.sessionID)
 	if err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return nil, models.ErrInvalidRefreshToken
+		}
 		return nil, err
 	}
+	if session.UserID != user.ID || session.RevokedAt != nil {
+		return nil, models.ErrTokenRevoked
+	}
+
+	if err := u.repo.TouchSession(ctx, session.ID, client, time.Now().Add(u.refreshTokenLifetime()).UTC()); err != nil {
+		return nil, err
+	}
+
+	return u.generateTokens(user, session.ID)
+}
+
+// Logout revokes a refresh token. Access tokens are short-lived and expire on their own.
+func (u *usecase) Logout(ctx context.Context, req *models.LogoutRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	claims, err := u.parseRefreshToken(req.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repo.RevokeToken(ctx, claims.jti, claims.userID, claims.expiresAt); err != nil {
+		return err
+	}
+
+	// The session may already have been revoked from another device
+	if claims.sessionID != uuid.Nil {
+		if err := u.repo.RevokeSession(ctx, claims.sessionID, claims.userID); err != nil && !errors.Is(err, models.ErrSessionNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevokeAllSessions revokes every refresh token issued to a user, logging them out everywhere
+func (u *usecase) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
 
-	// Generate new tokens
-	tokenResponse, err := u.generateTokens(user)
+	return u.repo.RevokeAllUserTokens(ctx, userID)
+}
+
+// ListSessions lists the active sessions of a user, marking the one the request was made with
+func (u *usecase) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*models.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	sessions, err := u.repo.ListActiveSessions(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return tokenResponse, nil
+	for _, session := range sessions {
+		session.Current = currentSessionID != uuid.Nil && session.ID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends one of a user's sessions, logging out the device it belongs to once its
+// access token expires
+func (u *usecase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.repo.RevokeSession(ctx, sessionID, userID)
 }
 
 // VerifyToken verifies a token
@@ -283,6 +378,10 @@ func (u *usecase) VerifyToken(ctx context.Context, tokenStr string) (*models.IDT
 		UserType: userType,
 	}
 
+	if sessionIDStr, ok := claims["sid"].(string); ok {
+		payload.SessionID, _ = uuid.Parse(sessionIDStr)
+	}
+
 	return payload, nil
 }
 
@@ -291,7 +390,19 @@ func (u *usecase) GetUserByID(ctx context.Context, id uuid.UUID) (*models.User,
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 
-	return u.repo.GetUserByID(ctx, id)
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-resolve uploaded images, as presigned URLs expire
+	if user.ProfileImagePath != "" && u.storage != nil {
+		if url := u.storage.GetFileURL(user.ProfileImagePath); url != "" {
+			user.ProfileImageURL = url
+		}
+	}
+
+	return user, nil
 }
 
 // ChangePassword changes a user's password
@@ -313,7 +424,7 @@ func (u *usecase) ChangePassword(ctx context.Context, userID uuid.UUID, req *mod
 	// Verify old password
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.OldPassword))
 	if err != nil {
-		return errors.New("incorrect old password")
+		return models.ErrIncorrectOldPassword
 	}
 
 	// Hash new password
@@ -327,6 +438,14 @@ func (u *usecase) ChangePassword(ctx context.Context, userID uuid.UUID, req *mod
 		return err
 	}
 
+	// Log out sessions that may have been opened with the old password
+	if err := u.repo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	// Only the fact of the change is recorded, never the password hashes
+	u.recordAudit(ctx, auditModels.NewAuditEntry(userID, auditModels.ActionUserPasswordChange, auditModels.TargetUser, userID.String(), nil, nil))
+
 	return nil
 }
 
@@ -348,26 +467,142 @@ func (u *usecase) ForgotPassword(ctx context.Context, req *models.ForgotPassword
 		return nil
 	}
 
-	// TODO: Generate reset token and send email
+	// Generate reset token
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	// Store only its hash so a database leak does not expose usable tokens
+	resetToken := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(passwordResetTokenExpiry),
+	}
+	if err := u.repo.CreatePasswordResetToken(ctx, resetToken); err != nil {
+		return err
+	}
 
-	return nil
+	return u.mailer.Send(ctx, Email{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    "Open this link within an hour to choose a new password: " + tokenLink(u.cfg.Auth.ResetPasswordURL, token),
+	})
 }
 
-// ResetPassword resets a user's password
+// ResetPassword resets a user's password with a password reset token
 func (u *usecase) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
-	// TODO: Verify reset token
-	// Get user ID from token
-	// Hash password
-	// Update password
-	return errors.New("not implemented")
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	// Verify reset token
+	resetToken, err := u.repo.GetPasswordResetToken(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, models.ErrResetTokenNotFound) {
+			return models.ErrInvalidResetToken
+		}
+		return err
+	}
+	if resetToken.UsedAt != nil {
+		return models.ErrResetTokenUsed
+	}
+	if time.Now().UTC().After(resetToken.ExpiresAt) {
+		return models.ErrResetTokenExpired
+	}
+
+	// Hash new password
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// Redeem the token along with changing the password, so concurrent requests cannot both
+	// use it and a failed change leaves the token usable
+	if err := u.repo.RedeemPasswordResetToken(ctx, resetToken.ID, resetToken.UserID, string(passwordHash)); err != nil {
+		return err
+	}
+
+	// Log out sessions that may have been opened with the old password
+	if err := u.repo.RevokeAllUserTokens(ctx, resetToken.UserID); err != nil {
+		return err
+	}
+
+	u.recordAudit(ctx, auditModels.NewAuditEntry(resetToken.UserID, auditModels.ActionUserPasswordReset, auditModels.TargetUser, resetToken.UserID.String(), nil, nil))
+
+	return nil
 }
 
-// VerifyEmail verifies a user's email
+// VerifyEmail verifies a user's email with an email verification token
 func (u *usecase) VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error {
-	// TODO: Verify email token
-	// Get user ID from token
-	// Update user verification status
-	return errors.New("not implemented")
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	// Verify the token
+	verificationToken, err := u.repo.GetEmailVerificationToken(ctx, hashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, models.ErrVerificationTokenNotFound) {
+			return models.ErrInvalidVerificationToken
+		}
+		return err
+	}
+	if verificationToken.UsedAt != nil {
+		return models.ErrVerificationTokenUsed
+	}
+	if time.Now().UTC().After(verificationToken.ExpiresAt) {
+		return models.ErrVerificationTokenExpired
+	}
+
+	// Redeem the token along with verifying the email, so concurrent requests cannot both
+	// use it and a failed update leaves the token usable
+	return u.repo.RedeemEmailVerificationToken(ctx, verificationToken.ID, verificationToken.UserID)
+}
+
+// ResendVerificationEmail issues a new verification token for an unverified email account
+func (u *usecase) ResendVerificationEmail(ctx context.Context, req *models.ResendVerificationRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	// Get user by email
+	user, err := u.repo.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		// Don't reveal if email exists for security reasons
+		return nil
+	}
+
+	// Social accounts and verified accounts have nothing to verify
+	if user.AuthProvider != "email" || user.IsVerified {
+		return nil
+	}
+
+	return u.sendVerificationEmail(ctx, user)
+}
+
+// sendVerificationEmail issues an email verification token for a user and emails them the link to redeem it
+func (u *usecase) sendVerificationEmail(ctx context.Context, user *models.User) error {
+	token, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	verificationToken := &models.EmailVerificationToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().UTC().Add(emailVerificationTokenExpiry),
+	}
+	if err := u.repo.CreateEmailVerificationToken(ctx, verificationToken); err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, Email{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body:    "Open this link within a day to verify your email address: " + tokenLink(u.cfg.Auth.VerifyEmailURL, token),
+	})
+}
+
+// tokenLink returns the link to a web app page redeeming a one-time token
+func tokenLink(pageURL, token string) string {
+	return pageURL + "?token=" + url.QueryEscape(token)
 }
 
 // UpdateProfile updates a user's profile
@@ -380,6 +615,7 @@ func (u *usecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *mode
 	if err != nil {
 		return nil, err
 	}
+	before := profileSnapshot(user)
 
 	// Update fields
 	if req.FullName != "" {
@@ -391,25 +627,143 @@ func (u *usecase) UpdateProfile(ctx context.Context, userID uuid.UUID, req *mode
 	if req.PreferredLanguage != "" {
 		user.PreferredLanguage = req.PreferredLanguage
 	}
+	if req.HideExplicit != nil {
+		user.HideExplicit = *req.HideExplicit
+	}
 
 	// Update user
 	if err := u.repo.UpdateUser(ctx, user); err != nil {
 		return nil, err
 	}
 
+	u.recordAudit(ctx, auditModels.NewAuditEntry(userID, auditModels.ActionUserProfileUpdate, auditModels.TargetUser, userID.String(), before, profileSnapshot(user)))
+
+	return user, nil
+}
+
+// UploadProfileImage stores a new profile image for the user and removes the one it replaces
+func (u *usecase) UploadProfileImage(ctx context.Context, userID uuid.UUID, file *multipart.FileHeader) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	if u.storage == nil {
+		return nil, errors.New("file storage is not configured")
+	}
+
+	// Get user
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	before := profileSnapshot(user)
+	previousPath := user.ProfileImagePath
+
+	// Save the image under the user's own directory
+	path, err := u.storage.SaveImage(file, "profile-images/"+userID.String())
+	if err != nil {
+		return nil, err
+	}
+	imageURL := u.storage.GetFileURL(path)
+
+	if err := u.repo.UpdateProfileImage(ctx, userID, imageURL, path); err != nil {
+		if deleteErr := u.storage.DeleteFile(path); deleteErr != nil {
+			logger.FromContext(ctx).Warn("Failed to remove unused profile image",
+				logger.Field("user_id", userID),
+				logger.Field("path", path),
+				logger.Field("error", deleteErr))
+		}
+		return nil, err
+	}
+	user.ProfileImageURL = imageURL
+	user.ProfileImagePath = path
+
+	// The previous image is no longer referenced; failing to remove it only leaves an orphaned file
+	if previousPath != "" {
+		if err := u.storage.DeleteFile(previousPath); err != nil {
+			logger.FromContext(ctx).Warn("Failed to remove previous profile image",
+				logger.Field("user_id", userID),
+				logger.Field("path", previousPath),
+				logger.Field("error", err))
+		}
+	}
+
+	u.recordAudit(ctx, auditModels.NewAuditEntry(userID, auditModels.ActionUserProfileUpdate, auditModels.TargetUser, userID.String(), before, profileSnapshot(user)))
+
 	return user, nil
 }
 
-// generateTokens generates access and refresh tokens
-func (u *usecase) generateTokens(user *models.User) (*models.TokenResponse, error) {
+// DeleteAccount deletes a user's own account once they have confirmed it with their password
+func (u *usecase) DeleteAccount(ctx context.Context, userID uuid.UUID, req *models.DeleteAccountRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.AuthProvider == "email" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			return models.ErrIncorrectPassword
+		}
+	}
+
+	if err := u.repo.DeleteUser(ctx, userID); err != nil {
+		return err
+	}
+
+	// The account is gone, so failing to remove the image only leaves an orphaned file
+	if user.ProfileImagePath != "" && u.storage != nil {
+		if err := u.storage.DeleteFile(user.ProfileImagePath); err != nil {
+			logger.FromContext(ctx).Warn("Failed to remove profile image of deleted account",
+				logger.Field("user_id", userID),
+				logger.Field("path", user.ProfileImagePath),
+				logger.Field("error", err))
+		}
+	}
+
+	// The actor is left out as the audit log can't refer to a deleted user
+	u.recordAudit(ctx, auditModels.NewAuditEntry(uuid.Nil, auditModels.ActionUserDelete, auditModels.TargetUser, userID.String(), nil, nil))
+
+	return nil
+}
+
+// startSession records a new session for a user who just logged in and issues its tokens
+func (u *usecase) startSession(ctx context.Context, user *models.User, client models.ClientInfo) (*models.TokenResponse, error) {
+	session := &models.Session{
+		UserID:      user.ID,
+		UserAgent:   client.UserAgent,
+		IPAddress:   client.IPAddress,
+		CountryCode: client.CountryCode,
+		ExpiresAt:   time.Now().Add(u.refreshTokenLifetime()).UTC(),
+	}
+	if err := u.repo.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return u.generateTokens(user, session.ID)
+}
+
+// refreshTokenLifetime is how long refresh tokens, and so idle sessions, last
+func (u *usecase) refreshTokenLifetime() time.Duration {
+	return time.Duration(u.cfg.JWT.RefreshExpiryDays) * 24 * time.Hour
+}
+
+// generateTokens generates access and refresh tokens for a session
+func (u *usecase) generateTokens(user *models.User, sessionID uuid.UUID) (*models.TokenResponse, error) {
+	issuedAt := time.Now()
+
 	// Access token expiry
 	accessExpiry := time.Now().Add(time.Duration(u.cfg.JWT.AccessExpiryMinutes) * time.Minute)
 
 	// Create access token claims
 	accessClaims := jwt.MapClaims{
+		"jti":       uuid.New().String(),
 		"user_id":   user.ID.String(),
 		"email":     user.Email,
 		"user_type": user.UserType,
+		"sid":       sessionID.String(),
+		"iat":       issuedAt.Unix(),
 		"exp":       accessExpiry.Unix(),
 	}
 
@@ -421,11 +775,15 @@ func (u *usecase) generateTokens(user *models.User) (*models.TokenResponse, erro
 	}
 
 	// Refresh token expiry
-	refreshExpiry := time.Now().Add(time.Duration(u.cfg.JWT.RefreshExpiryDays) * 24 * time.Hour)
+	refreshExpiry := time.Now().Add(u.refreshTokenLifetime())
 
-	// Create refresh token claims
+	// Create refresh token claims. The issue time has microseconds, as tokens_revoked_at does,
+	// so tokens issued in the same second as logging out everywhere are told apart.
 	refreshClaims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
 		"user_id": user.ID.String(),
+		"sid":     sessionID.String(),
+		"iat":     float64(issuedAt.UnixMicro()) / 1e6,
 		"exp":     refreshExpiry.Unix(),
 	}
 
@@ -446,4 +804,189 @@ func (u *usecase) generateTokens(user *models.User) (*models.TokenResponse, erro
 	}
 
 	return tokenResponse, nil
+}
+
+// refreshTokenClaims are the claims of a verified refresh token
+type refreshTokenClaims struct {
+	jti       uuid.UUID
+	userID    uuid.UUID
+	sessionID uuid.UUID // nil for tokens issued before sessions
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// parseRefreshToken verifies a refresh token and extracts its claims. Tokens issued
+// before they carried a jti cannot be revoked, so they are no longer accepted.
+func (u *usecase) parseRefreshToken(tokenStr string) (*refreshTokenClaims, error) {
+	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(u.cfg.JWT.RefreshSecret), nil
+	})
+
+	if err != nil || !token.Valid {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	// Extract claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+
+	jtiStr, ok := claims["jti"].(string)
+	if !ok {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	jti, err := uuid.Parse(jtiStr)
+	if err != nil {
+		return nil, models.ErrInvalidRefreshToken
+	}
+
+	// Extract user ID
+	userIDStr, ok := claims["user_id"].(string)
+	if !ok {
+		return nil, errors.New("invalid user ID in token")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	var sessionID uuid.UUID
+	if sessionIDStr, ok := claims["sid"].(string); ok {
+		if sessionID, err = uuid.Parse(sessionIDStr); err != nil {
+			return nil, models.ErrInvalidRefreshToken
+		}
+	}
+
+	// JSON numbers are decoded as float64
+	issuedAt, _ := claims["iat"].(float64)
+	expiresAt, _ := claims["exp"].(float64)
+
+	return &refreshTokenClaims{
+		jti:       jti,
+		userID:    userID,
+		sessionID: sessionID,
+		issuedAt:  time.UnixMicro(int64(math.Round(issuedAt * 1e6))).UTC(),
+		expiresAt: time.Unix(int64(expiresAt), 0).UTC(),
+	}, nil
+}
+
+// generateToken generates a random one-time token, such as a password reset token
+func generateToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// hashToken hashes a one-time token for storage and lookup
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// profileSnapshot captures the editable profile fields of a user for the audit log
+func profileSnapshot(user *models.User) map[string]string {
+	return map[string]string{
+		"full_name":          user.FullName,
+		"bio":                user.Bio,
+		"preferred_language": user.PreferredLanguage,
+		"hide_explicit":      strconv.FormatBool(user.HideExplicit),
+		"profile_image_url":  user.ProfileImageURL,
+	}
+}
+
+// BootstrapAdmins promotes the accounts with the given emails to admin. Only accounts that
+// verified their email are promoted, so nobody becomes admin by registering a listed address
+// first; missing and unverified accounts are logged and skipped.
+func (u *usecase) BootstrapAdmins(ctx context.Context, emails []string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	for _, email := range emails {
+		user, err := u.repo.GetUserByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, models.ErrUserNotFound) {
+				logger.FromContext(ctx).Warn("Admin account not found",
+					logger.Field("email", email))
+				continue
+			}
+			return err
+		}
+
+		if user.UserType == "admin" {
+			continue
+		}
+		if !user.IsVerified {
+			logger.FromContext(ctx).Warn("Admin account has not verified its email, not promoting it",
+				logger.Field("user_id", user.ID))
+			continue
+		}
+
+		if err := u.promoteAdmin(ctx, user); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PromoteAdmin makes the account with an email an admin whether or not it verified its email.
+// It is for operators running the auth service with -promote-admin, e.g. to create the first
+// admin before a mail server is set up.
+func (u *usecase) PromoteAdmin(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user.UserType == "admin" {
+		return nil
+	}
+
+	return u.promoteAdmin(ctx, user)
+}
+
+// promoteAdmin makes a user an admin
+func (u *usecase) promoteAdmin(ctx context.Context, user *models.User) error {
+	if err := u.repo.SetUserType(ctx, user.ID, "admin"); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Promoted account to admin",
+		logger.Field("user_id", user.ID))
+
+	u.recordAudit(ctx, auditModels.NewAuditEntry(uuid.Nil, auditModels.ActionUserPromoteAdmin, auditModels.TargetUser, user.ID.String(),
+		map[string]string{"user_type": user.UserType}, map[string]string{"user_type": "admin"}))
+
+	return nil
+}
+
+// DeleteExpiredRevokedTokens forgets revoked refresh tokens that have expired, which are
+// rejected for their expiry anyway. Returns the number of tokens deleted.
+func (u *usecase) DeleteExpiredRevokedTokens(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+
+	return u.repo.DeleteExpiredRevokedTokens(ctx, time.Now().UTC())
+}
+
+// recordAudit records an audit entry, ignoring failures so the audited change is not reported as failed
+func (u *usecase) recordAudit(ctx context.Context, entry *auditModels.AuditEntry) {
+	if u.audit == nil {
+		return
+	}
+	if err := u.audit.Record(ctx, entry); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record audit entry",
+			logger.Field("action", entry.Action),
+			logger.Field("target_id", entry.TargetID),
+			logger.Field("error", err))
+	}
 }
\ No newline at end of file
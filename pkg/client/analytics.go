@@ -0,0 +1,79 @@
+// pkg/client/analytics.go
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/analytics/models"
+	"github.com/google/uuid"
+)
+
+// AnalyticsClient wraps the analytics service API
+type AnalyticsClient struct {
+	client *Client
+}
+
+// TrackListenResponse represents the response to a tracked listen event
+type TrackListenResponse struct {
+	Status   string    `json:"status"`
+	ListenID uuid.UUID `json:"listen_id"`
+}
+
+// TrackListen records a listen event
+func (ac *AnalyticsClient) TrackListen(ctx context.Context, req *models.TrackListenRequest) (*TrackListenResponse, error) {
+	var result TrackListenResponse
+	if err := ac.client.post(ctx, "/analytics/track-listen", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetEpisodeAnalytics gets analytics for an episode
+func (ac *AnalyticsClient) GetEpisodeAnalytics(ctx context.Context, episodeID uuid.UUID, params models.AnalyticsParams) (*models.EpisodeAnalytics, error) {
+	var analytics models.EpisodeAnalytics
+	if err := ac.client.get(ctx, "/analytics/episodes/"+episodeID.String(), analyticsQuery(params), &analytics); err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// GetPodcastAnalytics gets analytics for a podcast
+func (ac *AnalyticsClient) GetPodcastAnalytics(ctx context.Context, podcastID uuid.UUID, params models.AnalyticsParams) (*models.PodcastAnalytics, error) {
+	var analytics models.PodcastAnalytics
+	if err := ac.client.get(ctx, "/analytics/podcasts/"+podcastID.String(), analyticsQuery(params), &analytics); err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// GetPodcasterAnalytics gets analytics for the authenticated podcaster
+func (ac *AnalyticsClient) GetPodcasterAnalytics(ctx context.Context, params models.AnalyticsParams) (*models.PodcasterAnalytics, error) {
+	var analytics models.PodcasterAnalytics
+	if err := ac.client.get(ctx, "/analytics/podcaster", analyticsQuery(params), &analytics); err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// GetListeningHistory gets the authenticated user's listening history
+func (ac *AnalyticsClient) GetListeningHistory(ctx context.Context, page, pageSize int) (*Page[models.ListeningHistoryItem], error) {
+	var result Page[models.ListeningHistoryItem]
+	if err := ac.client.get(ctx, "/analytics/history", paginationQuery(page, pageSize), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// analyticsQuery builds the date range and interval query parameters
+func analyticsQuery(params models.AnalyticsParams) url.Values {
+	query := url.Values{}
+	if !params.StartDate.IsZero() {
+		query.Set("start_date", params.StartDate.Format("2006-01-02"))
+	}
+	if !params.EndDate.IsZero() {
+		query.Set("end_date", params.EndDate.Format("2006-01-02"))
+	}
+	setIfNotEmpty(query, "interval", params.Interval)
+	return query
+}
@@ -0,0 +1,85 @@
+// pkg/client/auth.go
+package client
+
+import (
+	"context"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/auth/models"
+)
+
+// AuthClient wraps the auth service API
+type AuthClient struct {
+	client *Client
+}
+
+// Register registers a new user
+func (a *AuthClient) Register(ctx context.Context, req *models.RegisterRequest) (*models.User, error) {
+	var user models.User
+	if err := a.client.post(ctx, "/auth/register", req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Login logs a user in and uses the returned access token for subsequent requests
+func (a *AuthClient) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
+	return a.authenticate(ctx, "/auth/login", req)
+}
+
+// SocialLogin logs a user in with a social provider token and uses the returned access token for subsequent requests
+func (a *AuthClient) SocialLogin(ctx context.Context, req *models.SocialLoginRequest) (*models.TokenResponse, error) {
+	return a.authenticate(ctx, "/auth/social-login", req)
+}
+
+// RefreshToken exchanges a refresh token for new tokens and uses the new access token for subsequent requests
+func (a *AuthClient) RefreshToken(ctx context.Context, refreshToken string) (*models.TokenResponse, error) {
+	return a.authenticate(ctx, "/auth/refresh-token", &models.RefreshTokenRequest{RefreshToken: refreshToken})
+}
+
+// ForgotPassword starts the password reset flow for an email address
+func (a *AuthClient) ForgotPassword(ctx context.Context, req *models.ForgotPasswordRequest) error {
+	return a.client.post(ctx, "/auth/forgot-password", req, nil)
+}
+
+// ResetPassword sets a new password using a reset token
+func (a *AuthClient) ResetPassword(ctx context.Context, req *models.ResetPasswordRequest) error {
+	return a.client.post(ctx, "/auth/reset-password", req, nil)
+}
+
+// VerifyEmail verifies an email address using a verification token
+func (a *AuthClient) VerifyEmail(ctx context.Context, req *models.VerifyEmailRequest) error {
+	return a.client.post(ctx, "/auth/verify-email", req, nil)
+}
+
+// GetProfile gets the authenticated user's profile
+func (a *AuthClient) GetProfile(ctx context.Context) (*models.User, error) {
+	var user models.User
+	if err := a.client.get(ctx, "/auth/profile", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateProfile updates the authenticated user's profile
+func (a *AuthClient) UpdateProfile(ctx context.Context, req *models.UpdateProfileRequest) (*models.User, error) {
+	var user models.User
+	if err := a.client.put(ctx, "/auth/profile", req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ChangePassword changes the authenticated user's password
+func (a *AuthClient) ChangePassword(ctx context.Context, req *models.ChangePasswordRequest) error {
+	return a.client.post(ctx, "/auth/change-password", req, nil)
+}
+
+// authenticate posts credentials to a token endpoint and stores the returned access token
+func (a *AuthClient) authenticate(ctx context.Context, path string, req interface{}) (*models.TokenResponse, error) {
+	var tokens models.TokenResponse
+	if err := a.client.post(ctx, path, req, &tokens); err != nil {
+		return nil, err
+	}
+	a.client.SetAccessToken(tokens.AccessToken)
+	return &tokens, nil
+}
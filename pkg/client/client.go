@@ -0,0 +1,132 @@
+// pkg/client/client.go
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Client is a typed HTTP client for the platform APIs
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	tokenMutex  sync.RWMutex
+	accessToken string
+
+	Auth           *AuthClient
+	Content        *ContentClient
+	Analytics      *AnalyticsClient
+	Recommendation *RecommendationClient
+}
+
+// NewClient creates a new API client. baseURL is the API root, e.g. http://localhost:8080/api/v1.
+// If httpClient is nil a client with a 30 second timeout is used.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+	}
+	c.Auth = &AuthClient{client: c}
+	c.Content = &ContentClient{client: c}
+	c.Analytics = &AnalyticsClient{client: c}
+	c.Recommendation = &RecommendationClient{client: c}
+
+	return c
+}
+
+// SetAccessToken sets the bearer token sent with every request
+func (c *Client) SetAccessToken(token string) {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	c.accessToken = token
+}
+
+// AccessToken returns the bearer token currently in use
+func (c *Client) AccessToken() string {
+	c.tokenMutex.RLock()
+	defer c.tokenMutex.RUnlock()
+	return c.accessToken
+}
+
+// get sends a GET request and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// post sends a POST request with a JSON body and decodes the JSON response into out
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, nil, body, out)
+}
+
+// put sends a PUT request with a JSON body and decodes the JSON response into out
+func (c *Client) put(ctx context.Context, path string, body, out interface{}) error {
+	return c.do(ctx, http.MethodPut, path, nil, body, out)
+}
+
+// delete sends a DELETE request
+func (c *Client) delete(ctx context.Context, path string) error {
+	return c.do(ctx, http.MethodDelete, path, nil, nil, nil)
+}
+
+// do performs a request against the API, injecting the access token and decoding errors into *APIError
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.AccessToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
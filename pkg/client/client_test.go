@@ -0,0 +1,236 @@
+// pkg/client/client_test.go
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	authModels "github.com/MHK-26/pod_platfrom_go/pkg/auth/models"
+	contentModels "github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// newTestClient returns a client of an httptest server running handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL+"/api/v1/", server.Client())
+}
+
+// podcastPages serves /api/v1/podcasts from titles, paginated as the API does
+func podcastPages(t *testing.T, titles []string, requestedPages *[]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/podcasts" {
+			t.Errorf("request path = %s, want /api/v1/podcasts", r.URL.Path)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		*requestedPages = append(*requestedPages, page)
+
+		data := []contentModels.PodcastResponse{}
+		for i := (page - 1) * pageSize; i < len(titles) && i < page*pageSize; i++ {
+			data = append(data, contentModels.PodcastResponse{Podcast: contentModels.Podcast{Title: titles[i]}})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data":        data,
+			"total_count": len(titles),
+			"page":        page,
+			"page_size":   pageSize,
+			"total_pages": (len(titles) + pageSize - 1) / pageSize,
+		})
+	}
+}
+
+func TestIterateWalksEveryPage(t *testing.T) {
+	titles := []string{"One", "Two", "Three", "Four", "Five"}
+	var requestedPages []int
+	c := newTestClient(t, podcastPages(t, titles, &requestedPages))
+
+	fetch := func(ctx context.Context, page, pageSize int) (*Page[contentModels.PodcastResponse], error) {
+		return c.Content.ListPodcasts(ctx, contentModels.PodcastSearchParams{Page: page, PageSize: pageSize})
+	}
+	podcasts, err := CollectAll(context.Background(), 2, fetch)
+	if err != nil {
+		t.Fatalf("CollectAll() error = %v", err)
+	}
+
+	var got []string
+	for _, podcast := range podcasts {
+		got = append(got, podcast.Title)
+	}
+	if !reflect.DeepEqual(got, titles) {
+		t.Errorf("CollectAll() = %v, want %v", got, titles)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(requestedPages, want) {
+		t.Errorf("requested pages = %v, want %v", requestedPages, want)
+	}
+}
+
+func TestIterateStops(t *testing.T) {
+	errStop := errors.New("stop")
+
+	tests := []struct {
+		name      string
+		pages     []*Page[int]
+		fn        func(item int) error
+		wantErr   error
+		wantItems []int
+	}{
+		{
+			name:  "at the callback's error",
+			pages: []*Page[int]{{Data: []int{1, 2}, Page: 1, TotalPages: 2}, {Data: []int{3, 4}, Page: 2, TotalPages: 2}},
+			fn: func(item int) error {
+				if item == 2 {
+					return errStop
+				}
+				return nil
+			},
+			wantErr:   errStop,
+			wantItems: []int{1, 2},
+		},
+		{
+			// The total may have shrunk since the first page, which mustn't loop forever
+			name:      "at an empty page",
+			pages:     []*Page[int]{{Data: []int{1}, Page: 1, TotalPages: 5}, {Data: nil, Page: 2, TotalPages: 5}},
+			wantItems: []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch := func(ctx context.Context, page, pageSize int) (*Page[int], error) {
+				if page > len(tt.pages) {
+					t.Fatalf("fetched page %d of %d", page, len(tt.pages))
+				}
+				return tt.pages[page-1], nil
+			}
+
+			var items []int
+			err := Iterate(context.Background(), 2, fetch, func(item int) error {
+				items = append(items, item)
+				if tt.fn != nil {
+					return tt.fn(item)
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Iterate() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(items, tt.wantItems) {
+				t.Errorf("Iterate() items = %v, want %v", items, tt.wantItems)
+			}
+		})
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantErrors  map[string]string
+		is          func(error) bool
+	}{
+		{
+			name:        "error response",
+			status:      http.StatusNotFound,
+			body:        `{"status":404,"message":"Podcast not found"}`,
+			wantMessage: "Podcast not found",
+			is:          IsNotFound,
+		},
+		{
+			name:        "validation failure",
+			status:      http.StatusBadRequest,
+			body:        `{"status":400,"message":"Validation failed","errors":{"rss_url":"must be a URL"}}`,
+			wantMessage: "Validation failed",
+			wantErrors:  map[string]string{"rss_url": "must be a URL"},
+			is:          IsBadRequest,
+		},
+		{
+			name:        "not JSON",
+			status:      http.StatusBadGateway,
+			body:        "upstream unavailable",
+			wantMessage: "upstream unavailable",
+		},
+		{
+			name:        "empty body",
+			status:      http.StatusForbidden,
+			wantMessage: "Forbidden",
+			is:          IsForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := c.Auth.GetProfile(context.Background())
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("GetProfile() error = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != tt.wantMessage {
+				t.Errorf("APIError = %d %q, want %d %q", apiErr.StatusCode, apiErr.Message, tt.status, tt.wantMessage)
+			}
+			if !reflect.DeepEqual(apiErr.Errors, tt.wantErrors) {
+				t.Errorf("APIError.Errors = %v, want %v", apiErr.Errors, tt.wantErrors)
+			}
+			if tt.is != nil && !tt.is(err) {
+				t.Errorf("status check of %v = false, want true", err)
+			}
+			if tt.status != http.StatusNotFound && IsNotFound(err) {
+				t.Errorf("IsNotFound(%v) = true, want false", err)
+			}
+		})
+	}
+}
+
+func TestLoginSetsAccessToken(t *testing.T) {
+	var profileAuthorization []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/login":
+			if got := r.Header.Get("Authorization"); got != "" {
+				t.Errorf("login Authorization = %q, want none", got)
+			}
+			json.NewEncoder(w).Encode(authModels.TokenResponse{AccessToken: "access-1", RefreshToken: "refresh-1"})
+		case "/api/v1/auth/profile":
+			profileAuthorization = append(profileAuthorization, r.Header.Get("Authorization"))
+			json.NewEncoder(w).Encode(authModels.User{Email: "listener@example.com"})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	if _, err := c.Auth.Login(context.Background(), &authModels.LoginRequest{}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if got := c.AccessToken(); got != "access-1" {
+		t.Errorf("AccessToken() = %q, want %q", got, "access-1")
+	}
+	if _, err := c.Auth.GetProfile(context.Background()); err != nil {
+		t.Fatalf("GetProfile() error = %v", err)
+	}
+
+	// A token set by hand replaces the one from the login
+	c.SetAccessToken("access-2")
+	if _, err := c.Auth.GetProfile(context.Background()); err != nil {
+		t.Fatalf("GetProfile() error = %v", err)
+	}
+
+	if want := []string{"Bearer access-1", "Bearer access-2"}; !reflect.DeepEqual(profileAuthorization, want) {
+		t.Errorf("profile Authorization headers = %v, want %v", profileAuthorization, want)
+	}
+}
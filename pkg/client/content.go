@@ -0,0 +1,140 @@
+// pkg/client/content.go
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/google/uuid"
+)
+
+// ContentClient wraps the content service API
+type ContentClient struct {
+	client *Client
+}
+
+// GetPodcast gets a podcast by ID
+func (cc *ContentClient) GetPodcast(ctx context.Context, id uuid.UUID) (*models.PodcastResponse, error) {
+	var podcast models.PodcastResponse
+	if err := cc.client.get(ctx, "/podcasts/"+id.String(), nil, &podcast); err != nil {
+		return nil, err
+	}
+	return &podcast, nil
+}
+
+// ListPodcasts lists podcasts with optional filtering
+func (cc *ContentClient) ListPodcasts(ctx context.Context, params models.PodcastSearchParams) (*Page[models.PodcastResponse], error) {
+	query := paginationQuery(params.Page, params.PageSize)
+	setIfNotEmpty(query, "query", params.Query)
+	setIfNotEmpty(query, "category", params.Category)
+	setIfNotEmpty(query, "language", params.Language)
+	setIfNotEmpty(query, "sort_by", params.SortBy)
+	setIfNotEmpty(query, "sort_order", params.SortOrder)
+
+	var page Page[models.PodcastResponse]
+	if err := cc.client.get(ctx, "/podcasts", query, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetPodcastsByUser lists the podcasts created by a user
+func (cc *ContentClient) GetPodcastsByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) (*Page[models.PodcastResponse], error) {
+	var result Page[models.PodcastResponse]
+	if err := cc.client.get(ctx, "/users/"+userID.String()+"/podcasts", paginationQuery(page, pageSize), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreatePodcast creates a podcast from an RSS feed
+func (cc *ContentClient) CreatePodcast(ctx context.Context, req *models.CreatePodcastRequest) (*models.Podcast, error) {
+	var podcast models.Podcast
+	if err := cc.client.post(ctx, "/podcasts", req, &podcast); err != nil {
+		return nil, err
+	}
+	return &podcast, nil
+}
+
+// UpdatePodcast updates a podcast
+func (cc *ContentClient) UpdatePodcast(ctx context.Context, id uuid.UUID, req *models.UpdatePodcastRequest) (*models.Podcast, error) {
+	var podcast models.Podcast
+	if err := cc.client.put(ctx, "/podcasts/"+id.String(), req, &podcast); err != nil {
+		return nil, err
+	}
+	return &podcast, nil
+}
+
+// DeletePodcast deletes a podcast
+func (cc *ContentClient) DeletePodcast(ctx context.Context, id uuid.UUID) error {
+	return cc.client.delete(ctx, "/podcasts/"+id.String())
+}
+
+// SyncPodcast triggers a background sync of a podcast from its RSS feed
+func (cc *ContentClient) SyncPodcast(ctx context.Context, id uuid.UUID) error {
+	return cc.client.post(ctx, "/podcasts/"+id.String()+"/sync", nil, nil)
+}
+
+// ResumePodcastSync re-enables a suspended podcast sync
+func (cc *ContentClient) ResumePodcastSync(ctx context.Context, id uuid.UUID) error {
+	return cc.client.post(ctx, "/podcasts/"+id.String()+"/sync/resume", nil, nil)
+}
+
+// GetEpisode gets an episode by ID
+func (cc *ContentClient) GetEpisode(ctx context.Context, id uuid.UUID) (*models.EpisodeResponse, error) {
+	var episode models.EpisodeResponse
+	if err := cc.client.get(ctx, "/episodes/"+id.String(), nil, &episode); err != nil {
+		return nil, err
+	}
+	return &episode, nil
+}
+
+// GetEpisodesByPodcast lists the episodes of a podcast
+func (cc *ContentClient) GetEpisodesByPodcast(ctx context.Context, podcastID uuid.UUID, page, pageSize int) (*Page[models.EpisodeResponse], error) {
+	var result Page[models.EpisodeResponse]
+	if err := cc.client.get(ctx, "/podcasts/"+podcastID.String()+"/episodes", paginationQuery(page, pageSize), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BulkDeleteEpisodes deletes several episodes of a podcast and returns how many were deleted
+func (cc *ContentClient) BulkDeleteEpisodes(ctx context.Context, podcastID uuid.UUID, req *models.BulkDeleteEpisodesRequest) (int, error) {
+	var result models.BulkDeleteEpisodesResponse
+	if err := cc.client.post(ctx, "/podcasts/"+podcastID.String()+"/episodes/bulk-delete", req, &result); err != nil {
+		return 0, err
+	}
+	return result.Deleted, nil
+}
+
+// ListCategories lists the podcast categories
+func (cc *ContentClient) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	var categories []*models.Category
+	if err := cc.client.get(ctx, "/categories", nil, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// Subscribe subscribes the authenticated user to a podcast
+func (cc *ContentClient) Subscribe(ctx context.Context, podcastID uuid.UUID) error {
+	return cc.client.post(ctx, "/podcasts/"+podcastID.String()+"/subscribe", nil, nil)
+}
+
+// Unsubscribe unsubscribes the authenticated user from a podcast
+func (cc *ContentClient) Unsubscribe(ctx context.Context, podcastID uuid.UUID) error {
+	return cc.client.post(ctx, "/podcasts/"+podcastID.String()+"/unsubscribe", nil, nil)
+}
+
+// SavePlaybackPosition saves the authenticated user's playback position for an episode
+func (cc *ContentClient) SavePlaybackPosition(ctx context.Context, req *models.SavePlaybackPositionRequest) error {
+	return cc.client.post(ctx, "/episodes/playback", req, nil)
+}
+
+// setIfNotEmpty sets a query parameter only when the value is not empty
+func setIfNotEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
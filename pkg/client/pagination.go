@@ -0,0 +1,68 @@
+// pkg/client/pagination.go
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// Page represents one page of a paginated API response
+type Page[T any] struct {
+	Data       []T `json:"data"`
+	TotalCount int `json:"total_count"`
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+	TotalPages int `json:"total_pages"`
+}
+
+// HasNext reports whether there is a page after this one
+func (p *Page[T]) HasNext() bool {
+	return p.Page < p.TotalPages
+}
+
+// PageFunc fetches a single page of results
+type PageFunc[T any] func(ctx context.Context, page, pageSize int) (*Page[T], error)
+
+// Iterate walks every page returned by fetch, calling fn for each item until the pages are
+// exhausted or fn returns an error
+func Iterate[T any](ctx context.Context, pageSize int, fetch PageFunc[T], fn func(item T) error) error {
+	for page := 1; ; page++ {
+		result, err := fetch(ctx, page, pageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Data {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		if !result.HasNext() || len(result.Data) == 0 {
+			return nil
+		}
+	}
+}
+
+// CollectAll fetches every page returned by fetch and returns all items
+func CollectAll[T any](ctx context.Context, pageSize int, fetch PageFunc[T]) ([]T, error) {
+	var items []T
+	err := Iterate(ctx, pageSize, fetch, func(item T) error {
+		items = append(items, item)
+		return nil
+	})
+	return items, err
+}
+
+// paginationQuery builds the page/page_size query parameters
+func paginationQuery(page, pageSize int) url.Values {
+	query := url.Values{}
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if pageSize > 0 {
+		query.Set("page_size", strconv.Itoa(pageSize))
+	}
+	return query
+}
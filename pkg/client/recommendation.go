@@ -0,0 +1,65 @@
+// pkg/client/recommendation.go
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
+	"github.com/google/uuid"
+)
+
+// RecommendationClient wraps the recommendation service API
+type RecommendationClient struct {
+	client *Client
+}
+
+// GetPersonalizedRecommendations gets recommendations for the authenticated user.
+// The user is taken from the access token, so req.UserID is ignored.
+func (rc *RecommendationClient) GetPersonalizedRecommendations(ctx context.Context, req *models.RecommendationRequest) (*models.RecommendationResponse, error) {
+	return rc.fetch(ctx, "/recommendations/personalized", recommendationQuery(req.Limit, req.ExcludedIDs))
+}
+
+// GetSimilarPodcasts gets podcasts similar to req.ContentID
+func (rc *RecommendationClient) GetSimilarPodcasts(ctx context.Context, req *models.SimilarContentRequest) (*models.RecommendationResponse, error) {
+	return rc.fetch(ctx, "/recommendations/similar/podcasts/"+req.ContentID.String(), recommendationQuery(req.Limit, req.ExcludedIDs))
+}
+
+// GetSimilarEpisodes gets episodes similar to req.ContentID
+func (rc *RecommendationClient) GetSimilarEpisodes(ctx context.Context, req *models.SimilarContentRequest) (*models.RecommendationResponse, error) {
+	return rc.fetch(ctx, "/recommendations/similar/episodes/"+req.ContentID.String(), recommendationQuery(req.Limit, req.ExcludedIDs))
+}
+
+// GetTrendingPodcasts gets trending podcasts for a time range
+func (rc *RecommendationClient) GetTrendingPodcasts(ctx context.Context, req *models.TrendingRequest) (*models.RecommendationResponse, error) {
+	query := recommendationQuery(req.Limit, req.ExcludedIDs)
+	setIfNotEmpty(query, "time_range", req.TimeRange)
+	return rc.fetch(ctx, "/recommendations/trending", query)
+}
+
+// GetPopularInCategory gets popular podcasts in a category
+func (rc *RecommendationClient) GetPopularInCategory(ctx context.Context, req *models.CategoryPopularRequest) (*models.RecommendationResponse, error) {
+	return rc.fetch(ctx, "/recommendations/categories/"+req.CategoryID.String()+"/popular", recommendationQuery(req.Limit, req.ExcludedIDs))
+}
+
+// fetch gets a recommendation response from path
+func (rc *RecommendationClient) fetch(ctx context.Context, path string, query url.Values) (*models.RecommendationResponse, error) {
+	var response models.RecommendationResponse
+	if err := rc.client.get(ctx, path, query, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// recommendationQuery builds the limit and excluded_ids query parameters
+func recommendationQuery(limit int, excludedIDs []uuid.UUID) url.Values {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	for _, id := range excludedIDs {
+		query.Add("excluded_ids", id.String())
+	}
+	return query
+}
@@ -0,0 +1,45 @@
+// pkg/common/cache/cache.go
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores JSON-encoded values under string keys for a limited time
+type Cache interface {
+	// Get decodes the value stored under key into dest, reporting whether it was found
+	Get(ctx context.Context, key string, dest interface{}) (bool, error)
+
+	// Set stores value under key, expiring after ttl
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+
+	// Delete removes the values stored under the given keys
+	Delete(ctx context.Context, keys ...string) error
+
+	// Ping checks the cache backend is reachable
+	Ping(ctx context.Context) error
+}
+
+type noopCache struct{}
+
+// NewNoopCache creates a cache that stores nothing, so every lookup misses
+func NewNoopCache() Cache {
+	return noopCache{}
+}
+
+func (noopCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	return false, nil
+}
+
+func (noopCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return nil
+}
+
+func (noopCache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (noopCache) Ping(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,73 @@
+// pkg/common/cache/redis.go
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache creates a cache backed by the configured Redis server
+func NewRedisCache(cfg *config.RedisConfig) Cache {
+	return &redisCache{
+		client: redis.NewClient(&redis.Options{
+			Addr:     cfg.Addr,
+			Password: cfg.Password,
+			DB:       cfg.DB,
+		}),
+	}
+}
+
+// NewCache creates a Redis cache when a Redis address is configured, otherwise a no-op cache
+func NewCache(cfg *config.RedisConfig) Cache {
+	if cfg.Addr == "" {
+		return NewNoopCache()
+	}
+	return NewRedisCache(cfg)
+}
+
+// Get decodes the value stored under key into dest
+func (c *redisCache) Get(ctx context.Context, key string, dest interface{}) (bool, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Set stores value under key as JSON
+func (c *redisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, key, data, ttl).Err()
+}
+
+// Delete removes the values stored under the given keys
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// Ping checks the Redis server answers
+func (c *redisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
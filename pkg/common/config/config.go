@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -11,11 +13,19 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Server   ServerConfig
-	DB       DBConfig
-	JWT      JWTConfig
-	Storage  StorageConfig
-	MediaURL string
+	Server         ServerConfig
+	CORS           CORSConfig
+	DB             DBConfig
+	JWT            JWTConfig
+	Auth           AuthConfig
+	Storage        StorageConfig
+	Redis          RedisConfig
+	Content        ContentConfig
+	Recommendation RecommendationConfig
+	Analytics      AnalyticsConfig
+	MediaURL       string
+
+	parseErrors []error // Environment variables that failed to parse, reported by Validate
 }
 
 // ServerConfig represents the server configuration
@@ -24,6 +34,19 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	Mode         string
+
+	MaxConcurrentRequests      int      // In-flight requests allowed per user (or IP when anonymous); zero disables the limit
+	ConcurrencyLimitExemptions []string // Path prefixes of long-lived routes (long-poll, SSE) that are not limited
+	TrustedProxies             []string // Addresses or CIDRs of reverse proxies whose X-Forwarded-For gives the client IP; none when empty
+}
+
+// CORSConfig represents the cross-origin resource sharing configuration
+type CORSConfig struct {
+	AllowedOrigins   []string      // Origins allowed to call the API, e.g. https://app.example.com; "*" allows any origin
+	AllowedMethods   []string      // Methods allowed in cross-origin requests
+	AllowedHeaders   []string      // Request headers allowed in cross-origin requests
+	AllowCredentials bool          // Let browsers send cookies and authorization headers cross-origin
+	MaxAge           time.Duration // How long browsers may cache a preflight response
 }
 
 // DBConfig represents the database configuration
@@ -37,6 +60,12 @@ type DBConfig struct {
 	MaxConns int
 	MaxIdle  int
 	Timeout  time.Duration
+
+	ConnectAttempts   int           // Attempts to connect on startup before giving up
+	ConnectRetryDelay time.Duration // Delay before the first retry, doubled on each further retry
+	ConnectMaxWait    time.Duration // Longest time spent connecting on startup, across all attempts; zero for no limit
+
+	SlowQueryThreshold time.Duration // Queries running longer than this are logged as slow; zero disables it
 }
 
 // JWTConfig represents the JWT configuration
@@ -47,22 +76,129 @@ type JWTConfig struct {
 	RefreshExpiryDays   int
 }
 
+// AuthConfig represents the account configuration
+type AuthConfig struct {
+	AdminEmails      []string // Verified accounts promoted to admin when the auth service starts
+	VerifyEmailURL   string   // Web app page verifying emails; the emailed link adds ?token=
+	ResetPasswordURL string   // Web app page resetting passwords; the emailed link adds ?token=
+
+	SMTPAddr     string // host:port of the mail server; emails are logged instead of sent when empty
+	SMTPUsername string // Mail server login; no authentication when empty
+	SMTPPassword string
+	MailFrom     string // Sender address of account emails
+}
+
 // StorageConfig represents the file storage configuration
 type StorageConfig struct {
-	BasePath string // Base path for storing files
-	MaxSize  int64  // Maximum file size in bytes
+	Backend      string // Where uploads are stored: "local" or "s3"
+	BasePath     string // Base path for storing files
+	MaxSize      int64  // Maximum file size in bytes
+	MaxImageSize int64  // Maximum size of uploaded images (profile pictures, covers) in bytes
+
+	S3Endpoint        string        // Host (and port) of the S3-compatible store, e.g. s3.amazonaws.com or minio:9000
+	S3Region          string        // Bucket region
+	S3Bucket          string        // Bucket holding uploads
+	S3AccessKeyID     string        // Static credentials; the AWS environment and instance role are used when empty
+	S3SecretAccessKey string        // Secret of S3AccessKeyID
+	S3UseSSL          bool          // Connect to the endpoint over HTTPS
+	S3PublicURL       string        // Base URL of publicly readable objects; files get presigned URLs when empty
+	S3PresignExpiry   time.Duration // Lifetime of presigned file URLs
 }
 
-// LoadConfig loads the application configuration from environment variables
+// ContentConfig represents the content service configuration
+type ContentConfig struct {
+	GRPCPort               string            // Port of the content service's gRPC server, next to its HTTP port
+	DefaultCoverImageURL   string            // Cover returned for podcasts and episodes without artwork
+	CategoryCoverImageURLs map[string]string // Per-category cover overrides, keyed by lower-cased category name
+	SyncMaxFailures        int               // Consecutive feed sync failures before the feed is suspended
+	SyncRetryBaseDelay     time.Duration     // Delay before the first retry of a failed feed sync
+	SyncRetryMaxDelay      time.Duration     // Upper bound for the exponential retry backoff
+	SyncConcurrency        int               // Feeds synced in parallel by a full or retry sync
+	SyncInterval           time.Duration     // Default time between scheduled syncs of a feed; podcasts may override it
+	SyncInitialDelay       time.Duration     // Delay after startup before the first scheduled sync
+	SiteURL                string            // Public web app URL; episode share URLs are SiteURL/episodes/{id}
+	EmbedPlayerURL         string            // Base URL of the embeddable player, suffixed with the episode ID
+	EmbedWidth             int               // Default width of the embedded player in pixels
+	EmbedHeight            int               // Default height of the embedded player in pixels
+	ShortLinkBaseURL       string            // Base URL of short links, suffixed with the code
+	ListenCountBadges      bool              // Show bucketed listen counts (e.g. "1K+") to everyone but owners and admins
+	ListenCountRefresh     time.Duration     // Time between recounts of podcast listens for sorting by listens; zero leaves it to -refresh-listen-counts
+	CommentBlockedWords    []string          // Words that trip the comment filter
+	CommentMaxLinks        int               // Links allowed in a comment before it trips the filter; negative for no limit
+	CommentFilterAction    string            // What happens to comments tripping the filter: "reject" or "flag" for review
+	OPMLImportMaxFeeds     int               // Most feeds accepted in one OPML import
+	OPMLImportConcurrency  int               // Feeds fetched in parallel during an OPML import
+	OPMLImportOwnerID      string            // Account owning podcasts created by OPML imports; feeds not yet in the catalog fail when empty
+	WebhookTimeout         time.Duration     // Time a webhook endpoint has to answer a delivery
+	WebhookMaxAttempts     int               // Delivery attempts before an event is given up on
+	WebhookRetryBaseDelay  time.Duration     // Delay before retrying a failed delivery, doubled on each further attempt
+}
+
+// RedisConfig represents the Redis connection configuration
+type RedisConfig struct {
+	Addr     string // host:port of the Redis server; caching is disabled when empty
+	Password string
+	DB       int
+}
+
+// RecommendationConfig represents the recommendation service configuration
+type RecommendationConfig struct {
+	MaxExcludedIDs   int     // Maximum number of excluded IDs accepted per request
+	PreferenceWeight float64 // Score added to personalized recommendations per unit of explicit category preference weight
+	DislikePenalty   float64 // Score removed from personalized recommendations per disliked item sharing a category
+
+	RatingWeight       float64 // Largest fraction of a trending or popular score a podcast's rating adds or removes; zero ignores ratings
+	RatingPriorReviews float64 // Phantom average reviews added to each podcast's rating, so few reviews can't dominate
+
+	ListenedProgressRatio float64 // Fraction of an episode played from which it counts as heard and is no longer recommended; zero only excludes completed episodes
+
+	DiversityMaxPerPodcaster int // Results from one podcaster ranked ahead of the rest in similar and personalized recommendations; zero disables the cap
+	DiversityMaxPerCategory  int // Results from one category ranked ahead of the rest in similar and personalized recommendations; zero disables the cap
+
+	CacheTTL             time.Duration // How long trending, popular and similar content results are cached
+	PersonalizedCacheTTL time.Duration // How long a user's personalized recommendations are cached
+
+	TrendingTTL time.Duration // How long trending scores precomputed by -compute-trending are served before the live query takes over again
+}
+
+// AnalyticsConfig represents the analytics service configuration
+type AnalyticsConfig struct {
+	SessionGap         time.Duration // Longest pause between listen events of a listener on an episode that still counts as one session
+	ContentServiceAddr string        // host:port of the content service's gRPC server; podcast and episode titles are left empty when unset
+}
+
+// LoadConfig loads the application configuration from environment variables. Values that
+// fail to parse fall back to their zero value; call Validate to report them.
 func LoadConfig() (*Config, error) {
 	// Load .env file if it exists
 	godotenv.Load()
 
+	env := &envParser{}
+
 	// Server config
 	serverPort := getEnv("SERVER_PORT", "8080")
 	serverMode := getEnv("SERVER_MODE", "release")
-	readTimeout, _ := strconv.Atoi(getEnv("SERVER_READ_TIMEOUT", "5"))
-	writeTimeout, _ := strconv.Atoi(getEnv("SERVER_WRITE_TIMEOUT", "5"))
+	readTimeout := env.parseInt("SERVER_READ_TIMEOUT", "5")
+	writeTimeout := env.parseInt("SERVER_WRITE_TIMEOUT", "5")
+	maxConcurrentRequests := env.parseInt("SERVER_MAX_CONCURRENT_REQUESTS", "20")
+	concurrencyLimitExemptions := getEnvList("SERVER_CONCURRENCY_LIMIT_EXEMPT_PATHS")
+	trustedProxies := getEnvList("SERVER_TRUSTED_PROXIES")
+
+	// CORS config; any origin is allowed outside release mode unless origins are listed
+	corsAllowedOrigins := getEnvList("CORS_ALLOWED_ORIGINS")
+	if len(corsAllowedOrigins) == 0 && serverMode != "release" {
+		corsAllowedOrigins = []string{"*"}
+	}
+	corsAllowedMethods := getEnvList("CORS_ALLOWED_METHODS")
+	if len(corsAllowedMethods) == 0 {
+		corsAllowedMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	}
+	corsAllowedHeaders := getEnvList("CORS_ALLOWED_HEADERS")
+	if len(corsAllowedHeaders) == 0 {
+		corsAllowedHeaders = []string{"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", "Origin", "Cache-Control", "X-Requested-With"}
+	}
+	corsAllowCredentials := env.parseBool("CORS_ALLOW_CREDENTIALS", "true")
+	corsMaxAgeSeconds := env.parseInt("CORS_MAX_AGE_SECONDS", "600")
 
 	// Database config
 	dbHost := getEnv("DB_HOST", "localhost")
@@ -71,22 +207,99 @@ func LoadConfig() (*Config, error) {
 	dbPassword := getEnv("DB_PASSWORD", "postgres")
 	dbName := getEnv("DB_NAME", "podcast_platform")
 	dbSSLMode := getEnv("DB_SSL_MODE", "disable")
-	dbMaxConns, _ := strconv.Atoi(getEnv("DB_MAX_CONNS", "20"))
-	dbMaxIdle, _ := strconv.Atoi(getEnv("DB_MAX_IDLE", "5"))
-	dbTimeout, _ := strconv.Atoi(getEnv("DB_TIMEOUT", "5"))
+	dbMaxConns := env.parseInt("DB_MAX_CONNS", "20")
+	dbMaxIdle := env.parseInt("DB_MAX_IDLE", "5")
+	dbTimeout := env.parseInt("DB_TIMEOUT", "5")
+	dbConnectAttempts := env.parseInt("DB_CONNECT_ATTEMPTS", "5")
+	dbConnectRetryDelay := env.parseInt("DB_CONNECT_RETRY_DELAY", "2")
+	dbConnectTimeout := env.parseInt("DB_CONNECT_TIMEOUT", "60")
+	dbSlowQueryThresholdMs := env.parseInt("DB_SLOW_QUERY_THRESHOLD_MS", "500")
 
 	// JWT config
 	jwtAccessSecret := getEnv("JWT_ACCESS_SECRET", "access_secret")
 	jwtRefreshSecret := getEnv("JWT_REFRESH_SECRET", "refresh_secret")
-	jwtAccessExpiryMinutes, _ := strconv.Atoi(getEnv("JWT_ACCESS_EXPIRY_MINUTES", "15"))
-	jwtRefreshExpiryDays, _ := strconv.Atoi(getEnv("JWT_REFRESH_EXPIRY_DAYS", "7"))
+	jwtAccessExpiryMinutes := env.parseInt("JWT_ACCESS_EXPIRY_MINUTES", "15")
+	jwtRefreshExpiryDays := env.parseInt("JWT_REFRESH_EXPIRY_DAYS", "7")
+
+	// Public web app URL, linked to from emails as well as share URLs
+	siteURL := strings.TrimRight(getEnv("SITE_URL", "http://localhost:3000"), "/")
+
+	// Account config; registration only creates listeners and podcasters, so admins are listed here
+	adminEmails := getEnvList("ADMIN_EMAILS")
+	verifyEmailURL := getEnv("VERIFY_EMAIL_URL", siteURL+"/verify-email")
+	resetPasswordURL := getEnv("RESET_PASSWORD_URL", siteURL+"/reset-password")
+	smtpAddr := getEnv("SMTP_ADDR", "")
+	smtpUsername := getEnv("SMTP_USERNAME", "")
+	smtpPassword := getEnv("SMTP_PASSWORD", "")
+	mailFrom := getEnv("MAIL_FROM", "")
 
 	// File storage config
+	storageBackend := getEnv("STORAGE_BACKEND", "local")
 	storagePath := getEnv("STORAGE_PATH", "./storage")
-	maxFileSize, _ := strconv.ParseInt(getEnv("MAX_FILE_SIZE", "52428800"), 10, 64) // 50MB default
+	maxFileSize := env.parseInt64("MAX_FILE_SIZE", "52428800")  // 50MB default
+	maxImageSize := env.parseInt64("MAX_IMAGE_SIZE", "5242880") // 5MB default
+	s3Endpoint := getEnv("S3_ENDPOINT", "s3.amazonaws.com")
+	s3Region := getEnv("S3_REGION", "us-east-1")
+	s3Bucket := getEnv("S3_BUCKET", "")
+	s3AccessKeyID := getEnv("S3_ACCESS_KEY_ID", "")
+	s3SecretAccessKey := getEnv("S3_SECRET_ACCESS_KEY", "")
+	s3UseSSL := env.parseBool("S3_USE_SSL", "true")
+	s3PublicURL := strings.TrimRight(getEnv("S3_PUBLIC_URL", ""), "/")
+	s3PresignExpiryMinutes := env.parseInt("S3_PRESIGN_EXPIRY_MINUTES", "60")
+
+	// Redis config
+	redisAddr := getEnv("REDIS_ADDR", "")
+	redisPassword := getEnv("REDIS_PASSWORD", "")
+	redisDB := env.parseInt("REDIS_DB", "0")
+
+	// Content config
+	contentGRPCPort := getEnv("CONTENT_GRPC_PORT", "8081")
+	defaultCoverImageURL := getEnv("DEFAULT_COVER_IMAGE_URL", "")
+	categoryCoverImageURLs := getEnvMap("CATEGORY_COVER_IMAGE_URLS")
+	syncMaxFailures := env.parseInt("RSS_SYNC_MAX_FAILURES", "5")
+	syncRetryBaseMinutes := env.parseInt("RSS_SYNC_RETRY_BASE_MINUTES", "5")
+	syncRetryMaxMinutes := env.parseInt("RSS_SYNC_RETRY_MAX_MINUTES", "360")
+	syncConcurrency := env.parseInt("RSS_SYNC_CONCURRENCY", "5")
+	syncInterval := env.parseDuration("RSS_SYNC_INTERVAL", "6h")
+	syncInitialDelay := env.parseDuration("RSS_SYNC_INITIAL_DELAY", "1m")
+	embedPlayerURL := strings.TrimRight(getEnv("EMBED_PLAYER_URL", siteURL+"/embed/episodes"), "/")
+	embedWidth := env.parseInt("EMBED_WIDTH", "600")
+	embedHeight := env.parseInt("EMBED_HEIGHT", "180")
+	shortLinkBaseURL := strings.TrimRight(getEnv("SHORT_LINK_BASE_URL", "http://localhost:8080/s"), "/")
+	listenCountBadges := env.parseBool("LISTEN_COUNT_BADGES", "true")
+	listenCountRefresh := env.parseDuration("LISTEN_COUNT_REFRESH_INTERVAL", "15m")
+	commentBlockedWords := getEnvList("COMMENT_BLOCKED_WORDS")
+	commentMaxLinks := env.parseInt("COMMENT_MAX_LINKS", "2")
+	commentFilterAction := getEnv("COMMENT_FILTER_ACTION", "flag")
+	opmlImportMaxFeeds := env.parseInt("OPML_IMPORT_MAX_FEEDS", "500")
+	opmlImportConcurrency := env.parseInt("OPML_IMPORT_CONCURRENCY", "5")
+	opmlImportOwnerID := getEnv("OPML_IMPORT_OWNER_ID", "")
+	webhookTimeoutSeconds := env.parseInt("WEBHOOK_TIMEOUT_SECONDS", "10")
+	webhookMaxAttempts := env.parseInt("WEBHOOK_MAX_ATTEMPTS", "8")
+	webhookRetryBaseSeconds := env.parseInt("WEBHOOK_RETRY_BASE_SECONDS", "30")
+
+	// Recommendation config
+	maxExcludedIDs := env.parseInt("RECOMMENDATION_MAX_EXCLUDED_IDS", "500")
+	preferenceWeight := env.parseFloat("RECOMMENDATION_PREFERENCE_WEIGHT", "10")
+	dislikePenalty := env.parseFloat("RECOMMENDATION_DISLIKE_PENALTY", "5")
+	ratingWeight := env.parseFloat("RECOMMENDATION_RATING_WEIGHT", "0.3")
+	ratingPriorReviews := env.parseFloat("RECOMMENDATION_RATING_PRIOR_REVIEWS", "10")
+	listenedProgressRatio := env.parseFloat("RECOMMENDATION_LISTENED_PROGRESS_RATIO", "0.5")
+	diversityMaxPerPodcaster := env.parseInt("RECOMMENDATION_DIVERSITY_MAX_PER_PODCASTER", "2")
+	diversityMaxPerCategory := env.parseInt("RECOMMENDATION_DIVERSITY_MAX_PER_CATEGORY", "4")
+	recommendationCacheTTLMinutes := env.parseInt("RECOMMENDATION_CACHE_TTL_MINUTES", "5")
+	personalizedCacheTTLMinutes := env.parseInt("RECOMMENDATION_PERSONALIZED_CACHE_TTL_MINUTES", "15")
+	trendingTTLMinutes := env.parseInt("RECOMMENDATION_TRENDING_TTL_MINUTES", "120")
+
+	// Analytics config
+	sessionGapMinutes := env.parseInt("ANALYTICS_SESSION_GAP_MINUTES", "10")
+	contentServiceAddr := getEnv("CONTENT_SERVICE_GRPC_ADDR", "")
 
 	// Media URL for public access
 	mediaURL := getEnv("MEDIA_URL", "http://localhost:8080/media")
+	if defaultCoverImageURL == "" {
+		defaultCoverImageURL = mediaURL + "/default-cover.png"
+	}
 
 	return &Config{
 		Server: ServerConfig{
@@ -94,6 +307,17 @@ func LoadConfig() (*Config, error) {
 			Mode:         serverMode,
 			ReadTimeout:  time.Duration(readTimeout) * time.Second,
 			WriteTimeout: time.Duration(writeTimeout) * time.Second,
+
+			MaxConcurrentRequests:      maxConcurrentRequests,
+			ConcurrencyLimitExemptions: concurrencyLimitExemptions,
+			TrustedProxies:             trustedProxies,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   corsAllowedOrigins,
+			AllowedMethods:   corsAllowedMethods,
+			AllowedHeaders:   corsAllowedHeaders,
+			AllowCredentials: corsAllowCredentials,
+			MaxAge:           time.Duration(corsMaxAgeSeconds) * time.Second,
 		},
 		DB: DBConfig{
 			Host:     dbHost,
@@ -105,6 +329,12 @@ func LoadConfig() (*Config, error) {
 			MaxConns: dbMaxConns,
 			MaxIdle:  dbMaxIdle,
 			Timeout:  time.Duration(dbTimeout) * time.Second,
+
+			ConnectAttempts:   dbConnectAttempts,
+			ConnectRetryDelay: time.Duration(dbConnectRetryDelay) * time.Second,
+			ConnectMaxWait:    time.Duration(dbConnectTimeout) * time.Second,
+
+			SlowQueryThreshold: time.Duration(dbSlowQueryThresholdMs) * time.Millisecond,
 		},
 		JWT: JWTConfig{
 			AccessSecret:        jwtAccessSecret,
@@ -112,11 +342,88 @@ func LoadConfig() (*Config, error) {
 			AccessExpiryMinutes: jwtAccessExpiryMinutes,
 			RefreshExpiryDays:   jwtRefreshExpiryDays,
 		},
+		Auth: AuthConfig{
+			AdminEmails:      adminEmails,
+			VerifyEmailURL:   verifyEmailURL,
+			ResetPasswordURL: resetPasswordURL,
+
+			SMTPAddr:     smtpAddr,
+			SMTPUsername: smtpUsername,
+			SMTPPassword: smtpPassword,
+			MailFrom:     mailFrom,
+		},
 		Storage: StorageConfig{
-			BasePath: storagePath,
-			MaxSize:  maxFileSize,
+			Backend:      storageBackend,
+			BasePath:     storagePath,
+			MaxSize:      maxFileSize,
+			MaxImageSize: maxImageSize,
+
+			S3Endpoint:        s3Endpoint,
+			S3Region:          s3Region,
+			S3Bucket:          s3Bucket,
+			S3AccessKeyID:     s3AccessKeyID,
+			S3SecretAccessKey: s3SecretAccessKey,
+			S3UseSSL:          s3UseSSL,
+			S3PublicURL:       s3PublicURL,
+			S3PresignExpiry:   time.Duration(s3PresignExpiryMinutes) * time.Minute,
+		},
+		Content: ContentConfig{
+			GRPCPort:               contentGRPCPort,
+			DefaultCoverImageURL:   defaultCoverImageURL,
+			CategoryCoverImageURLs: categoryCoverImageURLs,
+			SyncMaxFailures:        syncMaxFailures,
+			SyncRetryBaseDelay:     time.Duration(syncRetryBaseMinutes) * time.Minute,
+			SyncRetryMaxDelay:      time.Duration(syncRetryMaxMinutes) * time.Minute,
+			SyncConcurrency:        syncConcurrency,
+			SyncInterval:           syncInterval,
+			SyncInitialDelay:       syncInitialDelay,
+			SiteURL:                siteURL,
+			EmbedPlayerURL:         embedPlayerURL,
+			EmbedWidth:             embedWidth,
+			EmbedHeight:            embedHeight,
+			ShortLinkBaseURL:       shortLinkBaseURL,
+			ListenCountBadges:      listenCountBadges,
+			ListenCountRefresh:     listenCountRefresh,
+			CommentBlockedWords:    commentBlockedWords,
+			CommentMaxLinks:        commentMaxLinks,
+			CommentFilterAction:    commentFilterAction,
+			OPMLImportMaxFeeds:     opmlImportMaxFeeds,
+			OPMLImportConcurrency:  opmlImportConcurrency,
+			OPMLImportOwnerID:      opmlImportOwnerID,
+			WebhookTimeout:         time.Duration(webhookTimeoutSeconds) * time.Second,
+			WebhookMaxAttempts:     webhookMaxAttempts,
+			WebhookRetryBaseDelay:  time.Duration(webhookRetryBaseSeconds) * time.Second,
+		},
+		Redis: RedisConfig{
+			Addr:     redisAddr,
+			Password: redisPassword,
+			DB:       redisDB,
+		},
+		Recommendation: RecommendationConfig{
+			MaxExcludedIDs:   maxExcludedIDs,
+			PreferenceWeight: preferenceWeight,
+			DislikePenalty:   dislikePenalty,
+
+			RatingWeight:       ratingWeight,
+			RatingPriorReviews: ratingPriorReviews,
+
+			ListenedProgressRatio: listenedProgressRatio,
+
+			DiversityMaxPerPodcaster: diversityMaxPerPodcaster,
+			DiversityMaxPerCategory:  diversityMaxPerCategory,
+
+			CacheTTL:             time.Duration(recommendationCacheTTLMinutes) * time.Minute,
+			PersonalizedCacheTTL: time.Duration(personalizedCacheTTLMinutes) * time.Minute,
+
+			TrendingTTL: time.Duration(trendingTTLMinutes) * time.Minute,
+		},
+		Analytics: AnalyticsConfig{
+			SessionGap:         time.Duration(sessionGapMinutes) * time.Minute,
+			ContentServiceAddr: contentServiceAddr,
 		},
 		MediaURL: mediaURL,
+
+		parseErrors: env.errs,
 	}, nil
 }
 
@@ -127,4 +434,87 @@ func getEnv(key, defaultValue string) string {
 		return defaultValue
 	}
 	return value
-}
\ No newline at end of file
+}
+
+// envParser reads typed environment variables, collecting those whose value fails to parse
+type envParser struct {
+	errs []error
+}
+
+// parseInt gets an integer environment variable or its default
+func (p *envParser) parseInt(key, defaultValue string) int {
+	value := getEnv(key, defaultValue)
+	result, err := strconv.Atoi(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+	}
+	return result
+}
+
+// parseInt64 gets a 64-bit integer environment variable or its default
+func (p *envParser) parseInt64(key, defaultValue string) int64 {
+	value := getEnv(key, defaultValue)
+	result, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+	}
+	return result
+}
+
+// parseFloat gets a decimal environment variable or its default
+func (p *envParser) parseFloat(key, defaultValue string) float64 {
+	value := getEnv(key, defaultValue)
+	result, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a number", key, value))
+	}
+	return result
+}
+
+// parseBool gets a boolean environment variable or its default
+func (p *envParser) parseBool(key, defaultValue string) bool {
+	value := getEnv(key, defaultValue)
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a boolean", key, value))
+	}
+	return result
+}
+
+// parseDuration gets a positive duration environment variable, such as "90s" or "6h", or its default
+func (p *envParser) parseDuration(key, defaultValue string) time.Duration {
+	value := getEnv(key, defaultValue)
+	result, err := time.ParseDuration(value)
+	if err != nil || result <= 0 {
+		p.errs = append(p.errs, fmt.Errorf("%s: %q is not a positive duration", key, value))
+	}
+	return result
+}
+
+// getEnvList parses a comma separated environment variable, skipping empty entries
+func getEnvList(key string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// getEnvMap parses an environment variable of the form "key=value,key=value" into a map with lower-cased keys
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		k, v, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		k = strings.ToLower(strings.TrimSpace(k))
+		v = strings.TrimSpace(v)
+		if k != "" && v != "" {
+			result[k] = v
+		}
+	}
+	return result
+}
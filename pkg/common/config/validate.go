@@ -0,0 +1,149 @@
+// pkg/common/config/validate.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+)
+
+// minSecretLength is the shortest JWT secret accepted in release mode
+const minSecretLength = 32
+
+// defaultSecrets are the JWT secrets LoadConfig falls back to, which must never sign tokens in production
+var defaultSecrets = map[string]bool{
+	"access_secret":  true,
+	"refresh_secret": true,
+}
+
+// Validate checks the configuration is complete and usable, so services fail at startup
+// instead of misbehaving later. All problems found are reported together.
+func (c *Config) Validate() error {
+	errs := append([]error(nil), c.parseErrors...)
+
+	addError := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	// Server
+	switch c.Server.Mode {
+	case "debug", "release", "test":
+	default:
+		addError("SERVER_MODE: must be debug, release or test, got %q", c.Server.Mode)
+	}
+	if !isPort(c.Server.Port) {
+		addError("SERVER_PORT: %q is not a valid port", c.Server.Port)
+	}
+	if c.Server.ReadTimeout <= 0 || c.Server.WriteTimeout <= 0 {
+		addError("SERVER_READ_TIMEOUT and SERVER_WRITE_TIMEOUT must be positive")
+	}
+	for _, proxy := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			addError("SERVER_TRUSTED_PROXIES: %q is not an IP address or CIDR", proxy)
+		}
+	}
+
+	// Database
+	if c.DB.Host == "" || c.DB.User == "" || c.DB.DBName == "" {
+		addError("DB_HOST, DB_USER and DB_NAME are required")
+	}
+	if !isPort(c.DB.Port) {
+		addError("DB_PORT: %q is not a valid port", c.DB.Port)
+	}
+	if c.DB.Timeout <= 0 {
+		addError("DB_TIMEOUT must be positive")
+	}
+	if c.DB.MaxConns <= 0 {
+		addError("DB_MAX_CONNS must be positive")
+	}
+
+	// JWT
+	if c.JWT.AccessSecret == "" || c.JWT.RefreshSecret == "" {
+		addError("JWT_ACCESS_SECRET and JWT_REFRESH_SECRET are required")
+	}
+	if c.Server.Mode == "release" {
+		for _, secret := range []struct{ key, value string }{
+			{"JWT_ACCESS_SECRET", c.JWT.AccessSecret},
+			{"JWT_REFRESH_SECRET", c.JWT.RefreshSecret},
+		} {
+			if defaultSecrets[secret.value] {
+				addError("%s: the default secret can't be used in release mode", secret.key)
+			} else if len(secret.value) < minSecretLength {
+				addError("%s: must be at least %d characters in release mode", secret.key, minSecretLength)
+			}
+		}
+		if c.JWT.AccessSecret == c.JWT.RefreshSecret {
+			addError("JWT_ACCESS_SECRET and JWT_REFRESH_SECRET must differ in release mode")
+		}
+	}
+	if c.JWT.AccessExpiryMinutes <= 0 || c.JWT.RefreshExpiryDays <= 0 {
+		addError("JWT_ACCESS_EXPIRY_MINUTES and JWT_REFRESH_EXPIRY_DAYS must be positive")
+	}
+
+	// Mail
+	if c.Auth.SMTPAddr != "" {
+		if _, port, err := net.SplitHostPort(c.Auth.SMTPAddr); err != nil || !isPort(port) {
+			addError("SMTP_ADDR: %q is not a host:port address", c.Auth.SMTPAddr)
+		}
+		if c.Auth.MailFrom == "" {
+			addError("MAIL_FROM is required when SMTP_ADDR is set")
+		}
+	}
+
+	// Storage
+	switch c.Storage.Backend {
+	case "local":
+		if err := checkWritable(c.Storage.BasePath); err != nil {
+			addError("STORAGE_PATH: %v", err)
+		}
+	case "s3":
+		if c.Storage.S3Bucket == "" {
+			addError("S3_BUCKET is required when STORAGE_BACKEND is s3")
+		}
+	default:
+		addError("STORAGE_BACKEND: must be local or s3, got %q", c.Storage.Backend)
+	}
+	if c.Storage.MaxSize <= 0 || c.Storage.MaxImageSize <= 0 {
+		addError("MAX_FILE_SIZE and MAX_IMAGE_SIZE must be positive")
+	}
+
+	// Content
+	if c.Content.ListenCountRefresh < 0 {
+		addError("LISTEN_COUNT_REFRESH_INTERVAL must not be negative")
+	}
+
+	// Recommendation
+	if c.Recommendation.RatingWeight < 0 || c.Recommendation.RatingWeight > 1 {
+		addError("RECOMMENDATION_RATING_WEIGHT: must be between 0 and 1, got %g", c.Recommendation.RatingWeight)
+	}
+	if c.Recommendation.RatingPriorReviews < 0 {
+		addError("RECOMMENDATION_RATING_PRIOR_REVIEWS must not be negative")
+	}
+
+	return errors.Join(errs...)
+}
+
+// isPort reports whether port is a TCP port number
+func isPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// checkWritable makes sure files can be created in the directory, creating it if needed
+func checkWritable(dir string) error {
+	if dir == "" {
+		return errors.New("is required")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("can't create %s: %w", dir, err)
+	}
+
+	file, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("%s is not writable: %w", dir, err)
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
@@ -4,6 +4,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -11,29 +12,76 @@ import (
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 )
 
-// NewPostgresDB creates a new PostgreSQL connection
+// maxConnectRetryDelay caps the backoff between startup connection attempts
+const maxConnectRetryDelay = 30 * time.Second
+
+// NewPostgresDB creates a new PostgreSQL connection, retrying with backoff
+// while the database is not yet reachable
 func NewPostgresDB(cfg *config.DBConfig) (*sqlx.DB, error) {
+	// Pin the session time zone to UTC so timestamps are read back in UTC
+	// whatever the zone of the server or the database
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s timezone=UTC",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
 	)
 
-	db, err := sqlx.Connect("postgres", dsn)
+	db, err := connectWithRetry(dsn, cfg.ConnectAttempts, cfg.ConnectRetryDelay, cfg.ConnectMaxWait)
 	if err != nil {
 		return nil, err
 	}
 
+	SetSlowQueryThreshold(cfg.SlowQueryThreshold)
+
 	// Set connection pool settings
 	db.SetMaxOpenConns(cfg.MaxConns)
 	db.SetMaxIdleConns(cfg.MaxIdle)
 	db.SetConnMaxLifetime(cfg.Timeout)
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		return nil, err
+	return db, nil
+}
+
+// connectWithRetry opens and pings the database, retrying failed attempts with an
+// exponentially growing delay. It gives up after the given number of attempts or once
+// maxWait has passed, whichever comes first; a zero maxWait only limits the attempts.
+func connectWithRetry(dsn string, attempts int, delay, maxWait time.Duration) (*sqlx.DB, error) {
+	if attempts < 1 {
+		attempts = 1
 	}
 
-	return db, nil
+	ctx := context.Background()
+	if maxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxWait)
+		defer cancel()
+	}
+	deadline, hasDeadline := ctx.Deadline()
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var db *sqlx.DB
+		// sqlx.ConnectContext pings the database after opening it
+		db, err = sqlx.ConnectContext(ctx, "postgres", dsn)
+		if err == nil {
+			return db, nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+		if hasDeadline && time.Now().Add(delay).After(deadline) {
+			return nil, fmt.Errorf("failed to connect to database within %s (%d attempts): %w", maxWait, attempt, err)
+		}
+
+		log.Printf("Database connection attempt %d/%d failed: %v; retrying in %s", attempt, attempts, err, delay)
+		time.Sleep(delay)
+
+		delay *= 2
+		if delay > maxConnectRetryDelay {
+			delay = maxConnectRetryDelay
+		}
+	}
+
+	return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", attempts, err)
 }
 
 // MigrateDatabase runs database migrations
@@ -70,12 +118,3 @@ func WithTransaction(db *sqlx.DB, fn func(*sqlx.Tx) error) error {
 
 	return tx.Commit()
 }
-
-// PostgresHealthCheck checks if the database is healthy
-func PostgresHealthCheck(db *sqlx.DB) error {
-	// Set a short timeout to detect when the database is unreachable
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-	defer cancel()
-
-	return db.PingContext(ctx)
-}
\ No newline at end of file
@@ -0,0 +1,50 @@
+// pkg/common/database/slow_query.go
+package database
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+)
+
+// slowQueryThreshold holds the slow query threshold in nanoseconds
+var slowQueryThreshold atomic.Int64
+
+// SetSlowQueryThreshold sets the duration above which tracked queries are logged as slow.
+// A zero or negative threshold disables the warnings.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// TrackSlowQuery starts timing a query and returns a function to call once it has finished.
+// It only logs a warning when the query exceeded the threshold; the query itself is never affected.
+//
+//	defer database.TrackSlowQuery("analytics.GetPodcastListens")()
+func TrackSlowQuery(name string) func() {
+	start := time.Now()
+
+	return func() {
+		threshold := time.Duration(slowQueryThreshold.Load())
+		if threshold <= 0 {
+			return
+		}
+
+		elapsed := time.Since(start)
+		if elapsed <= threshold {
+			return
+		}
+
+		// Not every service initializes the structured logger
+		if logger.Logger == nil {
+			log.Printf("Slow query %s took %s (threshold %s)", name, elapsed, threshold)
+			return
+		}
+
+		logger.Warn("Slow query",
+			logger.Field("query", name),
+			logger.Field("duration_ms", elapsed.Milliseconds()),
+			logger.Field("threshold_ms", threshold.Milliseconds()))
+	}
+}
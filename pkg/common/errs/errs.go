@@ -0,0 +1,66 @@
+// pkg/common/errs/errs.go
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kinds of domain errors. Domain errors keep their own message and unwrap to one of these,
+// so callers can check them with errors.Is.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrNotAuthorized = errors.New("not authorized")
+	ErrConflict      = errors.New("conflict")
+	ErrValidation    = errors.New("validation failed")
+)
+
+// kindError is a domain error of a given kind
+type kindError struct {
+	kind    error
+	message string
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// NotFound creates an error for a missing resource
+func NotFound(message string) error {
+	return &kindError{kind: ErrNotFound, message: message}
+}
+
+// NotAuthorized creates an error for an action the user may not take
+func NotAuthorized(message string) error {
+	return &kindError{kind: ErrNotAuthorized, message: message}
+}
+
+// Conflict creates an error for an action that clashes with the current state
+func Conflict(message string) error {
+	return &kindError{kind: ErrConflict, message: message}
+}
+
+// Validation creates an error for invalid input
+func Validation(message string) error {
+	return &kindError{kind: ErrValidation, message: message}
+}
+
+// HTTPStatus returns the HTTP status code for an error's kind, or 500 for other errors
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrNotAuthorized):
+		return http.StatusForbidden
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,148 @@
+// pkg/common/health/health.go
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkTimeout bounds each dependency check, so a hanging dependency can't hang the probe
+const checkTimeout = 2 * time.Second
+
+// Dependency statuses
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Overall service statuses
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded" // an optional dependency is down
+	StatusError    = "error"    // a required dependency is down
+)
+
+// CheckFunc checks that a dependency is reachable and working
+type CheckFunc func(ctx context.Context) error
+
+// DependencyStatus is the result of checking one dependency
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	Required  bool   `json:"required"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the readiness of a service and its dependencies
+type Report struct {
+	Status       string                      `json:"status"`
+	Service      string                      `json:"service"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type dependency struct {
+	name     string
+	required bool
+	check    CheckFunc
+}
+
+// Checker checks the dependencies of a service. A service is not ready while a required
+// dependency is down; optional dependencies only degrade it.
+type Checker struct {
+	service      string
+	dependencies []dependency
+}
+
+// NewChecker creates a checker for the named service
+func NewChecker(service string) *Checker {
+	return &Checker{service: service}
+}
+
+// AddRequired adds a dependency the service can't serve requests without
+func (h *Checker) AddRequired(name string, check CheckFunc) {
+	h.dependencies = append(h.dependencies, dependency{name: name, required: true, check: check})
+}
+
+// AddOptional adds a dependency the service can work without, in a degraded way
+func (h *Checker) AddOptional(name string, check CheckFunc) {
+	h.dependencies = append(h.dependencies, dependency{name: name, check: check})
+}
+
+// Check runs all dependency checks in parallel
+func (h *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		Status:       StatusOK,
+		Service:      h.service,
+		Dependencies: make(map[string]DependencyStatus, len(h.dependencies)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, dep := range h.dependencies {
+		wg.Add(1)
+		go func(dep dependency) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := dep.check(checkCtx)
+			status := DependencyStatus{
+				Status:    StatusUp,
+				Required:  dep.required,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[dep.name] = status
+			if err != nil {
+				if dep.required {
+					report.Status = StatusError
+				} else if report.Status == StatusOK {
+					report.Status = StatusDegraded
+				}
+			}
+		}(dep)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// Live answers liveness probes. The process is up if it can answer, so no dependency is checked.
+func (h *Checker) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  StatusOK,
+		"service": h.service,
+	})
+}
+
+// Ready answers readiness probes with the status of each dependency, failing with 503
+// while a required dependency is down
+func (h *Checker) Ready(c *gin.Context) {
+	report := h.Check(c.Request.Context())
+
+	code := http.StatusOK
+	if report.Status == StatusError {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, report)
+}
+
+// RegisterRoutes registers the liveness and readiness endpoints. /health stays an alias of
+// readiness for existing monitors.
+func (h *Checker) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/health", h.Ready)
+	router.GET("/health/live", h.Live)
+	router.GET("/health/ready", h.Ready)
+}
@@ -2,6 +2,7 @@
 package logger
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -77,6 +78,27 @@ func With(fields ...zapcore.Field) *zap.Logger {
 	return Logger.With(fields...)
 }
 
+// contextKey is the context key of request-scoped loggers
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the given logger, so that all layers handling a
+// request log with the same fields, such as the request ID
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx. Outside of requests, e.g. in scheduled syncs,
+// it returns the global logger, or a no-op logger before Initialize was called.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	if Logger != nil {
+		return Logger
+	}
+	return zap.NewNop()
+}
+
 // Field creates a field for the logger
 func Field(key string, value interface{}) zapcore.Field {
 	return zap.Any(key, value)
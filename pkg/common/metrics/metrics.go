@@ -0,0 +1,30 @@
+// pkg/common/metrics/metrics.go
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of all metrics of the platform
+const Namespace = "podcast"
+
+// NewRegistry creates a registry holding the Go runtime and process collectors. Each service
+// creates its own and passes it to whatever registers metrics, instead of using the global
+// default registry, so collectors can be created more than once (e.g. in tests) without panicking.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// Handler serves the metrics of the registry in the Prometheus exposition format
+func Handler(gatherer prometheus.Gatherer) gin.HandlerFunc {
+	handler := promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{})
+	return gin.WrapH(handler)
+}
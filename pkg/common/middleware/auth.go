@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/utils"
 )
@@ -44,6 +45,37 @@ func AuthMiddleware(authUsecase usecase.Usecase) gin.HandlerFunc {
 		c.Set("user_id", payload.UserID.String())
 		c.Set("email", payload.Email)
 		c.Set("user_type", payload.UserType)
+		if payload.SessionID != uuid.Nil {
+			c.Set("session_id", payload.SessionID.String())
+		}
+
+		c.Next()
+	}
+}
+
+// OptionalAuthMiddleware sets the user data in the context when the request carries a valid
+// token, and otherwise lets the request through anonymously. Use it on public routes whose
+// responses depend on who is asking.
+func OptionalAuthMiddleware(authUsecase usecase.Usecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.Next()
+			return
+		}
+
+		payload, err := authUsecase.VerifyToken(c.Request.Context(), parts[1])
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		c.Set("user_id", payload.UserID.String())
+		c.Set("email", payload.Email)
+		c.Set("user_type", payload.UserType)
+		if payload.SessionID != uuid.Nil {
+			c.Set("session_id", payload.SessionID.String())
+		}
 
 		c.Next()
 	}
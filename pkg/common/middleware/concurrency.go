@@ -0,0 +1,98 @@
+// pkg/common/middleware/concurrency.go
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/MHK-26/pod_platfrom_go/pkg/auth/usecase"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/utils"
+)
+
+// concurrencyLimiter counts the in-flight requests of each client
+type concurrencyLimiter struct {
+	mu       sync.Mutex
+	inFlight map[string]int
+	max      int
+}
+
+// acquire reserves a request slot for the client, reporting false when it has none left
+func (l *concurrencyLimiter) acquire(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[key] >= l.max {
+		return false
+	}
+	l.inFlight[key]++
+	return true
+}
+
+// release frees a request slot of the client
+func (l *concurrencyLimiter) release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.inFlight[key] <= 1 {
+		delete(l.inFlight, key)
+		return
+	}
+	l.inFlight[key]--
+}
+
+// ConcurrencyLimitMiddleware caps the number of simultaneous requests per user, or per IP
+// for anonymous requests, responding with 429 once the cap is reached. Requests to the exempt
+// path prefixes are not limited; nothing the client sends, such as its Accept header, exempts
+// other requests. A max of zero or less disables the limit.
+// Client IPs only come from X-Forwarded-For when the router trusts the proxy that set it.
+//
+// It runs before the route's auth middleware, so it verifies the token itself to key
+// requests by user; an invalid token is treated as anonymous.
+func ConcurrencyLimitMiddleware(authUsecase usecase.Usecase, max int, exemptPaths []string) gin.HandlerFunc {
+	if max <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	limiter := &concurrencyLimiter{
+		inFlight: make(map[string]int),
+		max:      max,
+	}
+
+	return func(c *gin.Context) {
+		if isConcurrencyLimitExempt(c, exemptPaths) {
+			c.Next()
+			return
+		}
+
+		key := "ip:" + c.ClientIP()
+		if parts := strings.Split(c.GetHeader("Authorization"), " "); len(parts) == 2 && parts[0] == "Bearer" {
+			if payload, err := authUsecase.VerifyToken(c.Request.Context(), parts[1]); err == nil {
+				key = "user:" + payload.UserID.String()
+			}
+		}
+
+		if !limiter.acquire(key) {
+			utils.RespondWithError(c, http.StatusTooManyRequests, "Too many concurrent requests")
+			c.Abort()
+			return
+		}
+		defer limiter.release(key)
+
+		c.Next()
+	}
+}
+
+// isConcurrencyLimitExempt reports whether a request is to a long-lived route that must not
+// hold a slot
+func isConcurrencyLimitExempt(c *gin.Context, exemptPaths []string) bool {
+	for _, prefix := range exemptPaths {
+		if strings.HasPrefix(c.Request.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
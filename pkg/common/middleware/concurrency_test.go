@@ -0,0 +1,90 @@
+// pkg/common/middleware/concurrency_test.go
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newLimitedRouter returns a router allowing max concurrent requests, whose /slow and /stream
+// routes hold their slot until release is closed while /fast answers right away
+func newLimitedRouter(max int, exemptPaths []string) (*gin.Engine, chan struct{}, chan struct{}) {
+	gin.SetMode(gin.TestMode)
+
+	entered := make(chan struct{}, max+1)
+	release := make(chan struct{})
+	slow := func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	}
+
+	// Anonymous requests only, so the auth usecase is never asked to verify a token
+	router := gin.New()
+	router.Use(ConcurrencyLimitMiddleware(nil, max, exemptPaths))
+	router.GET("/slow", slow)
+	router.GET("/fast", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/stream/events", slow)
+	return router, entered, release
+}
+
+func TestConcurrencyLimitIgnoresEventStreamAccept(t *testing.T) {
+	const max = 2
+	router, entered, release := newLimitedRouter(max, nil)
+
+	// Fill the client's slots with requests that claim to be event streams
+	var wg sync.WaitGroup
+	for i := 0; i < max; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+			req.Header.Set("Accept", "text/event-stream")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+		}()
+		<-entered
+	}
+	defer func() {
+		close(release)
+		wg.Wait()
+	}()
+
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/fast", nil)
+		req.Header.Set("Accept", "text/event-stream")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusTooManyRequests {
+			t.Fatalf("request %d over the limit: status = %d, want %d", i+1, w.Code, http.StatusTooManyRequests)
+		}
+	}
+}
+
+func TestConcurrencyLimitExemptPaths(t *testing.T) {
+	router, entered, release := newLimitedRouter(1, []string{"/stream/"})
+
+	var wg sync.WaitGroup
+	for _, path := range []string{"/slow", "/stream/events", "/stream/events"} {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}(path)
+		<-entered
+	}
+	defer func() {
+		close(release)
+		wg.Wait()
+	}()
+
+	// The streams don't hold slots, but the client's only slot is taken
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+}
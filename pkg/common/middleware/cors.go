@@ -1,22 +1,80 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/gin-gonic/gin"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
 )
 
-// CORS adds CORS headers to every request
-func CORS() gin.HandlerFunc {
+// exposedHeaders are the response headers browsers let cross-origin callers read
+const exposedHeaders = "Location, X-Request-ID"
+
+// CORS adds CORS headers to requests from the configured origins. Preflight requests from
+// other origins are rejected with 403; their other requests get no CORS headers, so browsers
+// keep the response from the calling page.
+func CORS(cfg *config.CORSConfig) gin.HandlerFunc {
+	allowAll := false
+	allowedOrigins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAll = true
+		}
+		allowedOrigins[normalizeOrigin(origin)] = true
+	}
+
+	allowedMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			// Not a cross-origin request
+			c.Next()
+			return
+		}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+		// The response depends on the origin, so caches must keep one copy per origin
+		c.Writer.Header().Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+		if !allowAll && !allowedOrigins[normalizeOrigin(origin)] {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		// Browsers refuse a wildcard origin on credentialed requests, so echo the origin back then
+		if allowAll && !cfg.AllowCredentials {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		c.Writer.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+
+		if preflight {
+			c.Writer.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			c.Writer.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			c.Writer.Header().Set("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
 		c.Next()
 	}
-}
\ No newline at end of file
+}
+
+// normalizeOrigin lower-cases an origin and drops a trailing slash, as origins are compared
+// case-insensitively and configured ones are sometimes written with one
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimRight(origin, "/"))
+}
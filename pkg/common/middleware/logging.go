@@ -11,6 +11,27 @@ import (
 	"go.uber.org/zap"
 )
 
+// RequestIDMiddleware gives each request an ID, returned in the X-Request-ID header, and puts a
+// logger with that ID in the request context. LoggingMiddleware does this as well; services that
+// must not log request bodies use this one instead.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setRequestID(c)
+		c.Next()
+	}
+}
+
+// setRequestID generates the request ID and stores the request's logger in its context
+func setRequestID(c *gin.Context) *zap.Logger {
+	requestID := uuid.New().String()
+	c.Set("request_id", requestID)
+	c.Writer.Header().Set("X-Request-ID", requestID)
+
+	requestLogger := logger.FromContext(c.Request.Context()).With(logger.Field("request_id", requestID))
+	c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), requestLogger))
+	return requestLogger
+}
+
 // LoggingMiddleware is a middleware that logs each request
 func LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -18,9 +39,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 		start := time.Now()
 
 		// Generate request ID
-		requestID := uuid.New().String()
-		c.Set("request_id", requestID)
-		c.Writer.Header().Set("X-Request-ID", requestID)
+		requestLogger := setRequestID(c)
 
 		// Create request buffer
 		var requestBody []byte
@@ -41,7 +60,6 @@ func LoggingMiddleware() gin.HandlerFunc {
 
 		// Log request
 		fields := []zap.Field{
-			logger.Field("request_id", requestID),
 			logger.Field("method", c.Request.Method),
 			logger.Field("path", c.Request.URL.Path),
 			logger.Field("query", c.Request.URL.RawQuery),
@@ -65,11 +83,11 @@ func LoggingMiddleware() gin.HandlerFunc {
 
 		// Log based on status code
 		if c.Writer.Status() >= 500 {
-			logger.Error("Server error", fields...)
+			requestLogger.Error("Server error", fields...)
 		} else if c.Writer.Status() >= 400 {
-			logger.Warn("Client error", fields...)
+			requestLogger.Warn("Client error", fields...)
 		} else {
-			logger.Info("Request processed", fields...)
+			requestLogger.Info("Request processed", fields...)
 		}
 	}
 }
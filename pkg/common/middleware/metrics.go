@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
+)
+
+// unmatchedRoute labels requests that matched no route, so probing random paths can't
+// create a series per path
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware records the count, duration and status code of each request, labelled
+// by the route pattern rather than the raw path to keep the number of series bounded
+func MetricsMiddleware(httpMetrics *metrics.HTTPMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		httpMetrics.InFlight.Inc()
+		defer httpMetrics.InFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		httpMetrics.RequestsTotal.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpMetrics.RequestDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,42 @@
+// pkg/common/pagination/cursor.go
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Cursor marks where a page of a cursor paginated listing ended.
+//
+// Cursor pagination orders a listing by a timestamp with the item ID as tie breaker, e.g.
+// (created_at, id), and fetches the items after the cursor instead of skipping an offset.
+// Every page costs the same however deep it is, and rows inserted while a client pages
+// through the listing never shift items into the next page or out of it. In exchange clients
+// can only move forward from a page they have seen and get no total count.
+type Cursor struct {
+	Time time.Time `json:"t"`
+	ID   string    `json:"id"`
+}
+
+// Encode encodes the cursor as an opaque URL-safe token
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes a token created by Cursor.Encode
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	return &cursor, nil
+}
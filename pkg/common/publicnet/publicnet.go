@@ -0,0 +1,74 @@
+// pkg/common/publicnet/publicnet.go
+package publicnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrNonPublicAddress is returned for hosts that resolve to an address the platform may not
+// make requests to on behalf of users
+var ErrNonPublicAddress = errors.New("host resolves to a non-public address")
+
+// IsPublicIP reports whether requests may be made to an address. Loopback, private, link-local
+// (such as the 169.254.169.254 metadata service), multicast and unspecified addresses are
+// refused, so user-supplied URLs can't be used to reach the platform's own network.
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// CheckHost makes sure every address a host resolves to is public, to reject a URL when it is
+// submitted. Requests made through NewTransport check the address they connect to again, as
+// the host may resolve differently by then.
+func CheckHost(ctx context.Context, host string) error {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrNonPublicAddress
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return ErrNonPublicAddress
+		}
+	}
+
+	return nil
+}
+
+// NewTransport returns an HTTP transport that only connects to public addresses. It connects
+// directly, never through a proxy, so that the address checked is the one requested.
+func NewTransport(dialTimeout time.Duration) *http.Transport {
+	dialer := &net.Dialer{Timeout: dialTimeout, Control: refuseNonPublic}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+// refuseNonPublic is a dialer control function refusing connections to non-public addresses.
+// It sees the address actually connected to, after name resolution.
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrNonPublicAddress, host)
+	}
+
+	return nil
+}
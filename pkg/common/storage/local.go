@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
 )
 
 // Service defines the interface for storage operations
@@ -19,11 +20,17 @@ type Service interface {
 	// SaveFile saves a file and returns the path to the file
 	SaveFile(file *multipart.FileHeader, directory string) (string, error)
 	
+	// SaveImage saves an image, held to the smaller image size limit, and returns the path to the file
+	SaveImage(file *multipart.FileHeader, directory string) (string, error)
+	
 	// GetFileURL returns the URL to the file
 	GetFileURL(filePath string) string
 	
 	// DeleteFile deletes a file
 	DeleteFile(filePath string) error
+	
+	// HealthCheck checks files can currently be stored
+	HealthCheck(ctx context.Context) error
 }
 
 type localService struct {
@@ -40,24 +47,71 @@ func NewLocalService(cfg *config.Config) Service {
 	}
 }
 
-// SaveFile saves a file to the local filesystem
-func (s *localService) SaveFile(file *multipart.FileHeader, directory string) (string, error) {
+// NewService creates the storage service of the configured backend
+func NewService(cfg *config.Config) (Service, error) {
+	switch cfg.Storage.Backend {
+	case "", "local":
+		return NewLocalService(cfg), nil
+	case "s3":
+		return NewS3Service(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// Errors returned for uploads and deletions
+var (
+	ErrFileTooLarge       = errs.Validation("file too large")
+	ErrFileTypeNotAllowed = errs.Validation("file type not allowed")
+	ErrFileNotFound       = errs.NotFound("file not found")
+)
+
+// Extensions of the files that can be uploaded
+var (
+	imageExts = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true}
+	audioExts = map[string]bool{".mp3": true, ".m4a": true, ".wav": true, ".ogg": true}
+)
+
+// validateUpload checks an uploaded file's size and type, returning its extension
+func validateUpload(file *multipart.FileHeader, maxSize int64, allowedExts ...map[string]bool) (string, error) {
 	// Check file size
-	if file.Size > s.cfg.Storage.MaxSize {
-		return "", fmt.Errorf("file size exceeds maximum allowed size of %d bytes", s.cfg.Storage.MaxSize)
+	if file.Size > maxSize {
+		return "", ErrFileTooLarge
 	}
 	
 	// Get file extension
-	ext := filepath.Ext(file.Filename)
-	allowedExts := map[string]bool{
-		".jpg": true, ".jpeg": true, ".png": true, ".gif": true, // Images
-		".mp3": true, ".m4a": true, ".wav": true, ".ogg": true, // Audio
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	for _, exts := range allowedExts {
+		if exts[ext] {
+			return ext, nil
+		}
+	}
+	
+	return "", ErrFileTypeNotAllowed
+}
+
+// SaveFile saves a file to the local filesystem
+func (s *localService) SaveFile(file *multipart.FileHeader, directory string) (string, error) {
+	ext, err := validateUpload(file, s.cfg.Storage.MaxSize, imageExts, audioExts)
+	if err != nil {
+		return "", err
 	}
 	
-	if !allowedExts[strings.ToLower(ext)] {
-		return "", errors.New("file type not allowed")
+	return s.save(file, directory, ext)
+}
+
+// SaveImage saves an image to the local filesystem
+func (s *localService) SaveImage(file *multipart.FileHeader, directory string) (string, error) {
+	ext, err := validateUpload(file, s.cfg.Storage.MaxImageSize, imageExts)
+	if err != nil {
+		return "", err
 	}
 	
+	return s.save(file, directory, ext)
+}
+
+// save copies a validated upload into the directory under a unique name
+func (s *localService) save(file *multipart.FileHeader, directory, ext string) (string, error) {
 	// Create directory if it doesn't exist
 	dirPath := filepath.Join(s.cfg.Storage.BasePath, directory)
 	if err := os.MkdirAll(dirPath, os.ModePerm); err != nil {
@@ -105,7 +159,7 @@ func (s *localService) DeleteFile(filePath string) error {
 	
 	// Check if file exists
 	if _, err := os.Stat(absPath); os.IsNotExist(err) {
-		return errors.New("file not found")
+		return ErrFileNotFound
 	}
 	
 	// Delete the file
@@ -115,4 +169,14 @@ func (s *localService) DeleteFile(filePath string) error {
 // SetupMediaRoute sets up a route for serving media files
 func SetupMediaRoute(r *gin.Engine, storagePath string) {
 	r.Static("/media", storagePath)
-}
\ No newline at end of file
+}
+
+// HealthCheck checks the base directory exists and is writable
+func (s *localService) HealthCheck(ctx context.Context) error {
+	file, err := os.CreateTemp(s.cfg.Storage.BasePath, ".health-*")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
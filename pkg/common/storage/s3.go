@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"path"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+)
+
+type s3Service struct {
+	cfg    *config.Config
+	client *minio.Client
+}
+
+// NewS3Service creates a storage service keeping files in an S3 or S3-compatible bucket
+func NewS3Service(cfg *config.Config) (Service, error) {
+	if cfg.Storage.S3Bucket == "" {
+		return nil, errors.New("S3 bucket is not configured")
+	}
+
+	// Use the static keys when given, otherwise the AWS environment or instance role
+	creds := credentials.NewStaticV4(cfg.Storage.S3AccessKeyID, cfg.Storage.S3SecretAccessKey, "")
+	if cfg.Storage.S3AccessKeyID == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.IAM{},
+		})
+	}
+
+	client, err := minio.New(cfg.Storage.S3Endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: cfg.Storage.S3UseSSL,
+		Region: cfg.Storage.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	return &s3Service{
+		cfg:    cfg,
+		client: client,
+	}, nil
+}
+
+// SaveFile streams a file to the bucket and returns its object key
+func (s *s3Service) SaveFile(file *multipart.FileHeader, directory string) (string, error) {
+	ext, err := validateUpload(file, s.cfg.Storage.MaxSize, imageExts, audioExts)
+	if err != nil {
+		return "", err
+	}
+
+	return s.put(file, directory, ext)
+}
+
+// SaveImage streams an image to the bucket and returns its object key
+func (s *s3Service) SaveImage(file *multipart.FileHeader, directory string) (string, error) {
+	ext, err := validateUpload(file, s.cfg.Storage.MaxImageSize, imageExts)
+	if err != nil {
+		return "", err
+	}
+
+	return s.put(file, directory, ext)
+}
+
+// put uploads a validated file into the directory under a unique key
+func (s *s3Service) put(file *multipart.FileHeader, directory, ext string) (string, error) {
+	// Open the source file
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	// Generate a unique object key
+	key := path.Join(directory, uuid.New().String()+ext)
+
+	// Serve the object with the type of its validated extension rather than the type the client claimed,
+	// which could make the bucket serve an image upload as HTML
+	contentType := mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_, err = s.client.PutObject(context.Background(), s.cfg.Storage.S3Bucket, key, src, file.Size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// GetFileURL returns the public URL of the file, or a presigned URL when the bucket isn't public.
+// An empty string is returned if the URL can't be signed.
+func (s *s3Service) GetFileURL(filePath string) string {
+	if s.cfg.Storage.S3PublicURL != "" {
+		return fmt.Sprintf("%s/%s", s.cfg.Storage.S3PublicURL, filePath)
+	}
+
+	u, err := s.client.PresignedGetObject(context.Background(), s.cfg.Storage.S3Bucket, filePath, s.cfg.Storage.S3PresignExpiry, nil)
+	if err != nil {
+		return ""
+	}
+	return u.String()
+}
+
+// DeleteFile deletes a file from the bucket
+func (s *s3Service) DeleteFile(filePath string) error {
+	ctx := context.Background()
+
+	// Check if file exists, as removing a missing object succeeds
+	if _, err := s.client.StatObject(ctx, s.cfg.Storage.S3Bucket, filePath, minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ErrFileNotFound
+		}
+		return err
+	}
+
+	return s.client.RemoveObject(ctx, s.cfg.Storage.S3Bucket, filePath, minio.RemoveObjectOptions{})
+}
+
+// HealthCheck checks the bucket is reachable
+func (s *s3Service) HealthCheck(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.cfg.Storage.S3Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.cfg.Storage.S3Bucket)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+// pkg/common/utils/cursor.go
+package utils
+
+import (
+	"net/http"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// GetCursorParam reports whether the client opted into cursor pagination by sending the
+// cursor query param, and decodes it. An empty cursor asks for the first page, so the
+// returned cursor is nil.
+func GetCursorParam(c *gin.Context) (*pagination.Cursor, bool, error) {
+	token, ok := c.GetQuery("cursor")
+	if !ok || token == "" {
+		return nil, ok, nil
+	}
+
+	cursor, err := pagination.DecodeCursor(token)
+	return cursor, true, err
+}
+
+// RespondWithCursorPagination sends a cursor paginated response. The next cursor is null on the last page.
+func RespondWithCursorPagination(c *gin.Context, data interface{}, next *pagination.Cursor, pageSize int) {
+	var nextCursor interface{}
+	if next != nil {
+		nextCursor = next.Encode()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"page_size":   pageSize,
+		"next_cursor": nextCursor,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
 )
 
 // ErrorResponse represents an error response
@@ -21,6 +22,11 @@ func RespondWithError(c *gin.Context, code int, message string) {
 	})
 }
 
+// RespondWithDomainError sends an error response with the status code of the error's kind
+func RespondWithDomainError(c *gin.Context, err error, message string) {
+	RespondWithError(c, errs.HTTPStatus(err), message)
+}
+
 // RespondWithValidationError sends a validation error response
 func RespondWithValidationError(c *gin.Context, errors map[string]string) {
 	c.JSON(http.StatusBadRequest, gin.H{
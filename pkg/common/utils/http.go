@@ -38,21 +38,58 @@ func CalculateOffset(page, pageSize int) int {
 	return (page - 1) * pageSize
 }
 
-// RespondWithPagination sends a paginated response
-func RespondWithPagination(c *gin.Context, data interface{}, totalCount, page, pageSize int) {
+// PaginatedResponse is the body of page-numbered list responses. NextPage and PrevPage are
+// null on the last and first page, so clients can follow them without counting pages.
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	TotalCount int         `json:"total_count"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"page_size"`
+	TotalPages int         `json:"total_pages"`
+	HasNext    bool        `json:"has_next"`
+	HasPrev    bool        `json:"has_prev"`
+	NextPage   *int        `json:"next_page"`
+	PrevPage   *int        `json:"prev_page"`
+}
+
+// NewPaginatedResponse builds the paginated response of one page of totalCount items. A page
+// size of zero or less has no pages, rather than dividing by zero.
+func NewPaginatedResponse(data interface{}, totalCount, page, pageSize int) PaginatedResponse {
 	// Calculate total pages
-	totalPages := totalCount / pageSize
-	if totalCount%pageSize != 0 {
-		totalPages++
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = totalCount / pageSize
+		if totalCount%pageSize != 0 {
+			totalPages++
+		}
+	}
+
+	response := PaginatedResponse{
+		Data:       data,
+		TotalCount: totalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}
+
+	if page < totalPages {
+		next := page + 1
+		response.HasNext = true
+		response.NextPage = &next
+	}
+	// A page past the end points back at the last page
+	if page > 1 && totalPages > 0 {
+		prev := min(page-1, totalPages)
+		response.HasPrev = true
+		response.PrevPage = &prev
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"data":        data,
-		"total_count": totalCount,
-		"page":        page,
-		"page_size":   pageSize,
-		"total_pages": totalPages,
-	})
+	return response
+}
+
+// RespondWithPagination sends a paginated response
+func RespondWithPagination(c *gin.Context, data interface{}, totalCount, page, pageSize int) {
+	c.JSON(http.StatusOK, NewPaginatedResponse(data, totalCount, page, pageSize))
 }
 
 // RespondWithSuccess sends a success response
@@ -60,8 +97,11 @@ func RespondWithSuccess(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusOK, data)
 }
 
-// RespondWithCreated sends a created response
-func RespondWithCreated(c *gin.Context, data interface{}) {
+// RespondWithCreated sends a created response with a Location header pointing at the new resource
+func RespondWithCreated(c *gin.Context, location string, data interface{}) {
+	if location != "" {
+		c.Header("Location", location)
+	}
 	c.JSON(http.StatusCreated, data)
 }
 
@@ -0,0 +1,51 @@
+// pkg/common/utils/http_test.go
+package utils
+
+import "testing"
+
+func TestNewPaginatedResponse(t *testing.T) {
+	tests := []struct {
+		name           string
+		totalCount     int
+		page           int
+		pageSize       int
+		wantTotalPages int
+		wantNextPage   int // 0 for none
+		wantPrevPage   int // 0 for none
+	}{
+		{name: "first page", totalCount: 45, page: 1, pageSize: 20, wantTotalPages: 3, wantNextPage: 2},
+		{name: "middle page", totalCount: 45, page: 2, pageSize: 20, wantTotalPages: 3, wantNextPage: 3, wantPrevPage: 1},
+		{name: "last page", totalCount: 45, page: 3, pageSize: 20, wantTotalPages: 3, wantPrevPage: 2},
+		{name: "exact pages", totalCount: 40, page: 2, pageSize: 20, wantTotalPages: 2, wantPrevPage: 1},
+		{name: "only page", totalCount: 5, page: 1, pageSize: 20, wantTotalPages: 1},
+		{name: "page past the end", totalCount: 45, page: 7, pageSize: 20, wantTotalPages: 3, wantPrevPage: 3},
+		{name: "no items", totalCount: 0, page: 1, pageSize: 20},
+		{name: "no items, page past the end", totalCount: 0, page: 2, pageSize: 20},
+		{name: "zero page size", totalCount: 45, page: 1, pageSize: 0},
+		{name: "negative page size", totalCount: 45, page: 2, pageSize: -5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPaginatedResponse(nil, tt.totalCount, tt.page, tt.pageSize)
+
+			if got.TotalPages != tt.wantTotalPages {
+				t.Errorf("TotalPages = %d, want %d", got.TotalPages, tt.wantTotalPages)
+			}
+			if got.HasNext != (tt.wantNextPage != 0) || pageNumber(got.NextPage) != tt.wantNextPage {
+				t.Errorf("HasNext = %v, NextPage = %d, want next page %d", got.HasNext, pageNumber(got.NextPage), tt.wantNextPage)
+			}
+			if got.HasPrev != (tt.wantPrevPage != 0) || pageNumber(got.PrevPage) != tt.wantPrevPage {
+				t.Errorf("HasPrev = %v, PrevPage = %d, want previous page %d", got.HasPrev, pageNumber(got.PrevPage), tt.wantPrevPage)
+			}
+		})
+	}
+}
+
+// pageNumber dereferences an optional page number, with 0 for none
+func pageNumber(page *int) int {
+	if page == nil {
+		return 0
+	}
+	return *page
+}
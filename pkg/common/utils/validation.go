@@ -0,0 +1,109 @@
+// pkg/common/utils/validation.go
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// validate checks request structs against their validate tags. Fields are reported by their
+// JSON names, which are the names clients know.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// BindJSON binds a JSON request body and validates it. When either fails it responds with
+// 400 and returns false, so handlers just return.
+func BindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+		return false
+	}
+	return Validate(c, obj)
+}
+
+// Validate validates a request struct, responding with the field errors and returning false
+// when it isn't valid. It is for requests completed after binding, e.g. from path parameters.
+func Validate(c *gin.Context, obj interface{}) bool {
+	err := validate.Struct(obj)
+	if err == nil {
+		return true
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+		return false
+	}
+
+	fieldErrors := make(map[string]string, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors[fieldError.Field()] = validationMessage(obj, fieldError)
+	}
+	RespondWithValidationError(c, fieldErrors)
+	return false
+}
+
+// validationMessage describes a failed validate tag
+func validationMessage(obj interface{}, fieldError validator.FieldError) string {
+	switch fieldError.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fieldError.Param(), " ", ", ")
+	case "eqfield":
+		return "must match " + jsonFieldName(obj, fieldError.Param())
+	case "min", "max":
+		bound := "at least"
+		if fieldError.Tag() == "max" {
+			bound = "at most"
+		}
+		switch fieldError.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters long", bound, fieldError.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must have %s %s items", bound, fieldError.Param())
+		default:
+			return fmt.Sprintf("must be %s %s", bound, fieldError.Param())
+		}
+	default:
+		return "is invalid"
+	}
+}
+
+// jsonFieldName returns the JSON name of a field of a request struct, as the validator
+// reports fields named in tags like eqfield by their Go names
+func jsonFieldName(obj interface{}, name string) string {
+	t := reflect.TypeOf(obj)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		if field, ok := t.FieldByName(name); ok {
+			if jsonName := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]; jsonName != "" && jsonName != "-" {
+				return jsonName
+			}
+		}
+	}
+	return name
+}
@@ -0,0 +1,165 @@
+// pkg/content/delivery/grpc/handlers.go
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/usecase"
+	pb "github.com/MHK-26/pod_platfrom_go/api/proto/content"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// serviceViewer is the viewer of gRPC calls. They come from other services of the
+// platform, which see exact listen counts like admins do.
+var serviceViewer = models.Viewer{IsAdmin: true}
+
+// Handler is the gRPC handler for the content service
+type Handler struct {
+	pb.UnimplementedContentServiceServer
+	usecase usecase.Usecase
+}
+
+// NewHandler creates a new content gRPC handler
+func NewHandler(usecase usecase.Usecase) *Handler {
+	return &Handler{
+		usecase: usecase,
+	}
+}
+
+// GetPodcast gets a podcast by ID
+func (h *Handler) GetPodcast(ctx context.Context, req *pb.GetPodcastRequest) (*pb.Podcast, error) {
+	podcastID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid podcast ID: %v", err)
+	}
+
+	podcast, err := h.usecase.GetPodcastByID(ctx, podcastID, serviceViewer)
+	if err != nil {
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Podcast not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to get podcast: %v", err)
+	}
+
+	return convertPodcastToProto(podcast), nil
+}
+
+// GetEpisode gets an episode by ID
+func (h *Handler) GetEpisode(ctx context.Context, req *pb.GetEpisodeRequest) (*pb.Episode, error) {
+	episodeID, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid episode ID: %v", err)
+	}
+
+	episode, err := h.usecase.GetEpisodeByID(ctx, episodeID, serviceViewer)
+	if err != nil {
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Episode not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to get episode: %v", err)
+	}
+
+	return convertEpisodeToProto(episode), nil
+}
+
+// BatchGetEpisodes gets several episodes by ID in one call
+func (h *Handler) BatchGetEpisodes(ctx context.Context, req *pb.BatchGetEpisodesRequest) (*pb.BatchGetEpisodesResponse, error) {
+	episodeIDs := make([]uuid.UUID, 0, len(req.Ids))
+	for _, idStr := range req.Ids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid episode ID %q: %v", idStr, err)
+		}
+		episodeIDs = append(episodeIDs, id)
+	}
+
+	episodes, err := h.usecase.GetEpisodesByIDs(ctx, episodeIDs, serviceViewer)
+	if err != nil {
+		if errors.Is(err, models.ErrTooManyEpisodeIDs) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid request: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to get episodes: %v", err)
+	}
+
+	response := &pb.BatchGetEpisodesResponse{
+		Episodes: make([]*pb.Episode, 0, len(episodes)),
+	}
+	for _, episode := range episodes {
+		response.Episodes = append(response.Episodes, convertEpisodeToProto(episode))
+	}
+
+	return response, nil
+}
+
+// Helper function to convert a podcast response to proto
+func convertPodcastToProto(podcast *models.PodcastResponse) *pb.Podcast {
+	pbPodcast := &pb.Podcast{
+		Id:            podcast.ID.String(),
+		PodcasterId:   podcast.PodcasterID.String(),
+		Title:         podcast.Title,
+		Description:   podcast.Description,
+		CoverImageUrl: podcast.CoverImageURL,
+		RssUrl:        podcast.RSSUrl,
+		WebsiteUrl:    podcast.WebsiteURL,
+		Language:      podcast.Language,
+		Author:        podcast.Author,
+		Category:      podcast.Category,
+		Subcategory:   podcast.Subcategory,
+		Explicit:      podcast.Explicit,
+		Status:        podcast.Status,
+		CreatedAt:     timestamppb.New(podcast.CreatedAt),
+		UpdatedAt:     timestamppb.New(podcast.UpdatedAt),
+		EpisodeCount:  int32(podcast.EpisodeCount),
+	}
+
+	if podcast.LastSyncedAt != nil {
+		pbPodcast.LastSyncedAt = timestamppb.New(*podcast.LastSyncedAt)
+	}
+
+	for _, category := range podcast.Categories {
+		pbPodcast.Categories = append(pbPodcast.Categories, &pb.Category{
+			Id:          category.ID.String(),
+			Name:        category.Name,
+			Description: category.Description,
+			IconUrl:     category.IconURL,
+		})
+	}
+
+	return pbPodcast
+}
+
+// Helper function to convert an episode response to proto
+func convertEpisodeToProto(episode *models.EpisodeResponse) *pb.Episode {
+	pbEpisode := &pb.Episode{
+		Id:              episode.ID.String(),
+		PodcastId:       episode.PodcastID.String(),
+		Title:           episode.Title,
+		Description:     episode.Description,
+		AudioUrl:        episode.AudioURL,
+		Duration:        int32(episode.Duration),
+		CoverImageUrl:   episode.CoverImageURL,
+		PublicationDate: timestamppb.New(episode.PublicationDate),
+		Guid:            episode.GUID,
+		Transcript:      episode.Transcript,
+		Status:          episode.Status,
+		CreatedAt:       timestamppb.New(episode.CreatedAt),
+		UpdatedAt:       timestamppb.New(episode.UpdatedAt),
+		PodcastTitle:    episode.PodcastTitle,
+		PodcastAuthor:   episode.PodcastAuthor,
+		ListenCount:     int32(episode.ListenCount),
+	}
+
+	if episode.EpisodeNumber != nil {
+		pbEpisode.EpisodeNumber = int32(*episode.EpisodeNumber)
+	}
+	if episode.SeasonNumber != nil {
+		pbEpisode.SeasonNumber = int32(*episode.SeasonNumber)
+	}
+
+	return pbEpisode
+}
@@ -3,17 +3,25 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/rss"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/usecase"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/middleware"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/utils"
 )
 
+// maxOPMLFileSize is the largest OPML file accepted for a subscription import
+const maxOPMLFileSize = 1 << 20
+
 // Handler is the HTTP handler for the content service
 type Handler struct {
 	usecase usecase.Usecase
@@ -33,6 +41,7 @@ func NewHandler(usecase usecase.Usecase) *Handler {
 // @Accept json
 // @Produce json
 // @Param id path string true "Podcast ID"
+// @Param hide_explicit query bool false "Leave out explicit latest episodes (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.PodcastResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
@@ -50,7 +59,7 @@ func (h *Handler) GetPodcast(c *gin.Context) {
 		return
 	}
 
-	podcast, err := h.usecase.GetPodcastByID(c.Request.Context(), id)
+	podcast, err := h.usecase.GetPodcastByID(c.Request.Context(), id, viewerFromContext(c))
 	if err != nil {
 		utils.RespondWithError(c, http.StatusNotFound, "Podcast not found")
 		return
@@ -61,7 +70,7 @@ func (h *Handler) GetPodcast(c *gin.Context) {
 
 // ListPodcasts godoc
 // @Summary List podcasts
-// @Description Get a paginated list of podcasts with optional filtering
+// @Description Get a paginated list of podcasts with optional filtering. Queries match podcast titles, authors and descriptions, including partially typed words.
 // @Tags podcasts
 // @Accept json
 // @Produce json
@@ -69,8 +78,10 @@ func (h *Handler) GetPodcast(c *gin.Context) {
 // @Param page_size query int false "Page size (default: 20)"
 // @Param query query string false "Search query"
 // @Param category query string false "Category ID"
-// @Param sort_by query string false "Sort field (created_at, title, listens)"
+// @Param language query string false "Language code, matching its regional varieties too (default: the signed in user's preferred language; all: every language)"
+// @Param sort_by query string false "Sort field (relevance, created_at, title, listens; default: relevance when searching, else created_at). Listen counts are recounted every LISTEN_COUNT_REFRESH_INTERVAL (15 minutes by default), so the most recent listens may not be counted yet."
 // @Param sort_order query string false "Sort order (asc, desc)"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -86,8 +97,23 @@ func (h *Handler) ListPodcasts(c *gin.Context) {
 		PageSize:   utils.GetIntQueryParam(c, "page_size", 20),
 	}
 
-	podcasts, totalCount, err := h.usecase.ListPodcasts(c.Request.Context(), params)
+	if params.Category != "" {
+		if _, err := uuid.Parse(params.Category); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid category ID")
+			return
+		}
+	}
+
+	podcasts, totalCount, err := h.usecase.ListPodcasts(c.Request.Context(), params, viewerFromContext(c))
 	if err != nil {
+		if errors.Is(err, models.ErrInvalidSortField) {
+			utils.RespondWithDomainError(c, err, "Invalid sort field")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidLanguage) {
+			utils.RespondWithDomainError(c, err, "Invalid language")
+			return
+		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch podcasts")
 		return
 	}
@@ -123,7 +149,7 @@ func (h *Handler) GetPodcastsByUser(c *gin.Context) {
 	page := utils.GetIntQueryParam(c, "page", 1)
 	pageSize := utils.GetIntQueryParam(c, "page_size", 20)
 
-	podcasts, totalCount, err := h.usecase.GetPodcastsByPodcasterID(c.Request.Context(), userID, page, pageSize)
+	podcasts, totalCount, err := h.usecase.GetPodcastsByPodcasterID(c.Request.Context(), userID, viewerFromContext(c), page, pageSize)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch podcasts")
 		return
@@ -143,14 +169,21 @@ func (h *Handler) GetPodcastsByUser(c *gin.Context) {
 // @Success 201 {object} models.Podcast
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
+// @Failure 409 {object} models.DuplicateFeedResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /podcasts [post]
 func (h *Handler) CreatePodcast(c *gin.Context) {
 	var req models.CreatePodcastRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	rssURL, err := rss.NormalizeFeedURL(req.RSSUrl)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid RSS URL")
 		return
 	}
+	req.RSSUrl = rssURL
 
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
@@ -175,14 +208,18 @@ func (h *Handler) CreatePodcast(c *gin.Context) {
 	// First parse the RSS feed to get podcast details
 	feed, err := h.usecase.ParseRSSFeed(c.Request.Context(), req.RSSUrl)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Failed to parse RSS feed: "+err.Error())
+		respondWithFeedError(c, err)
 		return
 	}
 
 	// Prepare podcast data from RSS feed
 	podcast, err := h.usecase.CreatePodcast(c.Request.Context(), userIDParsed, &req, feed)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create podcast: "+err.Error())
+		if errors.Is(err, rss.ErrInvalidFeedURL) {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid RSS URL")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create podcast")
 		return
 	}
 
@@ -193,7 +230,70 @@ func (h *Handler) CreatePodcast(c *gin.Context) {
 		h.usecase.SyncPodcastFromRSS(ctx, podcast.ID)
 	}()
 
-	utils.RespondWithCreated(c, podcast)
+	utils.RespondWithCreated(c, "/api/v1/podcasts/"+podcast.ID.String(), podcast)
+}
+
+// respondWithFeedError responds to a feed that couldn't be added. A feed already in the catalog
+// is a conflict, pointing at its podcast so that clients can offer to subscribe instead.
+func respondWithFeedError(c *gin.Context, err error) {
+	var duplicate *usecase.DuplicateFeedError
+	if errors.As(err, &duplicate) {
+		c.JSON(http.StatusConflict, models.DuplicateFeedResponse{
+			Status:     http.StatusConflict,
+			Message:    "A podcast with this RSS feed already exists",
+			PodcastID:  duplicate.PodcastID,
+			PodcastURL: "/api/v1/podcasts/" + duplicate.PodcastID.String(),
+		})
+		return
+	}
+
+	utils.RespondWithError(c, http.StatusBadRequest, feedErrorMessage(err))
+}
+
+// feedErrorMessage describes why a feed couldn't be fetched or parsed, see rss.DescribeFailure
+func feedErrorMessage(err error) string {
+	return "Failed to parse RSS feed: " + rss.DescribeFailure(err)
+}
+
+// PreviewFeed godoc
+// @Summary Preview a podcast feed
+// @Description Parse an RSS or Atom feed and return the podcast metadata, episode count and first episodes that creating a podcast from it would import, without saving anything (podcasters only). Warnings point out feed problems such as items without a GUID or audio enclosure, or with unparseable dates.
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.PreviewFeedRequest true "Preview Feed Request"
+// @Success 200 {object} models.FeedPreview
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Router /podcasts/preview [post]
+func (h *Handler) PreviewFeed(c *gin.Context) {
+	var req models.PreviewFeedRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	rssURL, err := rss.NormalizeFeedURL(req.RSSUrl)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid RSS URL")
+		return
+	}
+
+	// Check if user is a podcaster
+	userType, exists := c.Get("user_type")
+	if !exists || userType.(string) != "podcaster" {
+		utils.RespondWithError(c, http.StatusForbidden, "Only podcasters can preview podcast feeds")
+		return
+	}
+
+	preview, err := h.usecase.PreviewFeed(c.Request.Context(), rssURL)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, feedErrorMessage(err))
+		return
+	}
+
+	utils.RespondWithSuccess(c, preview)
 }
 
 // UpdatePodcast godoc
@@ -210,6 +310,7 @@ func (h *Handler) CreatePodcast(c *gin.Context) {
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} models.DuplicateFeedResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /podcasts/{id} [put]
 func (h *Handler) UpdatePodcast(c *gin.Context) {
@@ -225,11 +326,20 @@ func (h *Handler) UpdatePodcast(c *gin.Context) {
 	}
 
 	var req models.UpdatePodcastRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
+	// Normalize the URL before comparing it with the stored one, which was normalized too
+	if req.RSSUrl != "" {
+		rssURL, err := rss.NormalizeFeedURL(req.RSSUrl)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid RSS URL")
+			return
+		}
+		req.RSSUrl = rssURL
+	}
+
 	// Get user ID from context (set by auth middleware)
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -247,7 +357,7 @@ func (h *Handler) UpdatePodcast(c *gin.Context) {
 	var needsSync bool
 	if req.RSSUrl != "" {
 		// Get current podcast to check if URL changed
-		currentPodcast, err := h.usecase.GetPodcastByID(c.Request.Context(), id)
+		currentPodcast, err := h.usecase.GetPodcastByID(c.Request.Context(), id, viewerFromContext(c))
 		if err != nil {
 			utils.RespondWithError(c, http.StatusNotFound, "Podcast not found")
 			return
@@ -259,7 +369,7 @@ func (h *Handler) UpdatePodcast(c *gin.Context) {
 			// Validate and parse the new RSS feed
 			_, err := h.usecase.ParseRSSFeed(c.Request.Context(), req.RSSUrl)
 			if err != nil {
-				utils.RespondWithError(c, http.StatusBadRequest, "Failed to parse RSS feed: "+err.Error())
+				respondWithFeedError(c, err)
 				return
 			}
 		}
@@ -267,12 +377,16 @@ func (h *Handler) UpdatePodcast(c *gin.Context) {
 
 	podcast, err := h.usecase.UpdatePodcast(c.Request.Context(), id, userIDParsed, &req)
 	if err != nil {
-		if err.Error() == "podcast not found" {
-			utils.RespondWithError(c, http.StatusNotFound, "Podcast not found")
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to update this podcast")
 			return
 		}
-		if err.Error() == "not authorized" {
-			utils.RespondWithError(c, http.StatusForbidden, "Not authorized to update this podcast")
+		if errors.Is(err, models.ErrInvalidSyncInterval) {
+			utils.RespondWithDomainError(c, err, "Sync interval must be at least "+strconv.Itoa(models.MinSyncIntervalMinutes)+" minutes")
 			return
 		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update podcast")
@@ -334,8 +448,8 @@ func (h *Handler) SyncPodcast(c *gin.Context) {
 	// Check if user is authorized to sync this podcast
 	isAuthorized, err := h.usecase.IsUserAuthorizedForPodcast(c.Request.Context(), id, userIDParsed)
 	if err != nil {
-		if err.Error() == "podcast not found" {
-			utils.RespondWithError(c, http.StatusNotFound, "Podcast not found")
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
 			return
 		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to check authorization")
@@ -359,22 +473,22 @@ func (h *Handler) SyncPodcast(c *gin.Context) {
 	})
 }
 
-// DeletePodcast godoc
-// @Summary Delete a podcast
-// @Description Delete an existing podcast
+// ResumePodcastSync godoc
+// @Summary Resume a suspended podcast sync
+// @Description Re-enable RSS synchronization for a podcast that was suspended after repeated failures, and trigger a sync
 // @Tags podcasts
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Podcast ID"
-// @Success 204 "No Content"
+// @Success 202 {object} utils.Message
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /podcasts/{id} [delete]
-func (h *Handler) DeletePodcast(c *gin.Context) {
+// @Router /podcasts/{id}/sync/resume [post]
+func (h *Handler) ResumePodcastSync(c *gin.Context) {
 	idStr, ok := utils.ExtractIDParam(c, "id")
 	if !ok {
 		return
@@ -399,135 +513,268 @@ func (h *Handler) DeletePodcast(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.DeletePodcast(c.Request.Context(), id, userIDParsed)
+	userType, _ := c.Get("user_type")
+	isAdmin := userType == "admin"
+
+	err = h.usecase.ResumePodcastSync(c.Request.Context(), id, userIDParsed, isAdmin)
 	if err != nil {
-		if err.Error() == "podcast not found" {
-			utils.RespondWithError(c, http.StatusNotFound, "Podcast not found")
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
 			return
 		}
-		if err.Error() == "not authorized" {
-			utils.RespondWithError(c, http.StatusForbidden, "Not authorized to delete this podcast")
+		if errors.Is(err, models.ErrNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to resume sync for this podcast")
 			return
 		}
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete podcast")
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to resume podcast sync")
 		return
 	}
 
-	utils.RespondWithNoContent(c)
+	// Trigger the sync in the background
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		h.usecase.SyncPodcastFromRSS(ctx, id)
+	}()
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Podcast synchronization resumed",
+	})
 }
 
-// GetEpisode godoc
-// @Summary Get episode details
-// @Description Get detailed information about an episode
-// @Tags episodes
+// ListSyncSuspendedPodcasts godoc
+// @Summary List podcasts with suspended sync
+// @Description Get podcasts whose RSS synchronization was suspended after repeated failures (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
-// @Param id path string true "Episode ID"
-// @Success 200 {object} models.EpisodeResponse
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/podcasts/sync-suspended [get]
+func (h *Handler) ListSyncSuspendedPodcasts(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+
+	podcasts, totalCount, err := h.usecase.GetSyncSuspendedPodcasts(c.Request.Context(), pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch podcasts")
+		return
+	}
+
+	utils.RespondWithPagination(c, podcasts, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// StartSyncAll godoc
+// @Summary Start a full RSS sync
+// @Description Sync the podcasts due for a sync in the background, or every active podcast with force, e.g. to refresh feeds after a fix. Only one full sync runs at a time. (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param force query bool false "Sync every active podcast, not only those due for a sync (default: false)"
+// @Success 202 {object} models.SyncAllJob
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/sync-all [post]
+func (h *Handler) StartSyncAll(c *gin.Context) {
+	force := utils.GetBoolQueryParam(c, "force", false)
+
+	job, err := h.usecase.StartSyncAll(c.Request.Context(), force)
+	if err != nil {
+		if errors.Is(err, models.ErrFullSyncInProgress) {
+			utils.RespondWithDomainError(c, err, "A full sync is already in progress")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to start sync")
+		return
+	}
+
+	c.Header("Location", "/api/v1/admin/sync-all/"+job.ID.String())
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetSyncAllJob godoc
+// @Summary Get a full RSS sync job
+// @Description Get the progress of a full sync started with POST /admin/sync-all. Only recent jobs are kept, and only until the service restarts. (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path string true "Job ID"
+// @Success 200 {object} models.SyncAllJob
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /episodes/{id} [get]
-func (h *Handler) GetEpisode(c *gin.Context) {
-	idStr, ok := utils.ExtractIDParam(c, "id")
+// @Router /admin/sync-all/{job_id} [get]
+func (h *Handler) GetSyncAllJob(c *gin.Context) {
+	jobIDStr, ok := utils.ExtractIDParam(c, "job_id")
 	if !ok {
 		return
 	}
 
-	id, err := uuid.Parse(idStr)
+	jobID, err := uuid.Parse(jobIDStr)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid job ID")
 		return
 	}
 
-	episode, err := h.usecase.GetEpisodeByID(c.Request.Context(), id)
+	job, err := h.usecase.GetSyncAllJob(c.Request.Context(), jobID)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusNotFound, "Episode not found")
+		if errors.Is(err, models.ErrSyncJobNotFound) {
+			utils.RespondWithDomainError(c, err, "Sync job not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch sync job")
 		return
 	}
 
-	utils.RespondWithSuccess(c, episode)
+	utils.RespondWithSuccess(c, job)
 }
 
-// GetEpisodesByPodcast godoc
-// @Summary Get podcast episodes
-// @Description Get episodes for a specific podcast
-// @Tags episodes
+// ListModerationComments godoc
+// @Summary List comments for moderation
+// @Description Get comments of any status, by default those flagged by the comment filter and waiting for review, oldest first (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
-// @Param podcast_id path string true "Podcast ID"
+// @Security BearerAuth
+// @Param status query string false "Status (active, hidden, flagged; default: flagged, all for any)"
+// @Param episode_id query string false "Episode ID"
+// @Param user_id query string false "Author user ID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date (YYYY-MM-DD), exclusive"
+// @Param sort query string false "Sort field (created_at, updated_at)"
+// @Param order query string false "Sort order (asc, desc; default: asc)"
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Page size (default: 20)"
 // @Success 200 {object} utils.PaginatedResponse
 // @Failure 400 {object} utils.ErrorResponse
-// @Failure 404 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /podcasts/{podcast_id}/episodes [get]
-func (h *Handler) GetEpisodesByPodcast(c *gin.Context) {
-	podcastIDStr, ok := utils.ExtractIDParam(c, "podcast_id")
-	if !ok {
-		return
+// @Router /admin/comments [get]
+func (h *Handler) ListModerationComments(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	params := models.CommentModerationParams{
+		Status:    c.DefaultQuery("status", "flagged"),
+		EpisodeID: c.Query("episode_id"),
+		UserID:    c.Query("user_id"),
+		Sort:      c.Query("sort"),
+		Order:     c.Query("order"),
+		Page:      pagination.Page,
+		PageSize:  pagination.PageSize,
 	}
 
-	podcastID, err := uuid.Parse(podcastIDStr)
-	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+	switch params.Status {
+	case "all":
+		params.Status = ""
+	case "active", "hidden", "flagged":
+	default:
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid status")
 		return
 	}
 
-	page := utils.GetIntQueryParam(c, "page", 1)
-	pageSize := utils.GetIntQueryParam(c, "page_size", 20)
+	for _, id := range []string{params.EpisodeID, params.UserID} {
+		if id == "" {
+			continue
+		}
+		if _, err := uuid.Parse(id); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid ID filter")
+			return
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid from date format")
+			return
+		}
+		params.From = fromDate
+	}
+
+	if to := c.Query("to"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid to date format")
+			return
+		}
+		params.To = toDate
+	}
 
-	episodes, totalCount, err := h.usecase.GetEpisodesByPodcastID(c.Request.Context(), podcastID, page, pageSize)
+	comments, totalCount, err := h.usecase.ListCommentsForModeration(c.Request.Context(), params)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch episodes")
+		if errors.Is(err, models.ErrInvalidSortField) {
+			utils.RespondWithDomainError(c, err, "Invalid sort field")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch comments")
 		return
 	}
 
-	utils.RespondWithPagination(c, episodes, totalCount, page, pageSize)
+	utils.RespondWithPagination(c, comments, totalCount, params.Page, params.PageSize)
 }
 
-// ListCategories godoc
-// @Summary List categories
-// @Description Get a list of podcast categories
-// @Tags categories
+// GetCommentCounts godoc
+// @Summary Count comments by status
+// @Description Get the number of comments of each status, e.g. for a badge of comments awaiting review (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
-// @Success 200 {array} models.Category
+// @Security BearerAuth
+// @Success 200 {object} map[string]int
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /categories [get]
-func (h *Handler) ListCategories(c *gin.Context) {
-	categories, err := h.usecase.GetCategories(c.Request.Context())
+// @Router /admin/comments/counts [get]
+func (h *Handler) GetCommentCounts(c *gin.Context) {
+	counts, err := h.usecase.GetCommentCountsByStatus(c.Request.Context())
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch categories")
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to count comments")
 		return
 	}
 
-	utils.RespondWithSuccess(c, categories)
+	utils.RespondWithSuccess(c, counts)
 }
 
-// Subscribe godoc
-// @Summary Subscribe to podcast
-// @Description Subscribe to a podcast
-// @Tags subscriptions
+// ModerateComment godoc
+// @Summary Moderate a comment
+// @Description Set the status of any comment: hide it, flag it for review or make it visible again (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param podcast_id path string true "Podcast ID"
+// @Param id path string true "Comment ID"
+// @Param request body models.ModerateCommentRequest true "Moderate Comment Request"
 // @Success 204 "No Content"
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /podcasts/{podcast_id}/subscribe [post]
-func (h *Handler) Subscribe(c *gin.Context) {
-	podcastIDStr, ok := utils.ExtractIDParam(c, "podcast_id")
+// @Router /admin/comments/{id} [patch]
+func (h *Handler) ModerateComment(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
 	if !ok {
 		return
 	}
 
-	podcastID, err := uuid.Parse(podcastIDStr)
+	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	var req models.ModerateCommentRequest
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -544,37 +791,46 @@ func (h *Handler) Subscribe(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.SubscribeToPodcast(c.Request.Context(), userIDParsed, podcastID)
+	err = h.usecase.ModerateComment(c.Request.Context(), id, userIDParsed, req.Status)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to subscribe")
+		if errors.Is(err, models.ErrCommentNotFound) {
+			utils.RespondWithDomainError(c, err, "Comment not found")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidCommentStatus) {
+			utils.RespondWithDomainError(c, err, "Invalid comment status")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to moderate comment")
 		return
 	}
 
 	utils.RespondWithNoContent(c)
 }
 
-// Unsubscribe godoc
-// @Summary Unsubscribe from podcast
-// @Description Unsubscribe from a podcast
-// @Tags subscriptions
-// @Accept json
+// RemoveComment godoc
+// @Summary Remove a comment
+// @Description Delete any comment, whoever wrote it (admin only)
+// @Tags admin
 // @Produce json
 // @Security BearerAuth
-// @Param podcast_id path string true "Podcast ID"
+// @Param id path string true "Comment ID"
 // @Success 204 "No Content"
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /podcasts/{podcast_id}/unsubscribe [post]
-func (h *Handler) Unsubscribe(c *gin.Context) {
-	podcastIDStr, ok := utils.ExtractIDParam(c, "podcast_id")
+// @Router /admin/comments/{id} [delete]
+func (h *Handler) RemoveComment(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
 	if !ok {
 		return
 	}
 
-	podcastID, err := uuid.Parse(podcastIDStr)
+	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
 		return
 	}
 
@@ -591,32 +847,50 @@ func (h *Handler) Unsubscribe(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.UnsubscribeFromPodcast(c.Request.Context(), userIDParsed, podcastID)
+	err = h.usecase.RemoveComment(c.Request.Context(), id, userIDParsed)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to unsubscribe")
+		if errors.Is(err, models.ErrCommentNotFound) {
+			utils.RespondWithDomainError(c, err, "Comment not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to remove comment")
 		return
 	}
 
 	utils.RespondWithNoContent(c)
 }
 
-// SavePlaybackPosition godoc
-// @Summary Save playback position
-// @Description Save the current playback position for an episode
-// @Tags episodes
+// SuspendPodcast godoc
+// @Summary Suspend a podcast
+// @Description Hide a podcast and its episodes from everyone but its owner and admins (admin only)
+// @Tags admin
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body models.SavePlaybackPositionRequest true "Save Playback Position Request"
+// @Param id path string true "Podcast ID"
+// @Param request body models.SuspendPodcastRequest true "Suspend Podcast Request"
 // @Success 204 "No Content"
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /episodes/playback [post]
-func (h *Handler) SavePlaybackPosition(c *gin.Context) {
-	var req models.SavePlaybackPositionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+// @Router /admin/podcasts/{id}/suspend [post]
+func (h *Handler) SuspendPodcast(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	var req models.SuspendPodcastRequest
+	if !utils.BindJSON(c, &req) {
 		return
 	}
 
@@ -633,29 +907,2277 @@ func (h *Handler) SavePlaybackPosition(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.SavePlaybackPosition(c.Request.Context(), userIDParsed, req.EpisodeID, req.Position, req.Completed)
+	err = h.usecase.SuspendPodcast(c.Request.Context(), id, userIDParsed, req.Reason)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to save playback position")
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrPodcastAlreadySuspended) {
+			utils.RespondWithDomainError(c, err, "Podcast already suspended")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to suspend podcast")
 		return
 	}
 
 	utils.RespondWithNoContent(c)
 }
 
-// GetSyncStatus godoc
-// @Summary Get RSS feed sync status
-// @Description Get the status of RSS feed synchronization for a podcast
-// @Tags podcasts
-// @Accept json
+// UnsuspendPodcast godoc
+// @Summary Lift a podcast's suspension
+// @Description Make a suspended podcast and its episodes public again (admin only)
+// @Tags admin
 // @Produce json
-// @Param podcast_id path string true "Podcast ID"
-// @Success 200 {object} models.RSSFeedSyncLog
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 204 "No Content"
 // @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /podcasts/{podcast_id}/sync-status [get]
-func (h *Handler) GetSyncStatus(c *gin.Context) {
-	podcastIDStr, ok := utils.ExtractIDParam(c, "podcast_id")
+// @Router /admin/podcasts/{id}/unsuspend [post]
+func (h *Handler) UnsuspendPodcast(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.UnsuspendPodcast(c.Request.Context(), id, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrPodcastNotSuspended) {
+			utils.RespondWithDomainError(c, err, "Podcast is not suspended")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to lift podcast suspension")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// ReportComment godoc
+// @Summary Report a comment
+// @Description Report an abusive comment to the admins. Each user can report a comment once.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Comment ID"
+// @Param request body models.CreateReportRequest true "Create Report Request"
+// @Success 201 {object} models.ContentReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /comments/{id}/report [post]
+func (h *Handler) ReportComment(c *gin.Context) {
+	h.reportContent(c, "comment")
+}
+
+// ReportPodcast godoc
+// @Summary Report a podcast
+// @Description Report an abusive podcast to the admins. Each user can report a podcast once.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param request body models.CreateReportRequest true "Create Report Request"
+// @Success 201 {object} models.ContentReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/report [post]
+func (h *Handler) ReportPodcast(c *gin.Context) {
+	h.reportContent(c, "podcast")
+}
+
+// reportContent reports the comment or podcast with the ID in the path
+func (h *Handler) reportContent(c *gin.Context, targetType string) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	targetID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid "+targetType+" ID")
+		return
+	}
+
+	var req models.CreateReportRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	report, err := h.usecase.ReportContent(c.Request.Context(), userIDParsed, targetType, targetID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrCommentNotFound):
+			utils.RespondWithDomainError(c, err, "Comment not found")
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrAlreadyReported):
+			utils.RespondWithDomainError(c, err, "You have already reported this "+targetType)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to report "+targetType)
+		}
+		return
+	}
+
+	utils.RespondWithCreated(c, "", report)
+}
+
+// ListReports godoc
+// @Summary List content reports
+// @Description Get reports of comments and podcasts, by default the open ones waiting for review, oldest first (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Status (open, reviewed, dismissed; default: open, all for any)"
+// @Param target_type query string false "Target type (comment, podcast)"
+// @Param target_id query string false "Target ID"
+// @Param from query string false "Reported on or after (YYYY-MM-DD)"
+// @Param to query string false "Reported before (YYYY-MM-DD)"
+// @Param sort query string false "Sort field (created_at, reviewed_at, reason)"
+// @Param order query string false "Sort order (asc, desc; default: asc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/reports [get]
+func (h *Handler) ListReports(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	params := models.ReportListParams{
+		Status:     c.DefaultQuery("status", "open"),
+		TargetType: c.Query("target_type"),
+		TargetID:   c.Query("target_id"),
+		Sort:       c.Query("sort"),
+		Order:      c.Query("order"),
+		Page:       pagination.Page,
+		PageSize:   pagination.PageSize,
+	}
+
+	switch params.Status {
+	case "all":
+		params.Status = ""
+	case "open", "reviewed", "dismissed":
+	default:
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid status")
+		return
+	}
+
+	switch params.TargetType {
+	case "", "comment", "podcast":
+	default:
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid target type")
+		return
+	}
+
+	if params.TargetID != "" {
+		if _, err := uuid.Parse(params.TargetID); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid target ID")
+			return
+		}
+	}
+
+	if from := c.Query("from"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid from date format")
+			return
+		}
+		params.From = fromDate
+	}
+
+	if to := c.Query("to"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid to date format")
+			return
+		}
+		params.To = toDate
+	}
+
+	reports, totalCount, err := h.usecase.ListReports(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidSortField) {
+			utils.RespondWithDomainError(c, err, "Invalid sort field")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch reports")
+		return
+	}
+
+	utils.RespondWithPagination(c, reports, totalCount, params.Page, params.PageSize)
+}
+
+// GetReportCounts godoc
+// @Summary Count reports by status
+// @Description Get the number of reports of each status, e.g. for a badge of reports awaiting review (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]int
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/reports/counts [get]
+func (h *Handler) GetReportCounts(c *gin.Context) {
+	counts, err := h.usecase.GetReportCountsByStatus(c.Request.Context())
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to count reports")
+		return
+	}
+
+	utils.RespondWithSuccess(c, counts)
+}
+
+// ResolveReport godoc
+// @Summary Resolve a content report
+// @Description Close an open report as reviewed, after acting on it, or dismissed (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Report ID"
+// @Param request body models.ResolveReportRequest true "Resolve Report Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/reports/{id} [patch]
+func (h *Handler) ResolveReport(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid report ID")
+		return
+	}
+
+	var req models.ResolveReportRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.ResolveReport(c.Request.Context(), id, userIDParsed, req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrReportNotFound):
+			utils.RespondWithDomainError(c, err, "Report not found")
+		case errors.Is(err, models.ErrReportAlreadyResolved):
+			utils.RespondWithDomainError(c, err, "Report already resolved")
+		case errors.Is(err, models.ErrInvalidReportStatus):
+			utils.RespondWithDomainError(c, err, "Invalid report status")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to resolve report")
+		}
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// DeletePodcast godoc
+// @Summary Delete a podcast
+// @Description Delete an existing podcast
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id} [delete]
+func (h *Handler) DeletePodcast(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.DeletePodcast(c.Request.Context(), id, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to delete this podcast")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete podcast")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetEpisode godoc
+// @Summary Get episode details
+// @Description Get detailed information about an episode
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Param id path string true "Episode ID"
+// @Success 200 {object} models.EpisodeResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id} [get]
+func (h *Handler) GetEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	episode, err := h.usecase.GetEpisodeByID(c.Request.Context(), id, viewerFromContext(c))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusNotFound, "Episode not found")
+		return
+	}
+
+	utils.RespondWithSuccess(c, episode)
+}
+
+// StreamEpisode godoc
+// @Summary Stream an episode
+// @Description Redirect to the episode's audio. Returns 451 if the episode was taken down.
+// @Tags episodes
+// @Param id path string true "Episode ID"
+// @Success 302 "Redirect to the audio file"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 451 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/stream [get]
+func (h *Handler) StreamEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	audioURL, err := h.usecase.GetEpisodeStreamURL(c.Request.Context(), id, viewerFromContext(c))
+	if err != nil {
+		if errors.Is(err, models.ErrEpisodeTakenDown) {
+			utils.RespondWithError(c, http.StatusUnavailableForLegalReasons, "Episode is unavailable for legal reasons")
+			return
+		}
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get episode audio")
+		return
+	}
+
+	c.Redirect(http.StatusFound, audioURL)
+}
+
+// RefreshEpisode godoc
+// @Summary Refresh an episode from the feed
+// @Description Re-read a single episode's metadata from its podcast's RSS feed without a full sync (owner only)
+// @Tags episodes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Success 200 {object} models.EpisodeResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 502 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/refresh [post]
+func (h *Handler) RefreshEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	episode, err := h.usecase.RefreshEpisode(c.Request.Context(), id, userIDParsed)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+		case errors.Is(err, models.ErrEpisodeNotInFeed):
+			utils.RespondWithDomainError(c, err, "Episode is no longer in the podcast's feed")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to refresh this episode")
+		case errors.Is(err, models.ErrNoRSSURL):
+			utils.RespondWithDomainError(c, err, "Podcast has no RSS feed")
+		case errors.Is(err, models.ErrSyncInProgress):
+			utils.RespondWithDomainError(c, err, "A sync of this podcast is already in progress")
+		case errors.Is(err, models.ErrFeedFetch):
+			utils.RespondWithError(c, http.StatusBadGateway, feedErrorMessage(err))
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to refresh episode")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, episode)
+}
+
+// SetEpisodeChapters godoc
+// @Summary Set episode chapters
+// @Description Replace an episode's chapters with ones set by hand (owner only). Chapters need a title and must start within the episode; image and link URLs must be http(s). Chapters from the feed's podcast:chapters file no longer override them.
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Param request body models.SetChaptersRequest true "Set Chapters Request"
+// @Success 200 {object} models.EpisodeResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/chapters [put]
+func (h *Handler) SetEpisodeChapters(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	var req models.SetChaptersRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	episode, err := h.usecase.SetEpisodeChapters(c.Request.Context(), id, userIDParsed, req.Chapters)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to set the chapters of this episode")
+		case errors.Is(err, models.ErrTooManyChapters):
+			utils.RespondWithDomainError(c, err, "An episode can have at most 500 chapters")
+		case errors.Is(err, models.ErrInvalidChapters):
+			utils.RespondWithDomainError(c, err, "Chapters need a title of at most 200 characters, a start time within the episode, and http(s) image and link URLs")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to set chapters")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, episode)
+}
+
+// SearchTranscript godoc
+// @Summary Search an episode transcript
+// @Description Find the places in an episode's transcript matching a query, in transcript order. Each match has an excerpt with the matching words wrapped in <mark> tags, its offset in the transcript text, and its start time in seconds when the transcript is timed (VTT, SRT or JSON).
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Param id path string true "Episode ID"
+// @Param q query string true "Search query"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/transcript/search [get]
+func (h *Handler) SearchTranscript(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	matches, totalCount, err := h.usecase.SearchTranscript(c.Request.Context(), id, c.Query("q"), pagination.Page, pagination.PageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrEmptyQuery):
+			utils.RespondWithDomainError(c, err, "Search query is required")
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to search transcript")
+		}
+		return
+	}
+
+	utils.RespondWithPagination(c, matches, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// GetOEmbed godoc
+// @Summary Get oEmbed data for an episode
+// @Description oEmbed provider endpoint returning a rich embed of the episode player for an episode share URL on our site
+// @Tags episodes
+// @Produce json
+// @Param url query string true "Episode share URL"
+// @Param format query string false "Response format (only json is supported)"
+// @Param maxwidth query int false "Maximum embed width in pixels"
+// @Param maxheight query int false "Maximum embed height in pixels"
+// @Success 200 {object} models.OEmbedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 501 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /oembed [get]
+func (h *Handler) GetOEmbed(c *gin.Context) {
+	shareURL := c.Query("url")
+	if shareURL == "" {
+		utils.RespondWithError(c, http.StatusBadRequest, "URL is required")
+		return
+	}
+
+	if format := c.Query("format"); format != "" && format != "json" {
+		utils.RespondWithError(c, http.StatusNotImplemented, "Only the json format is supported")
+		return
+	}
+
+	maxWidth := utils.GetIntQueryParam(c, "maxwidth", 0)
+	maxHeight := utils.GetIntQueryParam(c, "maxheight", 0)
+
+	embed, err := h.usecase.GetEpisodeOEmbed(c.Request.Context(), shareURL, maxWidth, maxHeight)
+	if err != nil {
+		if errors.Is(err, models.ErrUnsupportedURL) || errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "No embeddable episode found for this URL")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get embed")
+		return
+	}
+
+	utils.RespondWithSuccess(c, embed)
+}
+
+// CreateShortLink godoc
+// @Summary Get or create a short link
+// @Description Get the short share link of an episode or podcast, creating it on first use
+// @Tags share
+// @Accept json
+// @Produce json
+// @Param request body models.CreateShortLinkRequest true "Create Short Link Request"
+// @Success 200 {object} models.ShortLinkResponse
+// @Success 201 {object} models.ShortLinkResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /shortlinks [post]
+func (h *Handler) CreateShortLink(c *gin.Context) {
+	var req models.CreateShortLinkRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	link, created, err := h.usecase.GetOrCreateShortLink(c.Request.Context(), &req)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidTargetType) {
+			utils.RespondWithDomainError(c, err, "Target type must be episode or podcast")
+			return
+		}
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		}
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create short link")
+		return
+	}
+
+	if created {
+		utils.RespondWithCreated(c, link.URL, link)
+		return
+	}
+	utils.RespondWithSuccess(c, link)
+}
+
+// FollowShortLink godoc
+// @Summary Follow a short link
+// @Description Redirect to the episode or podcast a short link points to, recording the click
+// @Tags share
+// @Param code path string true "Short link code"
+// @Success 302 "Redirect to the episode or podcast page"
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /s/{code} [get]
+func (h *Handler) FollowShortLink(c *gin.Context) {
+	targetURL, err := h.usecase.ResolveShortLink(c.Request.Context(), c.Param("code"), c.Request.Referer(), c.Request.UserAgent())
+	if err != nil {
+		if errors.Is(err, models.ErrShortLinkNotFound) {
+			utils.RespondWithDomainError(c, err, "Short link not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to resolve short link")
+		return
+	}
+
+	c.Redirect(http.StatusFound, targetURL)
+}
+
+// TakeDownEpisode godoc
+// @Summary Take down an episode
+// @Description Disable an episode's audio (e.g. for a DMCA request) while keeping its metadata and analytics (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Param request body models.TakeDownEpisodeRequest true "Take Down Episode Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /admin/episodes/{id}/takedown [post]
+func (h *Handler) TakeDownEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	var req models.TakeDownEpisodeRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.TakeDownEpisode(c.Request.Context(), id, userIDParsed, req.Reason)
+	if err != nil {
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		}
+		if errors.Is(err, models.ErrEpisodeAlreadyTakenDown) {
+			utils.RespondWithDomainError(c, err, "Episode already taken down")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to take down episode")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// SearchEpisodes godoc
+// @Summary Search episodes
+// @Description Search episodes across podcasts. Queries match episode titles and descriptions and are ranked by relevance unless sorted otherwise.
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Param query query string false "Search query"
+// @Param podcast_id query string false "Podcast ID"
+// @Param from_date query string false "Published on or after (YYYY-MM-DD)"
+// @Param to_date query string false "Published before (YYYY-MM-DD)"
+// @Param sort_by query string false "Sort field (relevance, publication_date, title, duration)"
+// @Param sort_order query string false "Sort order (asc, desc; default: desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes [get]
+func (h *Handler) SearchEpisodes(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	params := models.EpisodeSearchParams{
+		Query:     strings.TrimSpace(c.Query("query")),
+		PodcastID: c.Query("podcast_id"),
+		SortBy:    c.Query("sort_by"),
+		SortOrder: c.Query("sort_order"),
+		Page:      pagination.Page,
+		PageSize:  pagination.PageSize,
+	}
+
+	if params.PodcastID != "" {
+		if _, err := uuid.Parse(params.PodcastID); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+			return
+		}
+	}
+
+	if from := c.Query("from_date"); from != "" {
+		fromDate, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid from date format")
+			return
+		}
+		params.FromDate = fromDate
+	}
+
+	if to := c.Query("to_date"); to != "" {
+		toDate, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid to date format")
+			return
+		}
+		params.ToDate = toDate
+	}
+
+	episodes, totalCount, err := h.usecase.SearchEpisodes(c.Request.Context(), params, viewerFromContext(c))
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidSortField) {
+			utils.RespondWithDomainError(c, err, "Invalid sort field")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to search episodes")
+		return
+	}
+
+	utils.RespondWithPagination(c, episodes, totalCount, params.Page, params.PageSize)
+}
+
+// Search godoc
+// @Summary Search podcasts and episodes
+// @Description Search podcasts and episodes in one call. Results are ranked by relevance together and carry their type. The category and language filters apply to episodes through their podcast.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param query query string true "Search query"
+// @Param type query string false "Result type (podcast, episode; default: both)"
+// @Param category query string false "Category ID"
+// @Param language query string false "Language code, matching its regional varieties too (default: the signed in user's preferred language; all: every language)"
+// @Param explicit query bool false "Only explicit (true) or only clean (false) results"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /search [get]
+func (h *Handler) Search(c *gin.Context) {
+	pagination := utils.GetPaginationParams(c)
+	params := models.SearchParams{
+		Query:    strings.TrimSpace(c.Query("query")),
+		Type:     c.Query("type"),
+		Category: c.Query("category"),
+		Language: c.Query("language"),
+		Page:     pagination.Page,
+		PageSize: pagination.PageSize,
+	}
+
+	if params.Type != "" && params.Type != models.SearchResultPodcast && params.Type != models.SearchResultEpisode {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid result type")
+		return
+	}
+
+	if params.Category != "" {
+		if _, err := uuid.Parse(params.Category); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid category ID")
+			return
+		}
+	}
+
+	if explicitStr := c.Query("explicit"); explicitStr != "" {
+		explicit, err := strconv.ParseBool(explicitStr)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid explicit filter")
+			return
+		}
+		params.Explicit = &explicit
+	}
+
+	results, totalCount, err := h.usecase.Search(c.Request.Context(), params, viewerFromContext(c))
+	if err != nil {
+		if errors.Is(err, models.ErrEmptyQuery) {
+			utils.RespondWithDomainError(c, err, "Search query is required")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidLanguage) {
+			utils.RespondWithDomainError(c, err, "Invalid language")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to search")
+		return
+	}
+
+	utils.RespondWithPagination(c, results, totalCount, params.Page, params.PageSize)
+}
+
+// GetEpisodesByPodcast godoc
+// @Summary Get podcast episodes
+// @Description Get episodes for a specific podcast
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Param id path string true "Podcast ID"
+// @Param type query string false "Episode type (full, trailer, bonus)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Param cursor query string false "Opts into cursor pagination ordered by creation time; empty for the first page, then the next_cursor of the previous page"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/episodes [get]
+func (h *Handler) GetEpisodesByPodcast(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(podcastIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	episodeType := c.Query("type")
+	switch episodeType {
+	case "", "full", "trailer", "bonus":
+	default:
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode type")
+		return
+	}
+
+	cursor, useCursor, err := utils.GetCursorParam(c)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid cursor")
+		return
+	}
+	if useCursor {
+		pagination := utils.GetPaginationParams(c)
+		episodes, next, err := h.usecase.GetEpisodesByPodcastIDAfter(c.Request.Context(), podcastID, episodeType, viewerFromContext(c), cursor, pagination.PageSize)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch episodes")
+			return
+		}
+
+		utils.RespondWithCursorPagination(c, episodes, next, pagination.PageSize)
+		return
+	}
+
+	page := utils.GetIntQueryParam(c, "page", 1)
+	pageSize := utils.GetIntQueryParam(c, "page_size", 20)
+
+	episodes, totalCount, err := h.usecase.GetEpisodesByPodcastID(c.Request.Context(), podcastID, episodeType, viewerFromContext(c), page, pageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch episodes")
+		return
+	}
+
+	utils.RespondWithPagination(c, episodes, totalCount, page, pageSize)
+}
+
+// BulkDeleteEpisodes godoc
+// @Summary Bulk delete episodes
+// @Description Delete several episodes of a podcast at once, by ID and/or publication date. IDs not belonging to the podcast are skipped.
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param request body models.BulkDeleteEpisodesRequest true "Bulk Delete Episodes Request"
+// @Success 200 {object} models.BulkDeleteEpisodesResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/episodes/bulk-delete [post]
+func (h *Handler) BulkDeleteEpisodes(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	var req models.BulkDeleteEpisodesRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	deleted, err := h.usecase.BulkDeleteEpisodes(c.Request.Context(), id, userIDParsed, &req)
+	if err != nil {
+		if errors.Is(err, models.ErrNoEpisodesSelected) {
+			utils.RespondWithDomainError(c, err, "Provide episode_ids or published_before")
+			return
+		}
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to delete episodes of this podcast")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete episodes")
+		return
+	}
+
+	utils.RespondWithSuccess(c, models.BulkDeleteEpisodesResponse{Deleted: deleted})
+}
+
+// PinEpisode godoc
+// @Summary Pin an episode
+// @Description Pin one of the podcast's episodes (e.g. a trailer) to the top of the podcast page, replacing any previous pin
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param request body models.PinEpisodeRequest true "Pin Episode Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/pinned-episode [put]
+func (h *Handler) PinEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	var req models.PinEpisodeRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.PinEpisode(c.Request.Context(), id, userIDParsed, req.EpisodeID)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to pin episodes of this podcast")
+		case errors.Is(err, models.ErrEpisodeNotInPodcast):
+			utils.RespondWithDomainError(c, err, "Episode does not belong to this podcast")
+		case errors.Is(err, models.ErrEpisodeNotActive):
+			utils.RespondWithDomainError(c, err, "Only active episodes can be pinned")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to pin episode")
+		}
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// UploadPodcastCover godoc
+// @Summary Upload podcast cover art
+// @Description Upload cover art for a podcast, replacing the cover from its RSS feed. Feed syncs keep the uploaded cover until the feed image changes.
+// @Tags podcasts
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param image formData file true "Cover image (jpg, jpeg, png or gif, 300 to 3000 pixels per side)"
+// @Success 200 {object} models.Podcast
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/cover [post]
+func (h *Handler) UploadPodcastCover(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	// Check if user is a podcaster
+	userType, exists := c.Get("user_type")
+	if !exists || userType.(string) != "podcaster" {
+		utils.RespondWithError(c, http.StatusForbidden, "Only podcasters can upload cover art")
+		return
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Image file is required")
+		return
+	}
+
+	podcast, err := h.usecase.UploadPodcastCover(c.Request.Context(), id, userIDParsed, fileHeader)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to change the cover of this podcast")
+		case errors.Is(err, models.ErrInvalidImage), errors.Is(err, storage.ErrFileTypeNotAllowed):
+			utils.RespondWithDomainError(c, err, "Cover must be a jpg, jpeg, png or gif image")
+		case errors.Is(err, models.ErrInvalidImageDimensions):
+			utils.RespondWithDomainError(c, err, "Cover must be between 300 and 3000 pixels wide and high")
+		case errors.Is(err, storage.ErrFileTooLarge):
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, "Image file is too large")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to upload cover")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, podcast)
+}
+
+// UnpinEpisode godoc
+// @Summary Unpin the pinned episode
+// @Description Remove the pinned episode of a podcast, restoring the plain date order
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/pinned-episode [delete]
+func (h *Handler) UnpinEpisode(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.UnpinEpisode(c.Request.Context(), id, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		if errors.Is(err, models.ErrNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to unpin episodes of this podcast")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to unpin episode")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// ListReviews godoc
+// @Summary List podcast reviews
+// @Description Get the reviews of a podcast, most recently written or edited first
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path string true "Podcast ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/reviews [get]
+func (h *Handler) ListReviews(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	reviews, totalCount, err := h.usecase.ListReviews(c.Request.Context(), podcastID, pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch reviews")
+		return
+	}
+
+	utils.RespondWithPagination(c, reviews, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// SaveReview godoc
+// @Summary Review a podcast
+// @Description Rate a podcast from 1 to 5 stars with an optional review. Each user has one review per podcast, which posting again replaces. Reviews tripping the content filter are rejected or held for review.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param request body models.SaveReviewRequest true "Save Review Request"
+// @Success 200 {object} models.PodcastReview
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 422 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/reviews [post]
+func (h *Handler) SaveReview(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	var req models.SaveReviewRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	review, err := h.usecase.SaveReview(c.Request.Context(), podcastID, userIDParsed, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidRating):
+			utils.RespondWithDomainError(c, err, "Rating must be between 1 and 5")
+		case errors.Is(err, models.ErrReviewTooLong):
+			utils.RespondWithDomainError(c, err, "Review is too long")
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrOwnPodcastReview):
+			utils.RespondWithDomainError(c, err, "You can't review your own podcast")
+		case errors.Is(err, models.ErrReviewRejected):
+			utils.RespondWithError(c, http.StatusUnprocessableEntity, "Review was rejected by the content filter")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to save review")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, review)
+}
+
+// DeleteReview godoc
+// @Summary Delete a podcast review
+// @Description Delete the current user's review of a podcast
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/reviews [delete]
+func (h *Handler) DeleteReview(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.DeleteReview(c.Request.Context(), podcastID, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrReviewNotFound) {
+			utils.RespondWithDomainError(c, err, "Review not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete review")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// ListComments godoc
+// @Summary List episode comments
+// @Description Get the comments on an episode, newest first
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Episode ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/comments [get]
+func (h *Handler) ListComments(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	episodeID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	comments, totalCount, err := h.usecase.ListComments(c.Request.Context(), episodeID, pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch comments")
+		return
+	}
+
+	utils.RespondWithPagination(c, comments, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// AddComment godoc
+// @Summary Comment on an episode
+// @Description Add a comment to an episode. Comments tripping the content filter are rejected or held for review.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Param request body models.CreateCommentRequest true "Create Comment Request"
+// @Success 201 {object} models.Comment
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 422 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/comments [post]
+func (h *Handler) AddComment(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	episodeID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	var req models.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	req.EpisodeID = episodeID
+	if !utils.Validate(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	comment, err := h.usecase.AddComment(c.Request.Context(), userIDParsed, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrCommentEmpty):
+			utils.RespondWithDomainError(c, err, "Comment content is required")
+		case errors.Is(err, models.ErrCommentTooLong):
+			utils.RespondWithDomainError(c, err, "Comment is too long")
+		case errors.Is(err, models.ErrEpisodeNotFound):
+			utils.RespondWithDomainError(c, err, "Episode not found")
+		case errors.Is(err, models.ErrCommentRejected):
+			utils.RespondWithError(c, http.StatusUnprocessableEntity, "Comment was rejected by the content filter")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to add comment")
+		}
+		return
+	}
+
+	// Comments have no URL of their own, so point to the episode's comments
+	utils.RespondWithCreated(c, "/api/v1/episodes/"+episodeID.String()+"/comments", comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Delete a comment; only its author may delete it
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Comment ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /comments/{id} [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.DeleteComment(c.Request.Context(), id, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrCommentNotFound) {
+			utils.RespondWithDomainError(c, err, "Comment not found")
+			return
+		}
+		if errors.Is(err, models.ErrCommentDeleteNotAuthorized) {
+			utils.RespondWithDomainError(c, err, "Not authorized to delete this comment")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// ListCategories godoc
+// @Summary List categories
+// @Description Get a list of podcast categories
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.Category
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /categories [get]
+func (h *Handler) ListCategories(c *gin.Context) {
+	categories, err := h.usecase.GetCategories(c.Request.Context())
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch categories")
+		return
+	}
+
+	utils.RespondWithSuccess(c, categories)
+}
+
+// Subscribe godoc
+// @Summary Subscribe to podcast
+// @Description Subscribe to a podcast. Subscribing again succeeds with already_subscribed set.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 200 {object} models.SubscribeResponse "Already subscribed"
+// @Success 201 {object} models.SubscribeResponse "Subscribed"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/subscribe [post]
+func (h *Handler) Subscribe(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(podcastIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	created, err := h.usecase.SubscribeToPodcast(c.Request.Context(), userIDParsed, podcastID)
+	if err != nil {
+		if errors.Is(err, models.ErrPodcastNotFound) {
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to subscribe")
+		return
+	}
+
+	response := models.SubscribeResponse{PodcastID: podcastID, AlreadySubscribed: !created}
+	if !created {
+		utils.RespondWithSuccess(c, response)
+		return
+	}
+	utils.RespondWithCreated(c, "", response)
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from podcast
+// @Description Unsubscribe from a podcast
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/unsubscribe [post]
+func (h *Handler) Unsubscribe(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(podcastIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.UnsubscribeFromPodcast(c.Request.Context(), userIDParsed, podcastID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to unsubscribe")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetSubscriptions godoc
+// @Summary Get subscribed podcasts
+// @Description Get the podcasts the current user is subscribed to
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /me/subscriptions [get]
+func (h *Handler) GetSubscriptions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	podcasts, totalCount, err := h.usecase.GetSubscribedPodcasts(c.Request.Context(), userIDParsed, pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch subscriptions")
+		return
+	}
+
+	utils.RespondWithPagination(c, podcasts, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// ImportSubscriptions godoc
+// @Summary Import subscriptions from OPML
+// @Description Subscribe to every feed of an uploaded OPML file, adding feeds missing from the catalog
+// @Tags subscriptions
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "OPML file"
+// @Success 200 {object} models.OPMLImportResult
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /me/subscriptions/import [post]
+func (h *Handler) ImportSubscriptions(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "OPML file is required")
+		return
+	}
+	if fileHeader.Size > maxOPMLFileSize {
+		utils.RespondWithError(c, http.StatusBadRequest, "OPML file is too large")
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Failed to read OPML file")
+		return
+	}
+	defer file.Close()
+
+	result, err := h.usecase.ImportSubscriptions(c.Request.Context(), userIDParsed, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidOPML):
+			utils.RespondWithDomainError(c, err, "Invalid OPML file")
+		case errors.Is(err, models.ErrNoFeedsToImport):
+			utils.RespondWithDomainError(c, err, "OPML file contains no feeds")
+		case errors.Is(err, models.ErrTooManyFeeds):
+			utils.RespondWithDomainError(c, err, "OPML file contains too many feeds")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to import subscriptions")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, result)
+}
+
+// GetSubscriptionStatus godoc
+// @Summary Get subscription status
+// @Description Check whether the current user is subscribed to a podcast
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/subscribed [get]
+func (h *Handler) GetSubscriptionStatus(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(podcastIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	subscribed, err := h.usecase.IsSubscribed(c.Request.Context(), userIDParsed, podcastID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch subscription status")
+		return
+	}
+
+	utils.RespondWithSuccess(c, gin.H{"subscribed": subscribed})
+}
+
+// SavePlaybackPosition godoc
+// @Summary Save playback position
+// @Description Save the current playback position for an episode
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.SavePlaybackPositionRequest true "Save Playback Position Request"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/playback [post]
+func (h *Handler) SavePlaybackPosition(c *gin.Context) {
+	var req models.SavePlaybackPositionRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.SavePlaybackPosition(c.Request.Context(), userIDParsed, req.EpisodeID, req.Position, req.Completed)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to save playback position")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetPlaybackPosition godoc
+// @Summary Get playback position
+// @Description Get where the current user left off in an episode, to resume playback
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/playback [get]
+func (h *Handler) GetPlaybackPosition(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	episodeID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	position, completed, err := h.usecase.GetPlaybackPosition(c.Request.Context(), userIDParsed, episodeID)
+	if err != nil {
+		if errors.Is(err, models.ErrPlaybackPositionNotFound) {
+			utils.RespondWithDomainError(c, err, "No playback position for this episode")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get playback position")
+		return
+	}
+
+	utils.RespondWithSuccess(c, gin.H{
+		"position":  position,
+		"completed": completed,
+	})
+}
+
+// GetPlaybackPositions godoc
+// @Summary Get playback positions
+// @Description Get where the current user left off in each of up to 100 episodes, e.g. to render a "continue listening" row. Episodes the user hasn't played are left out.
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.GetPlaybackPositionsRequest true "Get Playback Positions Request"
+// @Success 200 {array} models.PlaybackPosition
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/playback/positions [post]
+func (h *Handler) GetPlaybackPositions(c *gin.Context) {
+	var req models.GetPlaybackPositionsRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	positions, err := h.usecase.GetPlaybackPositions(c.Request.Context(), userIDParsed, req.EpisodeIDs)
+	if err != nil {
+		if errors.Is(err, models.ErrTooManyEpisodeIDs) {
+			utils.RespondWithDomainError(c, err, "At most 100 episode IDs can be requested at once")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get playback positions")
+		return
+	}
+
+	utils.RespondWithSuccess(c, positions)
+}
+
+// MarkEpisodePlayed godoc
+// @Summary Mark episode as played
+// @Description Mark an episode as played by the current user, moving their position to its end
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/played [post]
+func (h *Handler) MarkEpisodePlayed(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	episodeID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.MarkEpisodePlayed(c.Request.Context(), userIDParsed, episodeID)
+	if err != nil {
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to mark episode as played")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// MarkEpisodeUnplayed godoc
+// @Summary Mark episode as unplayed
+// @Description Reset the current user's progress in an episode, removing it from their continue-listening episodes and listening history
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Episode ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /episodes/{id}/played [delete]
+func (h *Handler) MarkEpisodeUnplayed(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	episodeID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid episode ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.MarkEpisodeUnplayed(c.Request.Context(), userIDParsed, episodeID)
+	if err != nil {
+		if errors.Is(err, models.ErrEpisodeNotFound) {
+			utils.RespondWithDomainError(c, err, "Episode not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to mark episode as unplayed")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetContinueListening godoc
+// @Summary Get continue-listening episodes
+// @Description Get the episodes the current user started but didn't finish, most recently played first, with the position and duration to render their progress
+// @Tags episodes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /me/continue-listening [get]
+func (h *Handler) GetContinueListening(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	items, totalCount, err := h.usecase.GetContinueListening(c.Request.Context(), userIDParsed, pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get continue-listening episodes")
+		return
+	}
+
+	utils.RespondWithPagination(c, items, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get the current user's notifications, newest first, such as new episodes of the podcasts they subscribe to. Unread notifications have no read_at.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /me/notifications [get]
+func (h *Handler) GetNotifications(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	notifications, totalCount, err := h.usecase.GetNotifications(c.Request.Context(), userIDParsed, pagination.Page, pagination.PageSize)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get notifications")
+		return
+	}
+
+	utils.RespondWithPagination(c, notifications, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark notification as read
+// @Description Mark one of the current user's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Notification ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /me/notifications/{id}/read [post]
+func (h *Handler) MarkNotificationRead(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.MarkNotificationRead(c.Request.Context(), notificationID, userIDParsed)
+	if err != nil {
+		if errors.Is(err, models.ErrNotificationNotFound) {
+			utils.RespondWithDomainError(c, err, "Notification not found")
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to mark notification as read")
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetSyncStatus godoc
+// @Summary Get RSS feed sync status
+// @Description Get the status of RSS feed synchronization for a podcast. Failed syncs include a failure_reason, the HTTP status when the feed server answered with an error, and a failure_message to show the podcaster
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 200 {object} models.RSSFeedSyncLog
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/sync-status [get]
+func (h *Handler) GetSyncStatus(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
 	if !ok {
 		return
 	}
@@ -666,9 +3188,32 @@ func (h *Handler) GetSyncStatus(c *gin.Context) {
 		return
 	}
 
-	syncLog, err := h.usecase.GetLatestSyncLog(c.Request.Context(), podcastID)
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	userType, _ := c.Get("user_type")
+	isAdmin := userType == "admin"
+
+	syncLog, err := h.usecase.GetLatestSyncLog(c.Request.Context(), podcastID, userIDParsed, isAdmin)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get sync status")
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to view the sync status of this podcast")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get sync status")
+		}
 		return
 	}
 
@@ -680,36 +3225,445 @@ func (h *Handler) GetSyncStatus(c *gin.Context) {
 	utils.RespondWithSuccess(c, syncLog)
 }
 
+// GetSyncLogs godoc
+// @Summary Get RSS feed sync history
+// @Description Get the history of RSS feed synchronizations of a podcast, newest first, with the episodes each one added, updated and removed and why failed ones failed (owner or admin only)
+// @Tags podcasts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/sync-logs [get]
+func (h *Handler) GetSyncLogs(c *gin.Context) {
+	podcastIDStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(podcastIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	userType, _ := c.Get("user_type")
+	isAdmin := userType == "admin"
+
+	pagination := utils.GetPaginationParams(c)
+
+	logs, totalCount, err := h.usecase.GetSyncLogs(c.Request.Context(), podcastID, userIDParsed, isAdmin, pagination.Page, pagination.PageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to view the sync history of this podcast")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get sync logs")
+		}
+		return
+	}
+
+	utils.RespondWithPagination(c, logs, totalCount, pagination.Page, pagination.PageSize)
+}
+
+// CreateWebhook godoc
+// @Summary Register a webhook
+// @Description Register a URL to be POSTed events of a podcast: episode.added when a feed sync adds an episode and sync.failed when a feed sync fails. Payloads are signed in the X-Webhook-Signature header as sha256= and the hex HMAC-SHA256 of the body, keyed with the secret, which is only returned here. Failed deliveries are retried with backoff.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param request body models.CreateWebhookRequest true "Create Webhook Request"
+// @Success 201 {object} models.CreateWebhookResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	var req models.CreateWebhookRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	webhook, err := h.usecase.CreateWebhook(c.Request.Context(), podcastID, userIDParsed, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to manage the webhooks of this podcast")
+		case errors.Is(err, models.ErrInvalidWebhookURL):
+			utils.RespondWithDomainError(c, err, "Webhook URL must be a public http or https URL")
+		case errors.Is(err, models.ErrTooManyWebhooks):
+			utils.RespondWithDomainError(c, err, "This podcast has too many webhooks")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create webhook")
+		}
+		return
+	}
+
+	utils.RespondWithCreated(c, "/api/v1/podcasts/"+podcastID.String()+"/webhooks/"+webhook.ID.String(), webhook)
+}
+
+// GetWebhooks godoc
+// @Summary Get webhooks
+// @Description Get the webhooks registered for a podcast
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Success 200 {array} models.Webhook
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/webhooks [get]
+func (h *Handler) GetWebhooks(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	webhooks, err := h.usecase.GetWebhooks(c.Request.Context(), podcastID, userIDParsed)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to manage the webhooks of this podcast")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get webhooks")
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, webhooks)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook of a podcast along with its delivery log. Pending deliveries are dropped.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/webhooks/{webhook_id} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	webhookIDStr, ok := utils.ExtractIDParam(c, "webhook_id")
+	if !ok {
+		return
+	}
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	err = h.usecase.DeleteWebhook(c.Request.Context(), podcastID, webhookID, userIDParsed)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrWebhookNotFound):
+			utils.RespondWithDomainError(c, err, "Webhook not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to manage the webhooks of this podcast")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete webhook")
+		}
+		return
+	}
+
+	utils.RespondWithNoContent(c)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description Get the delivery log of a webhook, newest first, with each delivery's payload, status (pending, delivered or failed), attempts, and the response status or error of the last attempt
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Podcast ID"
+// @Param webhook_id path string true "Webhook ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /podcasts/{id}/webhooks/{webhook_id}/deliveries [get]
+func (h *Handler) GetWebhookDeliveries(c *gin.Context) {
+	idStr, ok := utils.ExtractIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	podcastID, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid podcast ID")
+		return
+	}
+
+	webhookIDStr, ok := utils.ExtractIDParam(c, "webhook_id")
+	if !ok {
+		return
+	}
+
+	webhookID, err := uuid.Parse(webhookIDStr)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	pagination := utils.GetPaginationParams(c)
+
+	deliveries, totalCount, err := h.usecase.GetWebhookDeliveries(c.Request.Context(), podcastID, webhookID, userIDParsed, pagination.Page, pagination.PageSize)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrPodcastNotFound):
+			utils.RespondWithDomainError(c, err, "Podcast not found")
+		case errors.Is(err, models.ErrWebhookNotFound):
+			utils.RespondWithDomainError(c, err, "Webhook not found")
+		case errors.Is(err, models.ErrNotAuthorized):
+			utils.RespondWithDomainError(c, err, "Not authorized to manage the webhooks of this podcast")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to get webhook deliveries")
+		}
+		return
+	}
+
+	utils.RespondWithPagination(c, deliveries, totalCount, pagination.Page, pagination.PageSize)
+}
+
 // RegisterRoutes registers all the content routes
-func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, optionalAuthMiddleware gin.HandlerFunc) {
 	// Public routes
 	podcasts := router.Group("/podcasts")
+	podcasts.Use(optionalAuthMiddleware)
 	{
 		podcasts.GET("", h.ListPodcasts)
 		podcasts.GET("/:id", h.GetPodcast)
-		podcasts.GET("/:podcast_id/episodes", h.GetEpisodesByPodcast)
+		podcasts.GET("/:id/episodes", h.GetEpisodesByPodcast)
+		podcasts.GET("/:id/reviews", h.ListReviews)
 	}
 
 	episodes := router.Group("/episodes")
+	episodes.Use(optionalAuthMiddleware)
 	{
+		episodes.GET("", h.SearchEpisodes)
 		episodes.GET("/:id", h.GetEpisode)
+		episodes.GET("/:id/stream", h.StreamEpisode)
+		episodes.GET("/:id/comments", h.ListComments)
+		episodes.GET("/:id/transcript/search", h.SearchTranscript)
 	}
 
+	router.GET("/search", optionalAuthMiddleware, h.Search)
 	router.GET("/categories", h.ListCategories)
-	router.GET("/users/:user_id/podcasts", h.GetPodcastsByUser)
+	router.GET("/oembed", h.GetOEmbed)
+	router.POST("/shortlinks", h.CreateShortLink)
+	router.GET("/users/:user_id/podcasts", optionalAuthMiddleware, h.GetPodcastsByUser)
 
 	// Protected routes
 	protected := router.Group("")
 	protected.Use(authMiddleware)
 	{
 		protected.POST("/podcasts", h.CreatePodcast)
+		protected.POST("/podcasts/preview", h.PreviewFeed)
 		protected.PUT("/podcasts/:id", h.UpdatePodcast)
 		protected.DELETE("/podcasts/:id", h.DeletePodcast)
 		protected.POST("/podcasts/:id/sync", h.SyncPodcast)
+		protected.POST("/podcasts/:id/sync/resume", h.ResumePodcastSync)
+		protected.POST("/podcasts/:id/episodes/bulk-delete", h.BulkDeleteEpisodes)
+		protected.PUT("/podcasts/:id/pinned-episode", h.PinEpisode)
+		protected.DELETE("/podcasts/:id/pinned-episode", h.UnpinEpisode)
+		protected.POST("/podcasts/:id/cover", h.UploadPodcastCover)
+		protected.POST("/podcasts/:id/reviews", h.SaveReview)
+		protected.DELETE("/podcasts/:id/reviews", h.DeleteReview)
+		protected.POST("/podcasts/:id/report", h.ReportPodcast)
+		protected.GET("/podcasts/:id/sync-status", h.GetSyncStatus)
+		protected.GET("/podcasts/:id/sync-logs", h.GetSyncLogs)
+		protected.POST("/podcasts/:id/webhooks", h.CreateWebhook)
+		protected.GET("/podcasts/:id/webhooks", h.GetWebhooks)
+		protected.DELETE("/podcasts/:id/webhooks/:webhook_id", h.DeleteWebhook)
+		protected.GET("/podcasts/:id/webhooks/:webhook_id/deliveries", h.GetWebhookDeliveries)
 		
-		protected.POST("/podcasts/:podcast_id/subscribe", h.Subscribe)
-		protected.POST("/podcasts/:podcast_id/unsubscribe", h.Unsubscribe)
+		protected.POST("/podcasts/:id/subscribe", h.Subscribe)
+		protected.POST("/podcasts/:id/unsubscribe", h.Unsubscribe)
+		protected.GET("/podcasts/:id/subscribed", h.GetSubscriptionStatus)
+		protected.GET("/me/subscriptions", h.GetSubscriptions)
+		protected.POST("/me/subscriptions/import", h.ImportSubscriptions)
+		protected.GET("/me/continue-listening", h.GetContinueListening)
+		protected.GET("/me/notifications", h.GetNotifications)
+		protected.POST("/me/notifications/:id/read", h.MarkNotificationRead)
 		
 		protected.POST("/episodes/playback", h.SavePlaybackPosition)
+		protected.POST("/episodes/playback/positions", h.GetPlaybackPositions)
+		protected.GET("/episodes/:id/playback", h.GetPlaybackPosition)
+		protected.POST("/episodes/:id/played", h.MarkEpisodePlayed)
+		protected.DELETE("/episodes/:id/played", h.MarkEpisodeUnplayed)
+		protected.POST("/episodes/:id/refresh", h.RefreshEpisode)
+		protected.PUT("/episodes/:id/chapters", h.SetEpisodeChapters)
+		protected.POST("/episodes/:id/comments", h.AddComment)
+		protected.DELETE("/comments/:id", h.DeleteComment)
+		protected.POST("/comments/:id/report", h.ReportComment)
+	}
+
+	// Admin routes
+	admin := protected.Group("/admin")
+	admin.Use(middleware.RoleMiddleware("admin"))
+	{
+		admin.GET("/podcasts/sync-suspended", h.ListSyncSuspendedPodcasts)
+		admin.POST("/sync-all", h.StartSyncAll)
+		admin.GET("/sync-all/:job_id", h.GetSyncAllJob)
+		admin.POST("/episodes/:id/takedown", h.TakeDownEpisode)
+		admin.GET("/comments", h.ListModerationComments)
+		admin.GET("/comments/counts", h.GetCommentCounts)
+		admin.PATCH("/comments/:id", h.ModerateComment)
+		admin.DELETE("/comments/:id", h.RemoveComment)
+		admin.POST("/podcasts/:id/suspend", h.SuspendPodcast)
+		admin.POST("/podcasts/:id/unsuspend", h.UnsuspendPodcast)
+		admin.GET("/reports", h.ListReports)
+		admin.GET("/reports/counts", h.GetReportCounts)
+		admin.PATCH("/reports/:id", h.ResolveReport)
+	}
+}
+// viewerFromContext gets the requesting user set by the auth middlewares; anonymous if there is none.
+// A hide_explicit query parameter overrides the user's preference for explicit content.
+func viewerFromContext(c *gin.Context) models.Viewer {
+	var viewer models.Viewer
+	if userID, exists := c.Get("user_id"); exists {
+		viewer.UserID, _ = uuid.Parse(userID.(string))
 	}
+	if userType, exists := c.Get("user_type"); exists {
+		viewer.IsAdmin = userType.(string) == "admin"
+	}
+	if hideExplicit, err := strconv.ParseBool(c.Query("hide_explicit")); err == nil {
+		viewer.HideExplicit = &hideExplicit
+	}
+	return viewer
+}
+
+// RegisterShortLinkRoutes registers the short link redirect at the root of the
+// router, outside the versioned API, to keep the shared URLs short
+func (h *Handler) RegisterShortLinkRoutes(router gin.IRoutes) {
+	router.GET("/s/:code", h.FollowShortLink)
 }
\ No newline at end of file
@@ -0,0 +1,75 @@
+// pkg/content/models/errors.go
+package models
+
+import (
+	"errors"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/errs"
+)
+
+// Errors returned by the content repository, usecase and sync service
+var (
+	ErrPodcastNotFound          = errs.NotFound("podcast not found")
+	ErrEpisodeNotFound          = errs.NotFound("episode not found")
+	ErrCommentNotFound          = errs.NotFound("comment not found")
+	ErrReviewNotFound           = errs.NotFound("review not found")
+	ErrPlaylistNotFound         = errs.NotFound("playlist not found")
+	ErrPlaylistNotAccessible    = errs.NotFound("playlist not found or not accessible")
+	ErrPlaybackPositionNotFound = errs.NotFound("playback position not found")
+	ErrShortLinkNotFound        = errs.NotFound("short link not found")
+	ErrReportNotFound           = errs.NotFound("report not found")
+	ErrEpisodeNotInFeed         = errs.NotFound("episode not in feed")
+	ErrUnsupportedURL           = errs.NotFound("unsupported url")
+	ErrNotificationNotFound     = errs.NotFound("notification not found")
+	ErrWebhookNotFound          = errs.NotFound("webhook not found")
+	ErrSyncJobNotFound          = errs.NotFound("sync job not found")
+
+	ErrNotAuthorized               = errs.NotAuthorized("not authorized")
+	ErrCommentDeleteNotAuthorized  = errs.NotAuthorized("not authorized to delete this comment")
+	ErrPlaylistUpdateNotAuthorized = errs.NotAuthorized("not authorized to update this playlist")
+	ErrPlaylistDeleteNotAuthorized = errs.NotAuthorized("not authorized to delete this playlist")
+	ErrOwnPodcastReview            = errs.NotAuthorized("cannot review own podcast")
+
+	ErrEpisodeAlreadyTakenDown = errs.Conflict("episode already taken down")
+	ErrSyncInProgress          = errs.Conflict("sync already in progress")
+	ErrFullSyncInProgress      = errs.Conflict("full sync already in progress")
+	ErrPodcastAlreadySuspended = errs.Conflict("podcast already suspended")
+	ErrPodcastNotSuspended     = errs.Conflict("podcast not suspended")
+	ErrAlreadyReported         = errs.Conflict("already reported")
+	ErrReportAlreadyResolved   = errs.Conflict("report already resolved")
+
+	ErrInvalidSortField       = errs.Validation("invalid sort field")
+	ErrNoRSSURL               = errs.Validation("podcast has no RSS URL")
+	ErrEmptyQuery             = errs.Validation("empty query")
+	ErrTooManyChapters        = errs.Validation("too many chapters")
+	ErrInvalidChapters        = errs.Validation("invalid chapters")
+	ErrInvalidTargetType      = errs.Validation("invalid target type")
+	ErrNoEpisodesSelected     = errs.Validation("no episodes selected")
+	ErrEpisodeNotInPodcast    = errs.Validation("episode not in podcast")
+	ErrEpisodeNotActive       = errs.Validation("episode not active")
+	ErrInvalidImage           = errs.Validation("invalid image")
+	ErrInvalidImageDimensions = errs.Validation("invalid image dimensions")
+	ErrInvalidRating          = errs.Validation("invalid rating")
+	ErrReviewTooLong          = errs.Validation("review too long")
+	ErrReviewRejected         = errs.Validation("review rejected")
+	ErrCommentEmpty           = errs.Validation("comment is empty")
+	ErrCommentTooLong         = errs.Validation("comment too long")
+	ErrCommentRejected        = errs.Validation("comment rejected")
+	ErrInvalidOPML            = errs.Validation("invalid OPML file")
+	ErrNoFeedsToImport        = errs.Validation("no feeds to import")
+	ErrTooManyFeeds           = errs.Validation("too many feeds")
+	ErrTooManyEpisodeIDs      = errs.Validation("too many episode IDs")
+	ErrInvalidCommentStatus   = errs.Validation("invalid comment status")
+	ErrInvalidReportStatus    = errs.Validation("invalid report status")
+	ErrInvalidWebhookURL      = errs.Validation("invalid webhook URL")
+	ErrTooManyWebhooks        = errs.Validation("too many webhooks")
+	ErrInvalidSyncInterval    = errs.Validation("invalid sync interval")
+	ErrInvalidLanguage        = errs.Validation("invalid language")
+
+	// ErrEpisodeTakenDown is returned for episodes removed for legal reasons, which have a
+	// status code of their own
+	ErrEpisodeTakenDown = errors.New("episode taken down")
+
+	// ErrFeedFetch wraps the errors of fetching and parsing a podcast's feed while syncing
+	ErrFeedFetch = errors.New("failed to parse feed")
+)
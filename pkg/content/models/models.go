@@ -2,9 +2,14 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // Podcast represents a podcast
@@ -25,7 +30,21 @@ type Podcast struct {
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 	LastSyncedAt *time.Time `json:"last_synced_at" db:"last_synced_at"`
-	EpisodeCount int        `json:"episode_count,omitempty"`
+	SyncFailureCount int        `json:"sync_failure_count" db:"sync_failure_count"`
+	NextSyncRetryAt  *time.Time `json:"next_sync_retry_at,omitempty" db:"next_sync_retry_at"`
+	SyncSuspended    bool       `json:"sync_suspended" db:"sync_suspended"`
+	PinnedEpisodeID  *uuid.UUID `json:"pinned_episode_id,omitempty" db:"pinned_episode_id"`
+	PruneMissing     bool       `json:"prune_missing" db:"prune_missing"`
+	SyncIntervalMinutes *int    `json:"sync_interval_minutes,omitempty" db:"sync_interval_minutes"` // overrides RSS_SYNC_INTERVAL
+	FeedETag         string     `json:"-" db:"feed_etag"`
+	FeedLastModified string     `json:"-" db:"feed_last_modified"`
+	CoverImagePath   string     `json:"-" db:"cover_image_path"`
+	CoverImageUserSet bool      `json:"cover_image_user_set" db:"cover_image_user_set"`
+	FeedCoverImageURL string    `json:"-" db:"feed_cover_image_url"`
+	Keywords     Keywords   `json:"keywords,omitempty" db:"keywords"`
+	EpisodeCount int        `json:"episode_count,omitempty" db:"episode_count"`
+	SubscriberCount int     `json:"subscriber_count,omitempty" db:"subscriber_count"`
+	ListenCount  int        `json:"-" db:"listen_count"` // refreshed periodically from listen events; see PodcastResponse
 	Categories   []*Category `json:"categories,omitempty"`
 }
 
@@ -36,18 +55,267 @@ type Episode struct {
 	Title           string     `json:"title" db:"title"`
 	Description     string     `json:"description" db:"description"`
 	AudioURL        string     `json:"audio_url" db:"audio_url"`
+	FileSize        int64      `json:"file_size" db:"file_size"` // in bytes, zero when unknown
+	MimeType        string     `json:"mime_type" db:"mime_type"`
 	Duration        int        `json:"duration" db:"duration"`
 	CoverImageURL   string     `json:"cover_image_url" db:"cover_image_url"`
 	PublicationDate time.Time  `json:"publication_date" db:"publication_date"`
 	GUID            string     `json:"guid" db:"guid"`
 	EpisodeNumber   *int       `json:"episode_number" db:"episode_number"`
 	SeasonNumber    *int       `json:"season_number" db:"season_number"`
+	EpisodeType     string     `json:"episode_type" db:"episode_type"` // full, trailer or bonus
 	Transcript      string     `json:"transcript" db:"transcript"`
+	Transcripts     TranscriptLinks `json:"transcripts,omitempty" db:"transcripts"`
+	Chapters        Chapters   `json:"chapters,omitempty" db:"chapters"`
+	ChaptersURL     string     `json:"chapters_url,omitempty" db:"chapters_url"`  // podcast:chapters file the chapters were read from
+	ChaptersUserSet bool       `json:"-" db:"chapters_user_set"`                  // set by the podcaster, so syncs leave them alone
+	Keywords        Keywords   `json:"keywords,omitempty" db:"keywords"`
+	AlternateEnclosures Enclosures `json:"alternate_enclosures,omitempty" db:"alternate_enclosures"` // other formats of the audio offered by the feed
+	Explicit        bool       `json:"explicit" db:"explicit"`
 	Status          string     `json:"status" db:"status"`
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
 
+// TranscriptLink points to an episode transcript in one format, from a podcast:transcript tag
+type TranscriptLink struct {
+	URL      string `json:"url"`
+	Type     string `json:"type"` // MIME type, e.g. text/vtt, application/x-subrip or text/plain
+	Language string `json:"language,omitempty"`
+	Rel      string `json:"rel,omitempty"` // "captions" for timed captions
+}
+
+// TranscriptLinks are the transcript links of an episode, stored as a JSON column
+type TranscriptLinks []TranscriptLink
+
+// Value encodes the links as JSON for the database
+func (t TranscriptLinks) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan decodes the links from a JSON database value
+func (t *TranscriptLinks) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return errors.New("unsupported transcript links value")
+	}
+}
+
+// Enclosure is a media file attached to a feed item
+type Enclosure struct {
+	URL    string `json:"url"`
+	Type   string `json:"type,omitempty"`   // MIME type, e.g. audio/mpeg
+	Length int64  `json:"length,omitempty"` // in bytes, zero when the feed gives none
+}
+
+// Enclosures are the alternate enclosures of an episode, stored as a JSON column
+type Enclosures []Enclosure
+
+// Value encodes the enclosures as JSON for the database
+func (e Enclosures) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan decodes the enclosures from a JSON database value
+func (e *Enclosures) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return errors.New("unsupported enclosures value")
+	}
+}
+
+// TranscriptSegment is a searchable piece of an episode transcript: a cue of a timed
+// transcript or a paragraph of a plain text one
+type TranscriptSegment struct {
+	StartTime  *float64 // in seconds, for timed transcripts
+	EndTime    *float64
+	CharOffset int // in characters from the start of the transcript text
+	Text       string
+}
+
+// TranscriptMatch is a place in an episode transcript matching a search, with the matching
+// words of its excerpt wrapped in <mark> tags. The rest of the excerpt is HTML-escaped.
+type TranscriptMatch struct {
+	StartTime  *float64 `json:"start_time,omitempty" db:"start_time"` // in seconds, for timed transcripts
+	CharOffset int      `json:"char_offset" db:"char_offset"`
+	Excerpt    string   `json:"excerpt" db:"excerpt"`
+}
+
+// Chapter is a section of an episode, for chapter navigation in players
+type Chapter struct {
+	StartTime float64 `json:"start_time"` // in seconds
+	Title     string  `json:"title"`
+	ImageURL  string  `json:"image_url,omitempty"`
+	URL       string  `json:"url,omitempty"`
+}
+
+// Chapters are the chapters of an episode in playback order, stored as a JSON column
+type Chapters []Chapter
+
+// Value encodes the chapters as JSON for the database
+func (c Chapters) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan decodes the chapters from a JSON database value
+func (c *Chapters) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("unsupported chapters value")
+	}
+}
+
+// Keywords are the itunes:keywords of a podcast or episode, lowercased, stored as a text array
+type Keywords []string
+
+// Value encodes the keywords as a Postgres array
+func (k Keywords) Value() (driver.Value, error) {
+	if k == nil {
+		return "{}", nil
+	}
+	return pq.StringArray(k).Value()
+}
+
+// Scan decodes the keywords from a Postgres array
+func (k *Keywords) Scan(src interface{}) error {
+	return (*pq.StringArray)(k).Scan(src)
+}
+
+// EpisodeTakedown represents an audit record of an episode being taken down
+type EpisodeTakedown struct {
+	ID             uuid.UUID `json:"id" db:"id"`
+	EpisodeID      uuid.UUID `json:"episode_id" db:"episode_id"`
+	TakenDownBy    uuid.UUID `json:"taken_down_by" db:"taken_down_by"`
+	Reason         string    `json:"reason" db:"reason"`
+	PreviousStatus string    `json:"previous_status" db:"previous_status"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// ShortLink represents a compact share code for an episode or podcast
+type ShortLink struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	Code       string    `json:"code" db:"code"`
+	TargetType string    `json:"target_type" db:"target_type"` // episode, podcast
+	TargetID   uuid.UUID `json:"target_id" db:"target_id"`
+	ClickCount int       `json:"click_count" db:"click_count"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Webhook is a URL a podcaster registered to receive events of their podcast
+type Webhook struct {
+	ID        uuid.UUID      `json:"id" db:"id"`
+	PodcastID uuid.UUID      `json:"podcast_id" db:"podcast_id"`
+	URL       string         `json:"url" db:"url"`
+	Secret    string         `json:"-" db:"secret"` // signs payloads; only shown when the webhook is created
+	Events    pq.StringArray `json:"events" db:"events"`
+	CreatedBy *uuid.UUID     `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// WebhookDelivery is an event sent, or still to be sent, to a webhook
+type WebhookDelivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	WebhookID      uuid.UUID       `json:"webhook_id" db:"webhook_id"`
+	Event          string          `json:"event" db:"event"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         string          `json:"status" db:"status"` // pending, delivered, failed
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status,omitempty" db:"response_status"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	
+	// Joined data
+	URL    string `json:"-" db:"url"`
+	Secret string `json:"-" db:"secret"`
+}
+
+// WebhookEvent is the JSON body POSTed to webhooks
+type WebhookEvent struct {
+	ID        uuid.UUID   `json:"id"`
+	Event     string      `json:"event"`
+	PodcastID uuid.UUID   `json:"podcast_id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookEpisodeAddedData is the data of an episode.added event
+type WebhookEpisodeAddedData struct {
+	Episode *Episode `json:"episode"`
+}
+
+// WebhookSyncFailedData is the data of a sync.failed event
+type WebhookSyncFailedData struct {
+	FailureReason  string `json:"failure_reason"`
+	HTTPStatus     int    `json:"http_status,omitempty"`
+	FailureMessage string `json:"failure_message"`
+}
+
+// Webhook events
+const (
+	WebhookEventEpisodeAdded = "episode.added" // a feed sync added an episode
+	WebhookEventSyncFailed   = "sync.failed"   // a feed sync failed
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed" // gave up after the last attempt
+)
+
+// ShortLinkClick represents a recorded visit of a short link
+type ShortLinkClick struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	ShortLinkID uuid.UUID `json:"short_link_id" db:"short_link_id"`
+	Referrer    string    `json:"referrer" db:"referrer"`
+	UserAgent   string    `json:"user_agent" db:"user_agent"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
 // Category represents a podcast category
 type Category struct {
 	ID          uuid.UUID `json:"id" db:"id"`
@@ -82,6 +350,7 @@ type Comment struct {
 	EpisodeID uuid.UUID `json:"episode_id" db:"episode_id"`
 	Content   string    `json:"content" db:"content"`
 	Status    string    `json:"status" db:"status"`
+	FlagReason string   `json:"flag_reason,omitempty" db:"flag_reason"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 	
@@ -91,6 +360,55 @@ type Comment struct {
 	UserProfileURL string `json:"user_profile_url" db:"user_profile_url"`
 }
 
+// PodcastReview represents a listener's rating of a podcast, with an optional review
+type PodcastReview struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	PodcastID  uuid.UUID `json:"podcast_id" db:"podcast_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Rating     int       `json:"rating" db:"rating"` // 1 to 5 stars
+	Content    string    `json:"content" db:"content"`
+	Status     string    `json:"status" db:"status"`
+	FlagReason string    `json:"flag_reason,omitempty" db:"flag_reason"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	
+	// Joined data
+	Username       string `json:"username" db:"username"`
+	UserFullName   string `json:"user_full_name" db:"user_full_name"`
+	UserProfileURL string `json:"user_profile_url" db:"user_profile_url"`
+}
+
+// PodcastRating is the aggregate of the visible reviews of a podcast
+type PodcastRating struct {
+	PodcastID     uuid.UUID `db:"podcast_id"`
+	AverageRating float64   `db:"average_rating"`
+	ReviewCount   int       `db:"review_count"`
+}
+
+// EpisodeListenStats is the aggregate of the listen events of an episode
+type EpisodeListenStats struct {
+	EpisodeID         uuid.UUID `db:"episode_id"`
+	ListenCount       int       `db:"listen_count"`
+	AverageCompletion float64   `db:"average_completion"` // percentage
+}
+
+// ContentReport represents a user's report of an abusive comment or podcast
+type ContentReport struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	ReporterID uuid.UUID  `json:"reporter_id" db:"reporter_id"`
+	TargetType string     `json:"target_type" db:"target_type"` // comment, podcast
+	TargetID   uuid.UUID  `json:"target_id" db:"target_id"`
+	Reason     string     `json:"reason" db:"reason"`
+	Details    string     `json:"details,omitempty" db:"details"`
+	Status     string     `json:"status" db:"status"` // open, reviewed, dismissed
+	ReviewedBy *uuid.UUID `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	
+	// Joined data
+	ReporterUsername string `json:"reporter_username" db:"reporter_username"`
+}
+
 // Playlist represents a user's playlist
 type Playlist struct {
 	ID          uuid.UUID `json:"id" db:"id"`
@@ -102,7 +420,7 @@ type Playlist struct {
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 	
 	// Metadata
-	EpisodeCount int `json:"episode_count,omitempty"`
+	EpisodeCount int `json:"episode_count,omitempty" db:"episode_count"`
 }
 
 // PlaylistItem represents an episode in a playlist
@@ -125,12 +443,19 @@ type RSSFeedItem struct {
 	Title           string    `json:"title"`
 	Description     string    `json:"description"`
 	AudioURL        string    `json:"audio_url"`
+	Enclosure       Enclosure `json:"enclosure"`                      // the enclosure AudioURL was taken from
+	AlternateEnclosures Enclosures `json:"alternate_enclosures,omitempty"` // the item's other enclosures
 	Duration        int       `json:"duration"`
 	GUID            string    `json:"guid"`
 	PublicationDate time.Time `json:"publication_date"`
 	CoverImageURL   string    `json:"cover_image_url"`
 	EpisodeNumber   *int      `json:"episode_number"`
 	SeasonNumber    *int      `json:"season_number"`
+	EpisodeType     string    `json:"episode_type"`
+	Transcripts     TranscriptLinks `json:"transcripts,omitempty"`
+	ChaptersURL     string    `json:"chapters_url,omitempty"`
+	Keywords        Keywords  `json:"keywords,omitempty"`
+	Explicit        bool      `json:"explicit"` // the feed's flag unless the item has its own
 }
 
 // RSSFeed represents a parsed RSS feed
@@ -144,7 +469,17 @@ type RSSFeed struct {
 	Category     string        `json:"category"`
 	Subcategory  string        `json:"subcategory"`
 	Explicit     bool          `json:"explicit"`
+	Keywords     Keywords      `json:"keywords,omitempty"`
 	Items        []RSSFeedItem `json:"items"`
+	Warnings     []string      `json:"warnings,omitempty"` // non-fatal problems noticed while fetching the feed
+}
+
+// FeedValidators are the HTTP cache validators of a fetched feed, sent back on the
+// next fetch so that an unchanged feed is answered with 304 Not Modified
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+	MovedTo      string // URL the feed was permanently redirected to, set on returned validators only
 }
 
 // RSSFeedSyncResult represents the result of an RSS feed sync operation
@@ -153,7 +488,55 @@ type RSSFeedSyncResult struct {
 	PodcastID      uuid.UUID `json:"podcast_id"`
 	EpisodesAdded  int       `json:"episodes_added"`
 	EpisodesUpdated int      `json:"episodes_updated"`
+	EpisodesRemoved int      `json:"episodes_removed"`
+	NotModified    bool      `json:"not_modified,omitempty"`
 	ErrorMessage   string    `json:"error_message,omitempty"`
+	Warnings       []string  `json:"warnings,omitempty"`
+}
+
+// Statuses of a full sync job
+const (
+	SyncJobRunning   = "running"
+	SyncJobCompleted = "completed"
+	SyncJobFailed    = "failed"
+)
+
+// SyncAllJob tracks a full sync started by an admin. Podcasts are counted as their syncs finish,
+// and a job that failed or was cut short by its timeout has an error.
+type SyncAllJob struct {
+	ID              uuid.UUID  `json:"id"`
+	Status          string     `json:"status"`
+	Force           bool       `json:"force"`
+	PodcastsTotal   int        `json:"podcasts_total"`
+	PodcastsSynced  int        `json:"podcasts_synced"`
+	PodcastsFailed  int        `json:"podcasts_failed"`
+	EpisodesAdded   int        `json:"episodes_added"`
+	EpisodesUpdated int        `json:"episodes_updated"`
+	EpisodesRemoved int        `json:"episodes_removed"`
+	Error           string     `json:"error,omitempty"`
+	StartedAt       time.Time  `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
+
+// SubscribeResponse is the outcome of a subscribe request, which succeeds whether or not the
+// listener was already subscribed
+type SubscribeResponse struct {
+	PodcastID         uuid.UUID `json:"podcast_id"`
+	AlreadySubscribed bool      `json:"already_subscribed"`
+}
+
+// OPMLImportResult summarizes an OPML subscription import
+type OPMLImportResult struct {
+	Imported int                 `json:"imported"`
+	Skipped  int                 `json:"skipped"`
+	Failed   int                 `json:"failed"`
+	Failures []OPMLImportFailure `json:"failures,omitempty"`
+}
+
+// OPMLImportFailure describes a feed that could not be imported
+type OPMLImportFailure struct {
+	RSSUrl string `json:"rss_url"`
+	Error  string `json:"error"`
 }
 
 // RSSFeedSyncLog represents a log entry for an RSS feed sync operation
@@ -163,38 +546,184 @@ type RSSFeedSyncLog struct {
 	Status          string    `json:"status" db:"status"`
 	EpisodesAdded   int       `json:"episodes_added" db:"episodes_added"`
 	EpisodesUpdated int       `json:"episodes_updated" db:"episodes_updated"`
+	EpisodesRemoved int       `json:"episodes_removed" db:"episodes_removed"`
 	ErrorMessage    string    `json:"error_message" db:"error_message"`
+	FailureReason   string    `json:"failure_reason,omitempty" db:"failure_reason"`
+	HTTPStatus      int       `json:"http_status,omitempty" db:"http_status"`
+	FailureMessage  string    `json:"failure_message,omitempty" db:"-"`
+	Warnings        string    `json:"warnings,omitempty" db:"warnings"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 }
 
+// Sync failure reasons, telling apart the ways a feed sync can fail
+const (
+	SyncFailureTimeout    = "timeout"        // the feed server didn't answer in time
+	SyncFailureHTTPError  = "http_error"     // the feed server couldn't be reached or answered with an error status
+	SyncFailureParseError = "parse_error"    // the feed was fetched but isn't a readable RSS document
+	SyncFailureEmptyFeed  = "empty_feed"     // the feed parsed but has no podcast content
+	SyncFailureNoRSSURL   = "no_rss_url"     // the podcast has no feed to sync
+	SyncFailureInternal   = "internal_error" // the feed was fine but saving it failed
+)
+
+// SyncFailureMessage describes a sync failure reason in words a podcaster can act on
+func SyncFailureMessage(reason string, httpStatus int) string {
+	switch reason {
+	case SyncFailureTimeout:
+		return "Your feed host took too long to respond. We'll retry automatically."
+	case SyncFailureHTTPError:
+		if httpStatus == 0 {
+			return "Your feed host couldn't be reached. Check that your feed URL is correct and online."
+		}
+		switch {
+		case httpStatus == 401 || httpStatus == 403:
+			return fmt.Sprintf("Your feed host refused access to the feed (HTTP %d). Make sure the feed is public.", httpStatus)
+		case httpStatus == 404:
+			return "Your feed URL was not found (HTTP 404). Update the RSS URL if your feed has moved."
+		case httpStatus == 410:
+			return "Your feed host reports the feed was removed (HTTP 410). Update the RSS URL to keep syncing."
+		case httpStatus >= 500:
+			return fmt.Sprintf("Your feed host had a server error (HTTP %d). We'll retry automatically.", httpStatus)
+		default:
+			return fmt.Sprintf("Your feed host answered with an error (HTTP %d). Check that your feed URL loads.", httpStatus)
+		}
+	case SyncFailureParseError:
+		return "Your feed could not be read as RSS. Validate it with your hosting provider."
+	case SyncFailureEmptyFeed:
+		return "Your feed has no podcast content. Make sure it has a title and episodes."
+	case SyncFailureNoRSSURL:
+		return "This podcast has no RSS URL to sync from. Add one in the podcast settings."
+	case SyncFailureInternal:
+		return "Something went wrong on our side while saving your feed. We'll retry automatically."
+	}
+	return ""
+}
+
 // Request/Response structures
 
 // CreatePodcastRequest represents a request to create a podcast
 type CreatePodcastRequest struct {
-	RSSUrl       string  `json:"rss_url" validate:"required,url"`
+	RSSUrl       string  `json:"rss_url" validate:"required"` // checked by rss.NormalizeFeedURL, which takes URLs without a scheme
 	Description  string  `json:"description"`
 	Category     string  `json:"category"`
 	Subcategory  string  `json:"subcategory"`
 }
 
+// DuplicateFeedResponse is the error response for a feed that is already in the catalog
+type DuplicateFeedResponse struct {
+	Status     int       `json:"status"`
+	Message    string    `json:"message"`
+	PodcastID  uuid.UUID `json:"podcast_id"`
+	PodcastURL string    `json:"podcast_url"`
+}
+
+// PreviewFeedRequest represents a request to preview the import of a feed
+type PreviewFeedRequest struct {
+	RSSUrl string `json:"rss_url" validate:"required"` // checked by rss.NormalizeFeedURL
+}
+
+// FeedPreview is what importing a feed would create, for podcasters to check their feed before
+// creating a podcast from it
+type FeedPreview struct {
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	Language       string        `json:"language"`
+	Author         string        `json:"author"`
+	CoverImageURL  string        `json:"cover_image_url"`
+	WebsiteURL     string        `json:"website_url"`
+	Category       string        `json:"category"`
+	Subcategory    string        `json:"subcategory"`
+	Explicit       bool          `json:"explicit"`
+	Keywords       Keywords      `json:"keywords,omitempty"`
+	EpisodeCount   int           `json:"episode_count"`
+	SampleEpisodes []RSSFeedItem `json:"sample_episodes"` // the first episodes in feed order
+	Warnings       []string      `json:"warnings,omitempty"`
+}
+
 // UpdatePodcastRequest represents a request to update a podcast
 type UpdatePodcastRequest struct {
 	Description  string  `json:"description"`
-	RSSUrl       string  `json:"rss_url" validate:"url"`
+	RSSUrl       string  `json:"rss_url"` // checked by rss.NormalizeFeedURL when set
 	Category     string  `json:"category"`
 	Subcategory  string  `json:"subcategory"`
+	PruneMissing *bool   `json:"prune_missing"`
+	SyncIntervalMinutes *int `json:"sync_interval_minutes" validate:"omitempty,min=0,max=10080"` // 0 resets to the default
 }
 
+// MinSyncIntervalMinutes is the shortest sync interval a podcast can override the default with
+const MinSyncIntervalMinutes = 15
+
 // SyncPodcastRequest represents a request to sync a podcast
 type SyncPodcastRequest struct {
 	PodcastID uuid.UUID `json:"podcast_id" validate:"required"`
 }
 
+// BulkDeleteEpisodesRequest represents a request to delete several episodes of a podcast at once.
+// Episodes are selected by ID, by publication cutoff, or by both.
+type BulkDeleteEpisodesRequest struct {
+	EpisodeIDs      []uuid.UUID `json:"episode_ids"`
+	PublishedBefore *time.Time  `json:"published_before"`
+}
+
+// BulkDeleteEpisodesResponse represents the result of a bulk episode deletion
+type BulkDeleteEpisodesResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// TakeDownEpisodeRequest represents a request to take an episode down
+type TakeDownEpisodeRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// ModerateCommentRequest represents an admin's request to change a comment's status
+type ModerateCommentRequest struct {
+	Status string `json:"status" validate:"required,oneof=active hidden flagged"`
+}
+
+// SuspendPodcastRequest represents a request to suspend a podcast
+type SuspendPodcastRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// PinEpisodeRequest represents a request to pin an episode to the top of its podcast page
+type PinEpisodeRequest struct {
+	EpisodeID uuid.UUID `json:"episode_id" validate:"required"`
+}
+
+// CreateShortLinkRequest represents a request to get or create the short link of an episode or podcast
+type CreateShortLinkRequest struct {
+	TargetType string    `json:"target_type" validate:"required,oneof=episode podcast"`
+	TargetID   uuid.UUID `json:"target_id" validate:"required"`
+}
+
+// CreateWebhookRequest represents a request to register a webhook for a podcast
+type CreateWebhookRequest struct {
+	URL    string   `json:"url" validate:"required,url,max=2048"`
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=episode.added sync.failed"`
+}
+
+// CreateWebhookResponse represents a newly registered webhook with the secret its payloads are signed with
+type CreateWebhookResponse struct {
+	Webhook
+	Secret string `json:"secret"`
+}
+
+// ShortLinkResponse represents a short link with its shareable URL
+type ShortLinkResponse struct {
+	ShortLink
+	URL string `json:"url"`
+}
+
 // PodcastResponse represents a podcast response with additional data
 type PodcastResponse struct {
 	Podcast
-	EpisodeCount   int               `json:"episode_count"`
-	LatestEpisodes []EpisodeResponse `json:"latest_episodes,omitempty"`
+	EpisodeCount     int               `json:"episode_count"`
+	SubscriberCount  int               `json:"subscriber_count"`
+	ListenCount      int               `json:"listen_count,omitempty"`       // exact count, hidden from the public when badges are enabled
+	ListenCountBadge string            `json:"listen_count_badge,omitempty"` // bucketed count, e.g. "1K+"
+	LatestEpisodes   []EpisodeResponse `json:"latest_episodes,omitempty"`
+	PinnedEpisode    *EpisodeResponse  `json:"pinned_episode,omitempty"`
+	AverageRating    float64           `json:"average_rating"`
+	ReviewCount      int               `json:"review_count"`
 }
 
 // EpisodeResponse represents an episode response with additional data
@@ -203,8 +732,70 @@ type EpisodeResponse struct {
 	PodcastTitle      string `json:"podcast_title"`
 	PodcastAuthor     string `json:"podcast_author"`
 	PodcastImageURL   string `json:"podcast_image_url"`
-	ListenCount       int    `json:"listen_count"`
-	AverageCompletion int    `json:"average_completion"` // percentage
+	ListenCount       int    `json:"listen_count,omitempty"`       // exact count, hidden from the public when badges are enabled
+	ListenCountBadge  string `json:"listen_count_badge,omitempty"` // bucketed count, e.g. "1K+"
+	AverageCompletion int    `json:"average_completion"`           // percentage
+}
+
+// Viewer identifies who a response is built for. The zero value is an anonymous viewer.
+type Viewer struct {
+	UserID  uuid.UUID
+	IsAdmin bool
+	
+	// HideExplicit overrides the viewer's hide_explicit preference when set, for clients that
+	// filter explicit content themselves
+	HideExplicit *bool
+}
+
+// OEmbedResponse represents an oEmbed "rich" response for an embeddable episode
+type OEmbedResponse struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// CommentModerationParams represents parameters for listing comments in the admin moderation views
+type CommentModerationParams struct {
+	Status    string    `form:"status"`
+	EpisodeID string    `form:"episode_id"`
+	UserID    string    `form:"user_id"`
+	From      time.Time `form:"from"`
+	To        time.Time `form:"to"`
+	Sort      string    `form:"sort"`  // created_at or updated_at
+	Order     string    `form:"order"` // asc or desc
+	Page      int       `form:"page,default=1"`
+	PageSize  int       `form:"page_size,default=20"`
+}
+
+// ReportListParams represents parameters for listing content reports for admins
+type ReportListParams struct {
+	Status     string    `form:"status"`
+	TargetType string    `form:"target_type"`
+	TargetID   string    `form:"target_id"`
+	From       time.Time `form:"from"`
+	To         time.Time `form:"to"`
+	Sort       string    `form:"sort"`  // created_at, reviewed_at or reason
+	Order      string    `form:"order"` // asc or desc
+	Page       int       `form:"page,default=1"`
+	PageSize   int       `form:"page_size,default=20"`
+}
+
+// CreateReportRequest represents a request to report a comment or podcast
+type CreateReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam harassment hate_speech sexual_content violence copyright other"`
+	Details string `json:"details" validate:"max=1000"`
+}
+
+// ResolveReportRequest represents an admin's decision on a report
+type ResolveReportRequest struct {
+	Status string `json:"status" validate:"required,oneof=reviewed dismissed"`
 }
 
 // CreateCommentRequest represents a request to create a comment
@@ -213,6 +804,17 @@ type CreateCommentRequest struct {
 	Content   string    `json:"content" validate:"required"`
 }
 
+// SaveReviewRequest represents a request to rate and review a podcast
+type SaveReviewRequest struct {
+	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
+	Content string `json:"content"`
+}
+
+// SetChaptersRequest represents a request to set the chapters of an episode by hand
+type SetChaptersRequest struct {
+	Chapters Chapters `json:"chapters"`
+}
+
 // CreatePlaylistRequest represents a request to create a playlist
 type CreatePlaylistRequest struct {
 	Name        string `json:"name" validate:"required"`
@@ -236,10 +838,55 @@ type AddToPlaylistRequest struct {
 // SavePlaybackPositionRequest represents a request to save playback position
 type SavePlaybackPositionRequest struct {
 	EpisodeID uuid.UUID `json:"episode_id" validate:"required"`
-	Position  int       `json:"position" validate:"required,min=0"`
+	Position  int       `json:"position" validate:"min=0"`
 	Completed bool      `json:"completed"`
 }
 
+// ContinueListeningItem is an episode a listener started but didn't finish
+type ContinueListeningItem struct {
+	EpisodeID     uuid.UUID `json:"episode_id" db:"episode_id"`
+	EpisodeTitle  string    `json:"episode_title" db:"episode_title"`
+	PodcastID     uuid.UUID `json:"podcast_id" db:"podcast_id"`
+	PodcastTitle  string    `json:"podcast_title" db:"podcast_title"`
+	CoverImageURL string    `json:"cover_image_url" db:"cover_image_url"`
+	Position      int       `json:"position" db:"position"` // in seconds
+	Duration      int       `json:"duration" db:"duration"` // in seconds
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Notification is an in-app notification for a listener, e.g. of a new episode of a podcast they subscribe to
+type Notification struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Type          string     `json:"type" db:"type"` // new_episode
+	PodcastID     uuid.UUID  `json:"podcast_id" db:"podcast_id"`
+	PodcastTitle  string     `json:"podcast_title" db:"podcast_title"`
+	EpisodeID     uuid.UUID  `json:"episode_id" db:"episode_id"`
+	EpisodeTitle  string     `json:"episode_title" db:"episode_title"`
+	CoverImageURL string     `json:"cover_image_url" db:"cover_image_url"`
+	ReadAt        *time.Time `json:"read_at" db:"read_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NotificationTypeNewEpisode is the type of notifications of an episode added by a feed sync
+const NotificationTypeNewEpisode = "new_episode"
+
+// PlaybackPosition is where a listener left off in an episode
+type PlaybackPosition struct {
+	EpisodeID uuid.UUID `json:"episode_id" db:"episode_id"`
+	Position  int       `json:"position" db:"position"`
+	Completed bool      `json:"completed" db:"completed"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// GetPlaybackPositionsRequest represents a request to get the playback positions of several episodes
+type GetPlaybackPositionsRequest struct {
+	EpisodeIDs []uuid.UUID `json:"episode_ids" validate:"required,min=1,max=100"`
+}
+
+// AllLanguages is the language filter value listing podcasts in every language, instead of
+// the signed in user's preferred language
+const AllLanguages = "all"
+
 // PodcastSearchParams represents parameters for searching podcasts
 type PodcastSearchParams struct {
 	Query      string    `form:"query"`
@@ -249,6 +896,7 @@ type PodcastSearchParams struct {
 	SortOrder  string    `form:"sort_order"`
 	Page       int       `form:"page,default=1"`
 	PageSize   int       `form:"page_size,default=20"`
+	HideExplicit bool    `form:"-"` // resolved from the viewer
 }
 
 // EpisodeSearchParams represents parameters for searching episodes
@@ -261,4 +909,40 @@ type EpisodeSearchParams struct {
 	SortOrder   string    `form:"sort_order"`
 	Page        int       `form:"page,default=1"`
 	PageSize    int       `form:"page_size,default=20"`
+	HideExplicit bool     `form:"-"` // resolved from the viewer
+}
+
+// Types of search results
+const (
+	SearchResultPodcast = "podcast"
+	SearchResultEpisode = "episode"
+)
+
+// SearchParams represents parameters for searching podcasts and episodes together. The
+// category and language filters apply to episodes through their podcast.
+type SearchParams struct {
+	Query        string
+	Type         string // podcast or episode; both when empty
+	Category     string
+	Language     string
+	Explicit     *bool // only explicit or only clean results when set
+	Page         int
+	PageSize     int
+	HideExplicit bool // resolved from the viewer
+}
+
+// SearchResult is a podcast or episode matching a search, ranked by how well it matches
+type SearchResult struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	Type            string     `json:"type" db:"type"` // podcast or episode
+	Title           string     `json:"title" db:"title"`
+	Description     string     `json:"description" db:"description"`
+	ImageURL        string     `json:"image_url" db:"image_url"`
+	PodcastID       *uuid.UUID `json:"podcast_id,omitempty" db:"podcast_id"` // the episode's podcast
+	PodcastTitle    string     `json:"podcast_title,omitempty" db:"podcast_title"`
+	Language        string     `json:"language" db:"language"`
+	Explicit        bool       `json:"explicit" db:"explicit"`
+	PublicationDate *time.Time `json:"publication_date,omitempty" db:"publication_date"`
+	Score           float64    `json:"score" db:"score"`
+	Category        string     `json:"-" db:"category"` // for the default cover
 }
\ No newline at end of file
@@ -0,0 +1,94 @@
+// pkg/content/moderation/filter.go
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Filter actions, deciding what happens to content a filter matched
+const (
+	ActionReject = "reject" // refuse the content
+	ActionFlag   = "flag"   // keep the content out of sight until a moderator reviews it
+)
+
+// Filter inspects user submitted text such as comments
+type Filter interface {
+	// Check returns the reason the text was matched, or an empty string if it is acceptable
+	Check(text string) string
+}
+
+// linkPattern matches the start of a URL
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)`)
+
+type wordListFilter struct {
+	words map[string]bool
+}
+
+// NewWordListFilter creates a filter matching text that contains any of the words.
+// Words are matched whole and case-insensitively, so "class" does not match "ass".
+func NewWordListFilter(words []string) Filter {
+	filter := &wordListFilter{words: make(map[string]bool, len(words))}
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			filter.words[word] = true
+		}
+	}
+	return filter
+}
+
+// Check matches text containing a listed word
+func (f *wordListFilter) Check(text string) string {
+	if len(f.words) == 0 {
+		return ""
+	}
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, token := range tokens {
+		if f.words[token] {
+			return "contains blocked words"
+		}
+	}
+	return ""
+}
+
+type linkFilter struct {
+	maxLinks int
+}
+
+// NewLinkFilter creates a filter matching text with more than maxLinks links,
+// a common trait of spam. A negative maxLinks disables the filter.
+func NewLinkFilter(maxLinks int) Filter {
+	return &linkFilter{maxLinks: maxLinks}
+}
+
+// Check matches text with too many links
+func (f *linkFilter) Check(text string) string {
+	if f.maxLinks < 0 {
+		return ""
+	}
+	if links := len(linkPattern.FindAllStringIndex(text, -1)); links > f.maxLinks {
+		return fmt.Sprintf("contains %d links, at most %d allowed", links, f.maxLinks)
+	}
+	return ""
+}
+
+type chain []Filter
+
+// Chain combines filters, matching text any of them matches
+func Chain(filters ...Filter) Filter {
+	return chain(filters)
+}
+
+// Check returns the reason of the first filter matching the text
+func (c chain) Check(text string) string {
+	for _, filter := range c {
+		if reason := filter.Check(text); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
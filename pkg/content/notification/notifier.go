@@ -0,0 +1,41 @@
+// pkg/content/notification/notifier.go
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/repository/postgres"
+)
+
+// Notifier delivers notifications to listeners. Notifications are only stored for now, to be
+// listed in the app; push and email delivery can be added as notifiers of their own.
+type Notifier interface {
+	// NotifyNewEpisodes notifies the subscribers of a podcast of episodes added by a feed sync
+	NotifyNewEpisodes(ctx context.Context, podcast *models.Podcast, episodes []*models.Episode) error
+}
+
+type storeNotifier struct {
+	repo postgres.Repository
+}
+
+// NewStoreNotifier creates a notifier that saves notifications for listeners to read in the app
+func NewStoreNotifier(repo postgres.Repository) Notifier {
+	return &storeNotifier{repo: repo}
+}
+
+// NotifyNewEpisodes saves a notification of each episode for every subscriber of the podcast
+func (n *storeNotifier) NotifyNewEpisodes(ctx context.Context, podcast *models.Podcast, episodes []*models.Episode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	episodeIDs := make([]uuid.UUID, len(episodes))
+	for i, episode := range episodes {
+		episodeIDs[i] = episode.ID
+	}
+
+	_, err := n.repo.CreateNewEpisodeNotifications(ctx, podcast.ID, episodeIDs)
+	return err
+}
@@ -4,13 +4,18 @@ package postgres
 import (
 	"context"
 	"database/sql"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	auditModels "github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	auditRepo "github.com/MHK-26/pod_platfrom_go/pkg/audit/repository/postgres"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/pagination"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
 )
 
@@ -22,31 +27,52 @@ type Repository interface {
 	GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.Podcast, error)
 	GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, page, pageSize int) ([]*models.Podcast, int, error)
 	UpdatePodcast(ctx context.Context, podcast *models.Podcast) error
+	UpdatePodcastCoverImage(ctx context.Context, id uuid.UUID, imageURL, imagePath string) error
 	DeletePodcast(ctx context.Context, id uuid.UUID) error
+	SuspendPodcast(ctx context.Context, podcastID uuid.UUID, auditEntry *auditModels.AuditEntry) error
+	UnsuspendPodcast(ctx context.Context, podcastID uuid.UUID, auditEntry *auditModels.AuditEntry) error
 	ListPodcasts(ctx context.Context, params models.PodcastSearchParams) ([]*models.Podcast, int, error)
 	GetActivePodcasts(ctx context.Context) ([]*models.Podcast, error)
 	GetPodcastByRSSURL(ctx context.Context, rssURL string) (*models.Podcast, error)
+	GetPodcastsDueForSyncRetry(ctx context.Context, now time.Time) ([]*models.Podcast, error)
+	GetSyncSuspendedPodcasts(ctx context.Context, page, pageSize int) ([]*models.Podcast, int, error)
 	IsUserAuthorizedForPodcast(ctx context.Context, podcastID, userID uuid.UUID) (bool, error)
+	SetPinnedEpisode(ctx context.Context, podcastID uuid.UUID, episodeID *uuid.UUID) error
+	UpdatePodcastRSSURL(ctx context.Context, podcastID uuid.UUID, rssURL string) error
 	
 	// Episode methods
 	CreateEpisode(ctx context.Context, episode *models.Episode) error
 	GetEpisodeByID(ctx context.Context, id uuid.UUID) (*models.Episode, error)
-	GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.Episode, int, error)
+	GetEpisodesByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Episode, error)
+	GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, episodeType string, hideExplicit bool, page, pageSize int) ([]*models.Episode, int, error)
+	GetEpisodesByPodcastIDAfter(ctx context.Context, podcastID uuid.UUID, episodeType string, hideExplicit bool, after *pagination.Cursor, limit int) ([]*models.Episode, error)
 	GetAllEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID) ([]*models.Episode, error)
 	UpdateEpisode(ctx context.Context, episode *models.Episode) error
 	DeleteEpisode(ctx context.Context, id uuid.UUID) error
+	ArchiveEpisodes(ctx context.Context, podcastID uuid.UUID, episodeIDs []uuid.UUID, publishedBefore *time.Time, auditEntry *auditModels.AuditEntry) (int, error)
+	TakeDownEpisode(ctx context.Context, takedown *models.EpisodeTakedown, auditEntry *auditModels.AuditEntry) error
 	ListEpisodes(ctx context.Context, params models.EpisodeSearchParams) ([]*models.Episode, int, error)
+	Search(ctx context.Context, params models.SearchParams) ([]*models.SearchResult, int, error)
 	
 	// Transaction methods for feed sync
 	UpdatePodcastTx(ctx context.Context, tx *sqlx.Tx, podcast *models.Podcast) error
 	GetAllEpisodesByPodcastIDTx(ctx context.Context, tx *sqlx.Tx, podcastID uuid.UUID) ([]*models.Episode, error)
 	CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *models.Episode) error
 	UpdateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *models.Episode) error
+	SetEpisodeChapters(ctx context.Context, episodeID uuid.UUID, chapters models.Chapters) error
+	UpdateFeedChapters(ctx context.Context, episodeID uuid.UUID, chapters models.Chapters) error
+	ReplaceTranscriptSegments(ctx context.Context, episodeID uuid.UUID, segments []models.TranscriptSegment) error
+	SearchTranscript(ctx context.Context, episodeID uuid.UUID, query string, page, pageSize int) ([]*models.TranscriptMatch, int, error)
+	MarkEpisodesRemovedTx(ctx context.Context, tx *sqlx.Tx, episodeIDs []uuid.UUID) (int, error)
 	
 	// RSS sync log methods
 	CreateSyncLog(ctx context.Context, log *models.RSSFeedSyncLog) error
 	GetLatestSyncLog(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error)
 	GetSyncLogs(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.RSSFeedSyncLog, int, error)
+	RecordSyncFailure(ctx context.Context, podcastID uuid.UUID, maxFailures int) (int, bool, error)
+	ScheduleSyncRetry(ctx context.Context, podcastID uuid.UUID, retryAt time.Time) error
+	ResetSyncFailures(ctx context.Context, podcastID uuid.UUID) error
+	UpdatePodcastSyncedAt(ctx context.Context, podcastID uuid.UUID, syncedAt time.Time) error
 	
 	// Category methods
 	GetCategories(ctx context.Context) ([]*models.Category, error)
@@ -54,7 +80,7 @@ type Repository interface {
 	GetCategoriesByPodcastID(ctx context.Context, podcastID uuid.UUID) ([]*models.Category, error)
 	
 	// Subscription methods
-	SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error
+	SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error)
 	UnsubscribeFromPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error
 	GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.Podcast, int, error)
 	IsSubscribed(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error)
@@ -62,7 +88,11 @@ type Repository interface {
 	// Playback history methods
 	SavePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID, position int, completed bool) error
 	GetPlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) (int, bool, error)
+	GetPlaybackPositions(ctx context.Context, listenerID uuid.UUID, episodeIDs []uuid.UUID) ([]*models.PlaybackPosition, error)
+	MarkEpisodePlayed(ctx context.Context, listenerID, episodeID uuid.UUID, duration int) error
+	DeletePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) error
 	GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PlaybackHistory, int, error)
+	GetContinueListening(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ContinueListeningItem, int, error)
 	
 	// Like methods
 	LikeEpisode(ctx context.Context, listenerID, episodeID uuid.UUID) error
@@ -72,8 +102,27 @@ type Repository interface {
 	
 	// Comments methods
 	AddComment(ctx context.Context, comment *models.Comment) error
+	ListCommentsForModeration(ctx context.Context, params models.CommentModerationParams) ([]*models.Comment, int, error)
+	GetCommentCountsByStatus(ctx context.Context) (map[string]int, error)
 	GetCommentsByEpisodeID(ctx context.Context, episodeID uuid.UUID, page, pageSize int) ([]*models.Comment, int, error)
 	DeleteComment(ctx context.Context, commentID, userID uuid.UUID) error
+	GetCommentByID(ctx context.Context, id uuid.UUID) (*models.Comment, error)
+	SetCommentStatus(ctx context.Context, commentID uuid.UUID, status string, auditEntry *auditModels.AuditEntry) error
+	RemoveComment(ctx context.Context, commentID uuid.UUID, auditEntry *auditModels.AuditEntry) error
+	
+	// Report methods
+	CreateReport(ctx context.Context, report *models.ContentReport) (bool, error)
+	ListReports(ctx context.Context, params models.ReportListParams) ([]*models.ContentReport, int, error)
+	GetReportCountsByStatus(ctx context.Context) (map[string]int, error)
+	ResolveReport(ctx context.Context, reportID uuid.UUID, status string, reviewerID uuid.UUID, auditEntry *auditModels.AuditEntry) error
+	
+	// Review methods
+	SaveReview(ctx context.Context, review *models.PodcastReview) error
+	GetReviewsByPodcastID(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.PodcastReview, int, error)
+	DeleteReview(ctx context.Context, podcastID, userID uuid.UUID) error
+	GetPodcastRatings(ctx context.Context, podcastIDs []uuid.UUID) (map[uuid.UUID]models.PodcastRating, error)
+	GetEpisodeListenStats(ctx context.Context, episodeIDs []uuid.UUID) (map[uuid.UUID]models.EpisodeListenStats, error)
+	RefreshPodcastListenCounts(ctx context.Context) (int, error)
 	
 	// Playlist methods
 	CreatePlaylist(ctx context.Context, playlist *models.Playlist) error
@@ -84,14 +133,40 @@ type Repository interface {
 	AddToPlaylist(ctx context.Context, playlistID, episodeID uuid.UUID, position int) error
 	RemoveFromPlaylist(ctx context.Context, playlistID, episodeID uuid.UUID) error
 	GetPlaylistItems(ctx context.Context, playlistID uuid.UUID, page, pageSize int) ([]*models.PlaylistItem, int, error)
+	
+	// Short link methods
+	CreateShortLink(ctx context.Context, link *models.ShortLink) (bool, error)
+	GetShortLinkByCode(ctx context.Context, code string) (*models.ShortLink, error)
+	GetShortLinkByTarget(ctx context.Context, targetType string, targetID uuid.UUID) (*models.ShortLink, error)
+	RecordShortLinkClick(ctx context.Context, click *models.ShortLinkClick) error
+	
+	// User preference methods
+	GetHideExplicit(ctx context.Context, userID uuid.UUID) (bool, error)
+	GetPreferredLanguage(ctx context.Context, userID uuid.UUID) (string, error)
+	
+	// Notification methods
+	CreateNewEpisodeNotifications(ctx context.Context, podcastID uuid.UUID, episodeIDs []uuid.UUID) (int, error)
+	GetNotifications(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Notification, int, error)
+	MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error
+	
+	// Webhook methods
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
+	GetWebhooksByPodcastID(ctx context.Context, podcastID uuid.UUID) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+	CreateWebhookDeliveries(ctx context.Context, podcastID uuid.UUID, event string, payload []byte) (int, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, page, pageSize int) ([]*models.WebhookDelivery, int, error)
 }
 type repository struct {
-	db *sqlx.DB
+	db    *sqlx.DB
+	audit auditRepo.Repository
 }
 
 // NewRepository creates a new content repository
 func NewRepository(db *sqlx.DB) Repository {
-	return &repository{db: db}
+	return &repository{db: db, audit: auditRepo.NewRepository(db)}
 }
 
 // CreatePodcast creates a new podcast
@@ -99,9 +174,10 @@ func (r *repository) CreatePodcast(ctx context.Context, podcast *models.Podcast)
 	query := `
 		INSERT INTO podcasts (
 			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
-			language, author, category, subcategory, explicit, status, created_at, updated_at
+			language, author, category, subcategory, explicit, status, created_at, updated_at,
+			feed_cover_image_url, keywords
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17
 		) RETURNING id
 	`
 
@@ -109,7 +185,7 @@ func (r *repository) CreatePodcast(ctx context.Context, podcast *models.Podcast)
 		podcast.ID = uuid.New()
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	podcast.CreatedAt = now
 	podcast.UpdatedAt = now
 
@@ -131,6 +207,8 @@ func (r *repository) CreatePodcast(ctx context.Context, podcast *models.Podcast)
 		podcast.Status,
 		podcast.CreatedAt,
 		podcast.UpdatedAt,
+		podcast.FeedCoverImageURL,
+		podcast.Keywords,
 	).Scan(&podcast.ID)
 
 	return err
@@ -143,7 +221,11 @@ func (r *repository) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.
 		SELECT
 			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
 			language, author, category, subcategory, explicit, status, created_at, updated_at,
-			last_synced_at
+			last_synced_at, sync_failure_count, next_sync_retry_at, sync_suspended,
+			pinned_episode_id, prune_missing, feed_etag, feed_last_modified,
+			cover_image_path, cover_image_user_set, feed_cover_image_url, keywords, sync_interval_minutes, listen_count,
+			(SELECT COUNT(*) FROM episodes e WHERE e.podcast_id = podcasts.id AND e.status = 'active') AS episode_count,
+			(SELECT COUNT(*) FROM subscriptions s WHERE s.podcast_id = podcasts.id) AS subscriber_count
 		FROM podcasts
 		WHERE id = $1
 	`
@@ -151,21 +233,11 @@ func (r *repository) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.
 	err := r.db.GetContext(ctx, &podcast, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, errors.New("podcast not found")
+			return nil, models.ErrPodcastNotFound
 		}
 		return nil, err
 	}
 
-	// Get episode count
-	countQuery := `SELECT COUNT(*) FROM episodes WHERE podcast_id = $1 AND status = 'active'`
-	var count int
-	err = r.db.GetContext(ctx, &count, countQuery, id)
-	if err != nil {
-		return nil, err
-	}
-	
-	podcast.EpisodeCount = count
-
 	// Get categories
 	categories, err := r.GetCategoriesByPodcastID(ctx, id)
 	if err != nil {
@@ -176,6 +248,260 @@ func (r *repository) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.
 	return &podcast, nil
 }
 
+// GetPodcastsByPodcasterID gets a podcaster's podcasts, newest first. Deleted podcasts are left out.
+func (r *repository) GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, page, pageSize int) ([]*models.Podcast, int, error) {
+	// Get total count
+	countQuery := `SELECT COUNT(*) FROM podcasts WHERE podcaster_id = $1 AND status != 'deleted'`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, podcasterID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get podcasts with pagination
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT
+			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
+			language, author, category, subcategory, explicit, status, created_at, updated_at,
+			last_synced_at, sync_failure_count, next_sync_retry_at, sync_suspended,
+			pinned_episode_id, prune_missing, feed_etag, feed_last_modified,
+			cover_image_path, cover_image_user_set, feed_cover_image_url, keywords, sync_interval_minutes, listen_count,
+			(SELECT COUNT(*) FROM episodes e WHERE e.podcast_id = podcasts.id AND e.status = 'active') AS episode_count,
+			(SELECT COUNT(*) FROM subscriptions s WHERE s.podcast_id = podcasts.id) AS subscriber_count
+		FROM podcasts
+		WHERE podcaster_id = $1 AND status != 'deleted'
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	podcasts := []*models.Podcast{}
+	err = r.db.SelectContext(ctx, &podcasts, query, podcasterID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return podcasts, totalCount, nil
+}
+
+// UpdatePodcast updates a podcast, including the settings podcasters choose such as prune_missing
+func (r *repository) UpdatePodcast(ctx context.Context, podcast *models.Podcast) error {
+	return updatePodcast(ctx, r.db, podcast)
+}
+
+// UpdatePodcastCoverImage sets an uploaded cover on a podcast and marks it as set by the podcaster
+func (r *repository) UpdatePodcastCoverImage(ctx context.Context, id uuid.UUID, imageURL, imagePath string) error {
+	query := `
+		UPDATE podcasts
+		SET cover_image_url = $2, cover_image_path = $3, cover_image_user_set = TRUE, updated_at = $4
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, id, imageURL, imagePath, time.Now().UTC())
+	return err
+}
+
+// DeletePodcast marks a podcast as deleted, hiding it and its episodes like a deleted account's
+// podcasts. The rows are kept for listening history and the audit trail.
+func (r *repository) DeletePodcast(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE podcasts SET status = 'deleted', updated_at = $2 WHERE id = $1 AND status != 'deleted'`
+
+	result, err := r.db.ExecContext(ctx, query, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrPodcastNotFound
+	}
+
+	return nil
+}
+
+// podcastSortColumns whitelists the columns podcasts can be sorted by in listings
+var podcastSortColumns = map[string]string{
+	"created_at": "p.created_at",
+	"title":      "p.title",
+	"listens":    "p.listen_count",
+}
+
+// ListPodcasts lists active podcasts with optional filtering. A query is matched against the
+// title, author and description search vector and, unless another sort is requested, results
+// are ranked by relevance; otherwise the newest podcasts come first.
+func (r *repository) ListPodcasts(ctx context.Context, params models.PodcastSearchParams) ([]*models.Podcast, int, error) {
+	conditions := []string{"p.status = 'active'"}
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	// A query also matches podcasts tagged with it as a keyword
+	tsQuery := prefixTSQuery(params.Query)
+	queryArg := 0
+	if tsQuery != "" {
+		args = append(args, tsQuery, searchKeyword(params.Query))
+		queryArg = len(args) - 1
+		conditions = append(conditions, fmt.Sprintf("(p.search_vector @@ to_tsquery('%s', $%d) OR p.keywords @> ARRAY[$%d::text])", searchConfig(params.Query), queryArg, len(args)))
+	}
+	if params.Category != "" {
+		addCondition("p.id IN (SELECT podcast_id FROM podcast_categories WHERE category_id = $%d)", params.Category)
+	}
+	if params.Language != "" {
+		addCondition(languageCondition, strings.ToLower(params.Language))
+	}
+	if params.HideExplicit {
+		conditions = append(conditions, "NOT p.explicit")
+	}
+
+	orderBy := "p.created_at DESC"
+	switch {
+	case params.SortBy == "relevance" || (params.SortBy == "" && tsQuery != ""):
+		if tsQuery == "" {
+			return nil, 0, models.ErrInvalidSortField
+		}
+		orderBy = fmt.Sprintf("ts_rank(p.search_vector, to_tsquery('%s', $%d)) DESC, p.created_at DESC", searchConfig(params.Query), queryArg)
+	case params.SortBy != "":
+		sortColumn, ok := podcastSortColumns[params.SortBy]
+		if !ok {
+			return nil, 0, models.ErrInvalidSortField
+		}
+		order := "DESC"
+		if strings.EqualFold(params.SortOrder, "asc") {
+			order = "ASC"
+		}
+		orderBy = sortColumn + " " + order
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM podcasts p " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get podcasts with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT
+			p.id, p.podcaster_id, p.title, p.description, p.cover_image_url, p.rss_url, p.website_url,
+			p.language, p.author, p.category, p.subcategory, p.explicit, p.status, p.created_at, p.updated_at,
+			p.last_synced_at, p.sync_failure_count, p.next_sync_retry_at, p.sync_suspended,
+			p.pinned_episode_id, p.prune_missing, p.feed_etag, p.feed_last_modified,
+			p.cover_image_path, p.cover_image_user_set, p.feed_cover_image_url, p.keywords, p.sync_interval_minutes, p.listen_count,
+			(SELECT COUNT(*) FROM episodes e WHERE e.podcast_id = p.id AND e.status = 'active') as episode_count,
+			(SELECT COUNT(*) FROM subscriptions s WHERE s.podcast_id = p.id) as subscriber_count
+		FROM podcasts p
+		%s
+		ORDER BY %s, p.id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, orderBy, len(args)+1, len(args)+2)
+
+	var podcasts []*models.Podcast
+	err = r.db.SelectContext(ctx, &podcasts, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return podcasts, totalCount, nil
+}
+
+// languageCondition matches podcasts in a language or any of its regional varieties, so "ar"
+// matches "ar-sd" podcasts while "ar-sd" matches only those. The language is compared as a
+// prefix rather than with LIKE, so % and _ in it have no special meaning.
+const languageCondition = "(lower(p.language) = $%[1]d OR starts_with(lower(p.language), $%[1]d || '-'))"
+
+// searchConfig returns the text search configuration to parse a query with. Search vectors
+// hold both English and Arabic lexemes, and queries in Arabic script are stemmed as Arabic.
+func searchConfig(query string) string {
+	for _, r := range query {
+		if unicode.Is(unicode.Arabic, r) {
+			return "arabic"
+		}
+	}
+	return "english"
+}
+
+// searchKeyword normalizes a search box query the way feed keywords are, to match it against them
+func searchKeyword(query string) string {
+	return strings.ToLower(strings.Join(strings.Fields(query), " "))
+}
+
+// prefixTSQuery turns a search box query into a tsquery matching every word as a prefix,
+// so partially typed words still match, e.g. "tech pod" becomes "tech:* & pod:*". Characters
+// other than letters and digits separate words, which also keeps tsquery operators out.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// SavePlaybackPosition records where a listener left off in an episode
+func (r *repository) SavePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID, position int, completed bool) error {
+	query := `
+		INSERT INTO playback_history (listener_id, episode_id, position, completed, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (listener_id, episode_id) DO UPDATE
+		SET position = EXCLUDED.position, completed = EXCLUDED.completed, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, listenerID, episodeID, position, completed, time.Now().UTC())
+	return err
+}
+
+// GetPlaybackPosition gets where a listener left off in an episode
+func (r *repository) GetPlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) (int, bool, error) {
+	query := `
+		SELECT position, completed
+		FROM playback_history
+		WHERE listener_id = $1 AND episode_id = $2
+	`
+
+	var playback models.PlaybackPosition
+	err := r.db.GetContext(ctx, &playback, query, listenerID, episodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, models.ErrPlaybackPositionNotFound
+		}
+		return 0, false, err
+	}
+
+	return playback.Position, playback.Completed, nil
+}
+
+// GetPlaybackPositions gets where a listener left off in each of the given episodes.
+// Episodes the listener hasn't played are left out.
+func (r *repository) GetPlaybackPositions(ctx context.Context, listenerID uuid.UUID, episodeIDs []uuid.UUID) ([]*models.PlaybackPosition, error) {
+	query := `
+		SELECT episode_id, position, completed, updated_at
+		FROM playback_history
+		WHERE listener_id = $1 AND episode_id = ANY($2)
+		ORDER BY updated_at DESC
+	`
+
+	positions := []*models.PlaybackPosition{}
+	err := r.db.SelectContext(ctx, &positions, query, listenerID, pq.Array(episodeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
 // GetListeningHistory gets the listening history for a user
 func (r *repository) GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PlaybackHistory, int, error) {
 	query := `
@@ -211,6 +537,66 @@ func (r *repository) GetListeningHistory(ctx context.Context, listenerID uuid.UU
 	return history, totalCount, nil
 }
 
+// MarkEpisodePlayed records an episode as fully played by a listener, with the position at its end
+func (r *repository) MarkEpisodePlayed(ctx context.Context, listenerID, episodeID uuid.UUID, duration int) error {
+	query := `
+		INSERT INTO playback_history (listener_id, episode_id, position, completed, created_at, updated_at)
+		VALUES ($1, $2, $3, true, $4, $4)
+		ON CONFLICT (listener_id, episode_id) DO UPDATE
+		SET position = EXCLUDED.position, completed = true, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, listenerID, episodeID, duration, time.Now().UTC())
+	return err
+}
+
+// DeletePlaybackPosition forgets a listener's progress in an episode. Forgetting progress
+// that was never recorded is not an error.
+func (r *repository) DeletePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) error {
+	query := `DELETE FROM playback_history WHERE listener_id = $1 AND episode_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, listenerID, episodeID)
+	return err
+}
+
+// GetContinueListening gets the episodes a listener started but didn't finish, most recently played first
+func (r *repository) GetContinueListening(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ContinueListeningItem, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM playback_history ph
+		JOIN episodes e ON ph.episode_id = e.id
+		WHERE ph.listener_id = $1 AND ph.completed = false AND ph.position > 0 AND e.status = 'active'
+	`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, listenerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT
+			ph.episode_id, e.title as episode_title, e.podcast_id, p.title as podcast_title,
+			COALESCE(e.cover_image_url, p.cover_image_url) as cover_image_url,
+			ph.position, COALESCE(e.duration, 0) as duration, ph.updated_at
+		FROM playback_history ph
+		JOIN episodes e ON ph.episode_id = e.id
+		JOIN podcasts p ON e.podcast_id = p.id
+		WHERE ph.listener_id = $1 AND ph.completed = false AND ph.position > 0 AND e.status = 'active'
+		ORDER BY ph.updated_at DESC, ph.episode_id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	items := []*models.ContinueListeningItem{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &items, query, listenerID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, totalCount, nil
+}
+
 // LikeEpisode adds a like to an episode
 func (r *repository) LikeEpisode(ctx context.Context, listenerID, episodeID uuid.UUID) error {
 	query := `
@@ -249,7 +635,7 @@ func (r *repository) GetLikedEpisodes(ctx context.Context, listenerID uuid.UUID,
 	query := `
 		SELECT e.id, e.podcast_id, e.title, e.description, e.audio_url, e.duration, 
 			e.cover_image_url, e.publication_date, e.guid, e.episode_number, e.season_number, 
-			e.transcript, e.status, e.created_at, e.updated_at
+			e.episode_type, e.transcript, e.transcripts, e.chapters, e.chapters_url, e.chapters_user_set, e.keywords, e.alternate_enclosures, e.file_size, e.mime_type, e.explicit, e.status, e.created_at, e.updated_at
 		FROM episodes e
 		JOIN likes l ON e.id = l.episode_id
 		WHERE l.listener_id = $1 AND e.status = 'active'
@@ -285,9 +671,9 @@ func (r *repository) GetLikedEpisodes(ctx context.Context, listenerID uuid.UUID,
 func (r *repository) AddComment(ctx context.Context, comment *models.Comment) error {
 	query := `
 		INSERT INTO comments (
-			id, user_id, episode_id, content, status, created_at, updated_at
+			id, user_id, episode_id, content, status, flag_reason, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7
+			$1, $2, $3, $4, $5, $6, $7, $8
 		) RETURNING id
 	`
 
@@ -295,7 +681,7 @@ func (r *repository) AddComment(ctx context.Context, comment *models.Comment) er
 		comment.ID = uuid.New()
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	comment.CreatedAt = now
 	comment.UpdatedAt = now
 
@@ -311,6 +697,7 @@ func (r *repository) AddComment(ctx context.Context, comment *models.Comment) er
 		comment.EpisodeID,
 		comment.Content,
 		comment.Status,
+		comment.FlagReason,
 		comment.CreatedAt,
 		comment.UpdatedAt,
 	).Scan(&comment.ID)
@@ -323,9 +710,10 @@ func (r *repository) GetCommentsByEpisodeID(ctx context.Context, episodeID uuid.
 	query := `
 		SELECT 
 			c.id, c.user_id, c.episode_id, c.content, c.status, c.created_at, c.updated_at,
-			u.username, u.full_name, u.profile_image_url as user_profile_url
+			COALESCE(u.username, '') as username, COALESCE(u.full_name, '') as user_full_name,
+			COALESCE(u.profile_image_url, '') as user_profile_url
 		FROM comments c
-		JOIN users u ON c.user_id = u.id
+		LEFT JOIN users u ON c.user_id = u.id -- comments of deleted accounts have no author
 		WHERE c.episode_id = $1 AND c.status = 'active'
 		ORDER BY c.created_at DESC
 		LIMIT $2 OFFSET $3
@@ -354,6 +742,107 @@ func (r *repository) GetCommentsByEpisodeID(ctx context.Context, episodeID uuid.
 	return comments, totalCount, nil
 }
 
+// commentSortColumns whitelists the columns comments can be sorted by in the moderation views
+var commentSortColumns = map[string]string{
+	"created_at": "c.created_at",
+	"updated_at": "c.updated_at",
+}
+
+// ListCommentsForModeration lists comments of any status matching the given filters
+func (r *repository) ListCommentsForModeration(ctx context.Context, params models.CommentModerationParams) ([]*models.Comment, int, error) {
+	sortColumn, ok := commentSortColumns[params.Sort]
+	if params.Sort == "" {
+		sortColumn, ok = commentSortColumns["created_at"], true
+	}
+	if !ok {
+		return nil, 0, models.ErrInvalidSortField
+	}
+	order := "ASC"
+	if strings.EqualFold(params.Order, "desc") {
+		order = "DESC"
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if params.Status != "" {
+		addCondition("c.status = $%d", params.Status)
+	}
+	if params.EpisodeID != "" {
+		addCondition("c.episode_id = $%d", params.EpisodeID)
+	}
+	if params.UserID != "" {
+		addCondition("c.user_id = $%d", params.UserID)
+	}
+	if !params.From.IsZero() {
+		addCondition("c.created_at >= $%d", params.From)
+	}
+	if !params.To.IsZero() {
+		addCondition("c.created_at < $%d", params.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM comments c " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get comments with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT 
+			c.id, c.user_id, c.episode_id, c.content, c.status, c.flag_reason, c.created_at, c.updated_at,
+			COALESCE(u.username, '') as username, COALESCE(u.full_name, '') as user_full_name,
+			COALESCE(u.profile_image_url, '') as user_profile_url
+		FROM comments c
+		LEFT JOIN users u ON c.user_id = u.id
+		%s
+		ORDER BY %s %s
+		LIMIT $%d OFFSET $%d
+	`, whereClause, sortColumn, order, len(args)+1, len(args)+2)
+
+	var comments []*models.Comment
+	err = r.db.SelectContext(ctx, &comments, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, totalCount, nil
+}
+
+// GetCommentCountsByStatus counts the comments of each status
+func (r *repository) GetCommentCountsByStatus(ctx context.Context) (map[string]int, error) {
+	query := `SELECT status, COUNT(*) as count FROM comments GROUP BY status`
+
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{"active": 0, "hidden": 0, "flagged": 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
 // DeleteComment deletes a comment
 func (r *repository) DeleteComment(ctx context.Context, commentID, userID uuid.UUID) error {
 	// First check if user owns the comment
@@ -362,14 +851,14 @@ func (r *repository) DeleteComment(ctx context.Context, commentID, userID uuid.U
 	err := r.db.GetContext(ctx, &commentUserID, checkQuery, commentID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("comment not found")
+			return models.ErrCommentNotFound
 		}
 		return err
 	}
 
 	// Only allow deletion if the user is the comment author
 	if commentUserID != userID {
-		return errors.New("not authorized to delete this comment")
+		return models.ErrCommentDeleteNotAuthorized
 	}
 
 	// Delete the comment
@@ -378,76 +867,248 @@ func (r *repository) DeleteComment(ctx context.Context, commentID, userID uuid.U
 	return err
 }
 
-// CreatePlaylist creates a new playlist
-func (r *repository) CreatePlaylist(ctx context.Context, playlist *models.Playlist) error {
+// SaveReview creates a user's review of a podcast, or replaces the one they already wrote
+func (r *repository) SaveReview(ctx context.Context, review *models.PodcastReview) error {
 	query := `
-		INSERT INTO playlists (
-			id, user_id, name, description, is_public, created_at, updated_at
+		INSERT INTO podcast_reviews (
+			id, podcast_id, user_id, rating, content, status, flag_reason, created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7
-		) RETURNING id
+			$1, $2, $3, $4, $5, $6, $7, $8, $8
+		)
+		ON CONFLICT (podcast_id, user_id) DO UPDATE
+		SET rating = EXCLUDED.rating, content = EXCLUDED.content, status = EXCLUDED.status,
+			flag_reason = EXCLUDED.flag_reason, updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
 	`
 
-	if playlist.ID == uuid.Nil {
-		playlist.ID = uuid.New()
+	if review.ID == uuid.Nil {
+		review.ID = uuid.New()
 	}
 
-	now := time.Now()
-	playlist.CreatedAt = now
-	playlist.UpdatedAt = now
+	if review.Status == "" {
+		review.Status = "active"
+	}
 
-	err := r.db.QueryRowContext(
+	return r.db.QueryRowContext(
 		ctx,
 		query,
-		playlist.ID,
-		playlist.UserID,
-		playlist.Name,
-		playlist.Description,
-		playlist.IsPublic,
-		playlist.CreatedAt,
-		playlist.UpdatedAt,
-	).Scan(&playlist.ID)
-
-	return err
+		review.ID,
+		review.PodcastID,
+		review.UserID,
+		review.Rating,
+		review.Content,
+		review.Status,
+		review.FlagReason,
+		time.Now().UTC(),
+	).Scan(&review.ID, &review.CreatedAt, &review.UpdatedAt)
 }
 
-// GetPlaylistByID gets a playlist by ID
-func (r *repository) GetPlaylistByID(ctx context.Context, id, userID uuid.UUID) (*models.Playlist, error) {
-	var playlist models.Playlist
-	query := `
-		SELECT id, user_id, name, description, is_public, created_at, updated_at
-		FROM playlists
-		WHERE id = $1 AND (user_id = $2 OR is_public = true)
+// GetReviewsByPodcastID gets the visible reviews of a podcast, most recently written or edited first
+func (r *repository) GetReviewsByPodcastID(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.PodcastReview, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM podcast_reviews
+		WHERE podcast_id = $1 AND status = 'active'
 	`
 
-	err := r.db.GetContext(ctx, &playlist, query, id, userID)
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, podcastID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, errors.New("playlist not found or not accessible")
-		}
-		return nil, err
+		return nil, 0, err
 	}
 
-	// Get episode count
-	countQuery := `SELECT COUNT(*) FROM playlist_items WHERE playlist_id = $1`
-	var episodeCount int
-	err = r.db.GetContext(ctx, &episodeCount, countQuery, id)
+	query := `
+		SELECT
+			pr.id, pr.podcast_id, pr.user_id, pr.rating, pr.content, pr.status, pr.created_at, pr.updated_at,
+			u.username, u.full_name as user_full_name, u.profile_image_url as user_profile_url
+		FROM podcast_reviews pr
+		JOIN users u ON pr.user_id = u.id
+		WHERE pr.podcast_id = $1 AND pr.status = 'active'
+		ORDER BY pr.updated_at DESC, pr.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	reviews := []*models.PodcastReview{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &reviews, query, podcastID, pageSize, offset)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	playlist.EpisodeCount = episodeCount
-
-	return &playlist, nil
+	return reviews, totalCount, nil
 }
 
-// GetUserPlaylists gets playlists for a user
-func (r *repository) GetUserPlaylists(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Playlist, int, error) {
+// DeleteReview deletes a user's review of a podcast
+func (r *repository) DeleteReview(ctx context.Context, podcastID, userID uuid.UUID) error {
+	query := `DELETE FROM podcast_reviews WHERE podcast_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, podcastID, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrReviewNotFound
+	}
+
+	return nil
+}
+
+// GetPodcastRatings gets the average rating and review count of each of the given podcasts.
+// Podcasts without visible reviews are left out.
+func (r *repository) GetPodcastRatings(ctx context.Context, podcastIDs []uuid.UUID) (map[uuid.UUID]models.PodcastRating, error) {
+	query := `
+		SELECT podcast_id, AVG(rating)::float8 as average_rating, COUNT(*) as review_count
+		FROM podcast_reviews
+		WHERE podcast_id = ANY($1) AND status = 'active'
+		GROUP BY podcast_id
+	`
+
+	var rows []models.PodcastRating
+	err := r.db.SelectContext(ctx, &rows, query, pq.Array(podcastIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make(map[uuid.UUID]models.PodcastRating, len(rows))
+	for _, rating := range rows {
+		ratings[rating.PodcastID] = rating
+	}
+
+	return ratings, nil
+}
+
+// RefreshPodcastListenCounts recounts the listens of every podcast from the listen events,
+// returning the number of podcasts whose count changed
+func (r *repository) RefreshPodcastListenCounts(ctx context.Context) (int, error) {
+	query := `
+		UPDATE podcasts p
+		SET listen_count = counts.listen_count
+		FROM (
+			SELECT p.id, COUNT(le.id) AS listen_count
+			FROM podcasts p
+			LEFT JOIN episodes e ON e.podcast_id = p.id
+			LEFT JOIN listen_events le ON le.episode_id = e.id
+			GROUP BY p.id
+		) counts
+		WHERE p.id = counts.id AND p.listen_count <> counts.listen_count
+	`
+
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// GetEpisodeListenStats gets the listen count and average completion of each of the given episodes,
+// reading the listen events recorded by the analytics service. A completed listen counts as 100%,
+// others by how much of the episode was heard. Episodes without listens are left out.
+func (r *repository) GetEpisodeListenStats(ctx context.Context, episodeIDs []uuid.UUID) (map[uuid.UUID]models.EpisodeListenStats, error) {
+	query := `
+		SELECT
+			le.episode_id,
+			COUNT(*) as listen_count,
+			COALESCE(AVG(CASE
+				WHEN le.completed THEN 100
+				WHEN e.duration > 0 THEN LEAST(COALESCE(le.duration, 0) * 100.0 / e.duration, 100)
+			END), 0)::float8 as average_completion
+		FROM listen_events le
+		JOIN episodes e ON e.id = le.episode_id
+		WHERE le.episode_id = ANY($1)
+		GROUP BY le.episode_id
+	`
+
+	var rows []models.EpisodeListenStats
+	err := r.db.SelectContext(ctx, &rows, query, pq.Array(episodeIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[uuid.UUID]models.EpisodeListenStats, len(rows))
+	for _, row := range rows {
+		stats[row.EpisodeID] = row
+	}
+
+	return stats, nil
+}
+
+// CreatePlaylist creates a new playlist
+func (r *repository) CreatePlaylist(ctx context.Context, playlist *models.Playlist) error {
+	query := `
+		INSERT INTO playlists (
+			id, user_id, name, description, is_public, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7
+		) RETURNING id
+	`
+
+	if playlist.ID == uuid.Nil {
+		playlist.ID = uuid.New()
+	}
+
+	now := time.Now().UTC()
+	playlist.CreatedAt = now
+	playlist.UpdatedAt = now
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		playlist.ID,
+		playlist.UserID,
+		playlist.Name,
+		playlist.Description,
+		playlist.IsPublic,
+		playlist.CreatedAt,
+		playlist.UpdatedAt,
+	).Scan(&playlist.ID)
+
+	return err
+}
+
+// GetPlaylistByID gets a playlist by ID
+func (r *repository) GetPlaylistByID(ctx context.Context, id, userID uuid.UUID) (*models.Playlist, error) {
+	var playlist models.Playlist
 	query := `
-		SELECT id, user_id, name, description, is_public, created_at, updated_at
+		SELECT
+			id, user_id, name, description, is_public, created_at, updated_at,
+			(SELECT COUNT(*) FROM playlist_items pi WHERE pi.playlist_id = playlists.id) AS episode_count
 		FROM playlists
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		WHERE id = $1 AND (user_id = $2 OR is_public = true)
+	`
+
+	err := r.db.GetContext(ctx, &playlist, query, id, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrPlaylistNotAccessible
+		}
+		return nil, err
+	}
+
+	return &playlist, nil
+}
+
+// GetUserPlaylists gets playlists for a user, with their episode counts
+func (r *repository) GetUserPlaylists(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Playlist, int, error) {
+	query := `
+		SELECT
+			p.id, p.user_id, p.name, p.description, p.is_public, p.created_at, p.updated_at,
+			COUNT(pi.episode_id) AS episode_count
+		FROM playlists p
+		LEFT JOIN playlist_items pi ON pi.playlist_id = p.id
+		WHERE p.user_id = $1
+		GROUP BY p.id
+		ORDER BY p.created_at DESC, p.id
 		LIMIT $2 OFFSET $3
 	`
 
@@ -466,17 +1127,6 @@ func (r *repository) GetUserPlaylists(ctx context.Context, userID uuid.UUID, pag
 		return nil, 0, err
 	}
 
-	// Get episode counts for each playlist
-	for _, playlist := range playlists {
-		episodeCountQuery := `SELECT COUNT(*) FROM playlist_items WHERE playlist_id = $1`
-		var episodeCount int
-		err = r.db.GetContext(ctx, &episodeCount, episodeCountQuery, playlist.ID)
-		if err != nil {
-			return nil, 0, err
-		}
-		playlist.EpisodeCount = episodeCount
-	}
-
 	return playlists, totalCount, nil
 }
 
@@ -488,14 +1138,14 @@ func (r *repository) UpdatePlaylist(ctx context.Context, playlist *models.Playli
 	err := r.db.GetContext(ctx, &playlistUserID, checkQuery, playlist.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("playlist not found")
+			return models.ErrPlaylistNotFound
 		}
 		return err
 	}
 
 	// Only allow updates if the user is the playlist owner
 	if playlistUserID != playlist.UserID {
-		return errors.New("not authorized to update this playlist")
+		return models.ErrPlaylistUpdateNotAuthorized
 	}
 
 	// Update the playlist
@@ -508,7 +1158,7 @@ func (r *repository) UpdatePlaylist(ctx context.Context, playlist *models.Playli
 		WHERE id = $5
 	`
 
-	playlist.UpdatedAt = time.Now()
+	playlist.UpdatedAt = time.Now().UTC()
 
 	_, err = r.db.ExecContext(
 		ctx,
@@ -531,14 +1181,14 @@ func (r *repository) DeletePlaylist(ctx context.Context, id, userID uuid.UUID) e
 	err := r.db.GetContext(ctx, &playlistUserID, checkQuery, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("playlist not found")
+			return models.ErrPlaylistNotFound
 		}
 		return err
 	}
 
 	// Only allow deletion if the user is the playlist owner
 	if playlistUserID != userID {
-		return errors.New("not authorized to delete this playlist")
+		return models.ErrPlaylistDeleteNotAuthorized
 	}
 
 	// Delete the playlist
@@ -555,7 +1205,7 @@ func (r *repository) AddToPlaylist(ctx context.Context, playlistID, episodeID uu
 	err := r.db.GetContext(ctx, &episode, episodeQuery, episodeID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return errors.New("episode not found")
+			return models.ErrEpisodeNotFound
 		}
 		return err
 	}
@@ -581,7 +1231,7 @@ func (r *repository) AddToPlaylist(ctx context.Context, playlistID, episodeID uu
 		SET position = $3, added_at = $4
 	`
 
-	_, err = r.db.ExecContext(ctx, query, playlistID, episodeID, position, time.Now())
+	_, err = r.db.ExecContext(ctx, query, playlistID, episodeID, position, time.Now().UTC())
 	return err
 }
 
@@ -633,9 +1283,9 @@ func (r *repository) GetActivePodcasts(ctx context.Context) ([]*models.Podcast,
 		SELECT 
 			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
 			language, author, category, subcategory, explicit, status, created_at, updated_at,
-			last_synced_at
+			last_synced_at, next_sync_retry_at, sync_interval_minutes
 		FROM podcasts
-		WHERE status = 'active' AND rss_url != ''
+		WHERE status = 'active' AND rss_url != '' AND NOT sync_suspended
 	`
 
 	var podcasts []*models.Podcast
@@ -666,6 +1316,18 @@ func (r *repository) GetPodcastByRSSURL(ctx context.Context, rssURL string) (*mo
 	return &podcast, nil
 }
 
+// UpdatePodcastRSSURL points a podcast at the new location of its feed
+func (r *repository) UpdatePodcastRSSURL(ctx context.Context, podcastID uuid.UUID, rssURL string) error {
+	query := `
+		UPDATE podcasts
+		SET rss_url = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, podcastID, rssURL, time.Now().UTC())
+	return err
+}
+
 // IsUserAuthorizedForPodcast checks if a user is authorized to manage a podcast
 func (r *repository) IsUserAuthorizedForPodcast(ctx context.Context, podcastID, userID uuid.UUID) (bool, error) {
 	query := `
@@ -682,6 +1344,11 @@ func (r *repository) IsUserAuthorizedForPodcast(ctx context.Context, podcastID,
 
 // UpdatePodcastTx updates a podcast within a transaction
 func (r *repository) UpdatePodcastTx(ctx context.Context, tx *sqlx.Tx, podcast *models.Podcast) error {
+	return updatePodcast(ctx, tx, podcast)
+}
+
+// updatePodcast writes a podcast's metadata and settings, from the podcaster or its feed
+func updatePodcast(ctx context.Context, db sqlx.ExecerContext, podcast *models.Podcast) error {
 	query := `
 		UPDATE podcasts
 		SET
@@ -697,11 +1364,18 @@ func (r *repository) UpdatePodcastTx(ctx context.Context, tx *sqlx.Tx, podcast *
 			explicit = $11,
 			status = $12,
 			updated_at = $13,
-			last_synced_at = $14
+			last_synced_at = $14,
+			prune_missing = $15,
+			feed_etag = $16,
+			feed_last_modified = $17,
+			cover_image_user_set = $18,
+			feed_cover_image_url = $19,
+			keywords = $20,
+			sync_interval_minutes = $21
 		WHERE id = $1
 	`
 
-	_, err := tx.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		query,
 		podcast.ID,
@@ -718,6 +1392,13 @@ func (r *repository) UpdatePodcastTx(ctx context.Context, tx *sqlx.Tx, podcast *
 		podcast.Status,
 		podcast.UpdatedAt,
 		podcast.LastSyncedAt,
+		podcast.PruneMissing,
+		podcast.FeedETag,
+		podcast.FeedLastModified,
+		podcast.CoverImageUserSet,
+		podcast.FeedCoverImageURL,
+		podcast.Keywords,
+		podcast.SyncIntervalMinutes,
 	)
 
 	return err
@@ -728,7 +1409,7 @@ func (r *repository) GetAllEpisodesByPodcastIDTx(ctx context.Context, tx *sqlx.T
 	query := `
 		SELECT
 			id, podcast_id, title, description, audio_url, duration, cover_image_url,
-			publication_date, guid, episode_number, season_number, transcript, status,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
 			created_at, updated_at
 		FROM episodes
 		WHERE podcast_id = $1
@@ -744,7 +1425,7 @@ func (r *repository) GetAllEpisodesByPodcastID(ctx context.Context, podcastID uu
 	query := `
 		SELECT
 			id, podcast_id, title, description, audio_url, duration, cover_image_url,
-			publication_date, guid, episode_number, season_number, transcript, status,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
 			created_at, updated_at
 		FROM episodes
 		WHERE podcast_id = $1
@@ -755,15 +1436,26 @@ func (r *repository) GetAllEpisodesByPodcastID(ctx context.Context, podcastID uu
 	return episodes, err
 }
 
+// CreateEpisode creates an episode
+func (r *repository) CreateEpisode(ctx context.Context, episode *models.Episode) error {
+	return createEpisode(ctx, r.db, episode)
+}
+
 // CreateEpisodeTx creates an episode within a transaction
 func (r *repository) CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *models.Episode) error {
+	return createEpisode(ctx, tx, episode)
+}
+
+// createEpisode inserts an episode, defaulting its ID and timestamps
+func createEpisode(ctx context.Context, db sqlx.QueryerContext, episode *models.Episode) error {
 	query := `
 		INSERT INTO episodes (
 			id, podcast_id, title, description, audio_url, duration, cover_image_url,
-			publication_date, guid, episode_number, season_number, transcript, status,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
 			created_at, updated_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22,
+			$23, $24, $25
 		) RETURNING id
 	`
 
@@ -771,7 +1463,7 @@ func (r *repository) CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 		episode.ID = uuid.New()
 	}
 
-	now := time.Now()
+	now := time.Now().UTC()
 	if episode.CreatedAt.IsZero() {
 		episode.CreatedAt = now
 	}
@@ -779,7 +1471,7 @@ func (r *repository) CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 		episode.UpdatedAt = now
 	}
 
-	err := tx.QueryRowContext(
+	err := db.QueryRowxContext(
 		ctx,
 		query,
 		episode.ID,
@@ -793,7 +1485,17 @@ func (r *repository) CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 		episode.GUID,
 		episode.EpisodeNumber,
 		episode.SeasonNumber,
+		episode.EpisodeType,
 		episode.Transcript,
+		episode.Transcripts,
+		episode.Chapters,
+		episode.ChaptersURL,
+		episode.ChaptersUserSet,
+		episode.Keywords,
+		episode.AlternateEnclosures,
+		episode.FileSize,
+		episode.MimeType,
+		episode.Explicit,
 		episode.Status,
 		episode.CreatedAt,
 		episode.UpdatedAt,
@@ -802,8 +1504,18 @@ func (r *repository) CreateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 	return err
 }
 
+// UpdateEpisode updates an episode
+func (r *repository) UpdateEpisode(ctx context.Context, episode *models.Episode) error {
+	return updateEpisode(ctx, r.db, episode)
+}
+
 // UpdateEpisodeTx updates an episode within a transaction
 func (r *repository) UpdateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *models.Episode) error {
+	return updateEpisode(ctx, tx, episode)
+}
+
+// updateEpisode writes an episode's metadata, from the podcaster or its feed
+func updateEpisode(ctx context.Context, db sqlx.ExecerContext, episode *models.Episode) error {
 	query := `
 		UPDATE episodes
 		SET
@@ -818,11 +1530,19 @@ func (r *repository) UpdateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 			season_number = $10,
 			transcript = $11,
 			status = $12,
-			updated_at = $13
+			updated_at = $13,
+			episode_type = $14,
+			transcripts = $15,
+			chapters_url = $16,
+			keywords = $17,
+			alternate_enclosures = $18,
+			file_size = $19,
+			mime_type = $20,
+			explicit = $21
 		WHERE id = $1
 	`
 
-	_, err := tx.ExecContext(
+	_, err := db.ExecContext(
 		ctx,
 		query,
 		episode.ID,
@@ -838,18 +1558,162 @@ func (r *repository) UpdateEpisodeTx(ctx context.Context, tx *sqlx.Tx, episode *
 		episode.Transcript,
 		episode.Status,
 		episode.UpdatedAt,
+		episode.EpisodeType,
+		episode.Transcripts,
+		episode.ChaptersURL,
+		episode.Keywords,
+		episode.AlternateEnclosures,
+		episode.FileSize,
+		episode.MimeType,
+		episode.Explicit,
 	)
 
 	return err
 }
 
+// SetEpisodeChapters sets the chapters of an episode by hand; syncs leave them alone from then on
+func (r *repository) SetEpisodeChapters(ctx context.Context, episodeID uuid.UUID, chapters models.Chapters) error {
+	query := `
+		UPDATE episodes
+		SET chapters = $2, chapters_user_set = true, updated_at = $3
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query, episodeID, chapters, time.Now().UTC())
+	return err
+}
+
+// UpdateFeedChapters stores the chapters read from an episode's podcast:chapters file, unless
+// the podcaster set the chapters by hand
+func (r *repository) UpdateFeedChapters(ctx context.Context, episodeID uuid.UUID, chapters models.Chapters) error {
+	query := `
+		UPDATE episodes
+		SET chapters = $2, updated_at = $3
+		WHERE id = $1 AND chapters_user_set = false
+	`
+
+	_, err := r.db.ExecContext(ctx, query, episodeID, chapters, time.Now().UTC())
+	return err
+}
+
+// ReplaceTranscriptSegments replaces the searchable segments of an episode's transcript
+func (r *repository) ReplaceTranscriptSegments(ctx context.Context, episodeID uuid.UUID, segments []models.TranscriptSegment) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM episode_transcript_segments WHERE episode_id = $1`, episodeID)
+	if err != nil {
+		return err
+	}
+
+	if len(segments) > 0 {
+		positions := make([]int64, len(segments))
+		startTimes := make([]sql.NullFloat64, len(segments))
+		endTimes := make([]sql.NullFloat64, len(segments))
+		offsets := make([]int64, len(segments))
+		texts := make([]string, len(segments))
+		for i, segment := range segments {
+			positions[i] = int64(i)
+			if segment.StartTime != nil {
+				startTimes[i] = sql.NullFloat64{Float64: *segment.StartTime, Valid: true}
+			}
+			if segment.EndTime != nil {
+				endTimes[i] = sql.NullFloat64{Float64: *segment.EndTime, Valid: true}
+			}
+			offsets[i] = int64(segment.CharOffset)
+			texts[i] = segment.Text
+		}
+
+		query := `
+			INSERT INTO episode_transcript_segments (episode_id, position, start_time, end_time, char_offset, text)
+			SELECT $1, s.position, s.start_time, s.end_time, s.char_offset, s.text
+			FROM unnest($2::int[], $3::float8[], $4::float8[], $5::int[], $6::text[])
+				AS s(position, start_time, end_time, char_offset, text)
+		`
+
+		_, err = tx.ExecContext(ctx, query, episodeID, pq.Array(positions), pq.Array(startTimes),
+			pq.Array(endTimes), pq.Array(offsets), pq.Array(texts))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SearchTranscript finds the transcript segments of an episode matching a web search style query,
+// in transcript order. Segment text is HTML-escaped before ts_headline marks the matching words.
+func (r *repository) SearchTranscript(ctx context.Context, episodeID uuid.UUID, query string, page, pageSize int) ([]*models.TranscriptMatch, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM episode_transcript_segments
+		WHERE episode_id = $1 AND tsv @@ websearch_to_tsquery('simple', $2)
+	`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, episodeID, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	searchQuery := `
+		SELECT
+			ts.start_time, ts.char_offset,
+			ts_headline(
+				'simple',
+				replace(replace(replace(ts.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+				q,
+				'StartSel=<mark>, StopSel=</mark>, MaxFragments=1, MaxWords=20, MinWords=5'
+			) as excerpt
+		FROM episode_transcript_segments ts, websearch_to_tsquery('simple', $2) q
+		WHERE ts.episode_id = $1 AND ts.tsv @@ q
+		ORDER BY ts.position
+		LIMIT $3 OFFSET $4
+	`
+
+	matches := []*models.TranscriptMatch{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &matches, searchQuery, episodeID, query, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return matches, totalCount, nil
+}
+
+// MarkEpisodesRemovedTx marks episodes that dropped out of their feed as removed within a
+// transaction. Only active episodes are changed. Returns the number of episodes marked.
+func (r *repository) MarkEpisodesRemovedTx(ctx context.Context, tx *sqlx.Tx, episodeIDs []uuid.UUID) (int, error) {
+	query := `
+		UPDATE episodes
+		SET status = 'removed', updated_at = $2
+		WHERE id = ANY($1) AND status = 'active'
+	`
+
+	result, err := tx.ExecContext(ctx, query, pq.Array(episodeIDs), time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
 // CreateSyncLog creates a new RSS feed sync log
 func (r *repository) CreateSyncLog(ctx context.Context, log *models.RSSFeedSyncLog) error {
 	query := `
 		INSERT INTO rss_sync_logs (
-			id, podcast_id, status, episodes_added, episodes_updated, error_message, created_at
+			id, podcast_id, status, episodes_added, episodes_updated, episodes_removed, error_message,
+			failure_reason, http_status, warnings, created_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7
+			$1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, 0), $10, $11
 		) RETURNING id
 	`
 
@@ -858,7 +1722,7 @@ func (r *repository) CreateSyncLog(ctx context.Context, log *models.RSSFeedSyncL
 	}
 
 	if log.CreatedAt.IsZero() {
-		log.CreatedAt = time.Now()
+		log.CreatedAt = time.Now().UTC()
 	}
 
 	err := r.db.QueryRowContext(
@@ -869,7 +1733,11 @@ func (r *repository) CreateSyncLog(ctx context.Context, log *models.RSSFeedSyncL
 		log.Status,
 		log.EpisodesAdded,
 		log.EpisodesUpdated,
+		log.EpisodesRemoved,
 		log.ErrorMessage,
+		log.FailureReason,
+		log.HTTPStatus,
+		log.Warnings,
 		log.CreatedAt,
 	).Scan(&log.ID)
 
@@ -880,7 +1748,9 @@ func (r *repository) CreateSyncLog(ctx context.Context, log *models.RSSFeedSyncL
 func (r *repository) GetLatestSyncLog(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error) {
 	query := `
 		SELECT
-			id, podcast_id, status, episodes_added, episodes_updated, error_message, created_at
+			id, podcast_id, status, episodes_added, episodes_updated, episodes_removed, error_message,
+			COALESCE(failure_reason, '') AS failure_reason, COALESCE(http_status, 0) AS http_status,
+			warnings, created_at
 		FROM rss_sync_logs
 		WHERE podcast_id = $1
 		ORDER BY created_at DESC
@@ -918,7 +1788,9 @@ func (r *repository) GetSyncLogs(ctx context.Context, podcastID uuid.UUID, page,
 	offset := (page - 1) * pageSize
 	logsQuery := `
 		SELECT
-			id, podcast_id, status, episodes_added, episodes_updated, error_message, created_at
+			id, podcast_id, status, episodes_added, episodes_updated, episodes_removed, error_message,
+			COALESCE(failure_reason, '') AS failure_reason, COALESCE(http_status, 0) AS http_status,
+			warnings, created_at
 		FROM rss_sync_logs
 		WHERE podcast_id = $1
 		ORDER BY created_at DESC
@@ -932,4 +1804,1493 @@ func (r *repository) GetSyncLogs(ctx context.Context, podcastID uuid.UUID, page,
 	}
 
 	return logs, totalCount, nil
-}
\ No newline at end of file
+}
+
+// ArchiveEpisodes soft-deletes a podcast's episodes matching the given IDs and/or publication cutoff.
+// IDs that do not belong to the podcast are ignored, as are taken down episodes, which stay
+// taken down. Returns the number of episodes archived. The audit entry, if any, is written in
+// the same transaction.
+func (r *repository) ArchiveEpisodes(ctx context.Context, podcastID uuid.UUID, episodeIDs []uuid.UUID, publishedBefore *time.Time, auditEntry *auditModels.AuditEntry) (int, error) {
+	conditions := []string{"podcast_id = $1", "status NOT IN ('archived', 'taken_down')"}
+	args := []interface{}{podcastID, time.Now().UTC()}
+
+	if len(episodeIDs) > 0 {
+		args = append(args, pq.Array(episodeIDs))
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d)", len(args)))
+	}
+
+	if publishedBefore != nil {
+		args = append(args, *publishedBefore)
+		conditions = append(conditions, fmt.Sprintf("publication_date < $%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE episodes
+		SET status = 'archived', updated_at = $2
+		WHERE %s
+	`, strings.Join(conditions, " AND "))
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if auditEntry != nil && affected > 0 {
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// GetPodcastsDueForSyncRetry gets podcasts whose failed sync is scheduled for a retry at or before now
+func (r *repository) GetPodcastsDueForSyncRetry(ctx context.Context, now time.Time) ([]*models.Podcast, error) {
+	query := `
+		SELECT 
+			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
+			language, author, category, subcategory, explicit, status, created_at, updated_at,
+			last_synced_at, sync_failure_count, next_sync_retry_at, sync_suspended
+		FROM podcasts
+		WHERE status = 'active' AND rss_url != '' AND NOT sync_suspended
+			AND next_sync_retry_at IS NOT NULL AND next_sync_retry_at <= $1
+		ORDER BY next_sync_retry_at
+	`
+
+	var podcasts []*models.Podcast
+	err := r.db.SelectContext(ctx, &podcasts, query, now)
+	return podcasts, err
+}
+
+// GetSyncSuspendedPodcasts gets podcasts whose feed sync was suspended after repeated failures
+func (r *repository) GetSyncSuspendedPodcasts(ctx context.Context, page, pageSize int) ([]*models.Podcast, int, error) {
+	// Get total count
+	countQuery := `SELECT COUNT(*) FROM podcasts WHERE sync_suspended`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get podcasts with pagination
+	offset := (page - 1) * pageSize
+	query := `
+		SELECT 
+			id, podcaster_id, title, description, cover_image_url, rss_url, website_url,
+			language, author, category, subcategory, explicit, status, created_at, updated_at,
+			last_synced_at, sync_failure_count, next_sync_retry_at, sync_suspended, cover_image_path
+		FROM podcasts
+		WHERE sync_suspended
+		ORDER BY updated_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	var podcasts []*models.Podcast
+	err = r.db.SelectContext(ctx, &podcasts, query, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return podcasts, totalCount, nil
+}
+
+// RecordSyncFailure increments the consecutive sync failure count of a podcast, suspending its sync
+// once maxFailures is reached. Returns the new failure count and whether the sync is now suspended.
+func (r *repository) RecordSyncFailure(ctx context.Context, podcastID uuid.UUID, maxFailures int) (int, bool, error) {
+	query := `
+		UPDATE podcasts
+		SET
+			sync_failure_count = sync_failure_count + 1,
+			sync_suspended = sync_suspended OR ($2 > 0 AND sync_failure_count + 1 >= $2),
+			next_sync_retry_at = NULL
+		WHERE id = $1
+		RETURNING sync_failure_count, sync_suspended
+	`
+
+	var failureCount int
+	var suspended bool
+	err := r.db.QueryRowContext(ctx, query, podcastID, maxFailures).Scan(&failureCount, &suspended)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, false, models.ErrPodcastNotFound
+		}
+		return 0, false, err
+	}
+
+	return failureCount, suspended, nil
+}
+
+// ScheduleSyncRetry sets when a failed podcast sync should be retried
+func (r *repository) ScheduleSyncRetry(ctx context.Context, podcastID uuid.UUID, retryAt time.Time) error {
+	query := `UPDATE podcasts SET next_sync_retry_at = $2 WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, podcastID, retryAt)
+	return err
+}
+
+// ResetSyncFailures clears the failure count, pending retry and suspension of a podcast sync
+func (r *repository) ResetSyncFailures(ctx context.Context, podcastID uuid.UUID) error {
+	query := `
+		UPDATE podcasts
+		SET sync_failure_count = 0, next_sync_retry_at = NULL, sync_suspended = FALSE
+		WHERE id = $1 AND (sync_failure_count != 0 OR next_sync_retry_at IS NOT NULL OR sync_suspended)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, podcastID)
+	return err
+}
+
+// UpdatePodcastSyncedAt records when a podcast's feed was last checked, for syncs that
+// found nothing to apply
+func (r *repository) UpdatePodcastSyncedAt(ctx context.Context, podcastID uuid.UUID, syncedAt time.Time) error {
+	query := `UPDATE podcasts SET last_synced_at = $2 WHERE id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, podcastID, syncedAt)
+	return err
+}
+
+// SetPinnedEpisode pins an episode to the top of a podcast page, or unpins it when episodeID is nil
+func (r *repository) SetPinnedEpisode(ctx context.Context, podcastID uuid.UUID, episodeID *uuid.UUID) error {
+	query := `
+		UPDATE podcasts
+		SET pinned_episode_id = $2, updated_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query, podcastID, episodeID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return models.ErrPodcastNotFound
+	}
+
+	return nil
+}
+
+// GetEpisodeByID gets an episode by ID, whatever its status
+func (r *repository) GetEpisodeByID(ctx context.Context, id uuid.UUID) (*models.Episode, error) {
+	query := `
+		SELECT
+			id, podcast_id, title, description, audio_url, duration, cover_image_url,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
+			created_at, updated_at
+		FROM episodes
+		WHERE id = $1
+	`
+
+	var episode models.Episode
+	err := r.db.GetContext(ctx, &episode, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrEpisodeNotFound
+		}
+		return nil, err
+	}
+
+	return &episode, nil
+}
+
+// DeleteEpisode deletes an episode along with its plays, likes and comments
+func (r *repository) DeleteEpisode(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM episodes WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrEpisodeNotFound
+	}
+
+	return nil
+}
+
+// GetEpisodesByIDs gets the episodes with the given IDs, whatever their status. IDs without an
+// episode are skipped, so fewer episodes than IDs may be returned.
+func (r *repository) GetEpisodesByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Episode, error) {
+	query := `
+		SELECT
+			id, podcast_id, title, description, audio_url, duration, cover_image_url,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
+			created_at, updated_at
+		FROM episodes
+		WHERE id = ANY($1)
+	`
+
+	var episodes []*models.Episode
+	err := r.db.SelectContext(ctx, &episodes, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return episodes, nil
+}
+
+// GetEpisodesByPodcastID gets the active episodes of a podcast, newest first, optionally
+// only those of the given episode type and leaving out explicit ones
+func (r *repository) GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, episodeType string, hideExplicit bool, page, pageSize int) ([]*models.Episode, int, error) {
+	whereClause := "WHERE podcast_id = $1 AND status = 'active'"
+	args := []interface{}{podcastID}
+	if episodeType != "" {
+		args = append(args, episodeType)
+		whereClause += fmt.Sprintf(" AND episode_type = $%d", len(args))
+	}
+	if hideExplicit {
+		whereClause += " AND NOT explicit"
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM episodes " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get episodes with pagination
+	offset := (page - 1) * pageSize
+	query := fmt.Sprintf(`
+		SELECT
+			id, podcast_id, title, description, audio_url, duration, cover_image_url,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
+			created_at, updated_at
+		FROM episodes
+		%s
+		ORDER BY publication_date DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, len(args)+1, len(args)+2)
+
+	var episodes []*models.Episode
+	err = r.db.SelectContext(ctx, &episodes, query, append(args, pageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return episodes, totalCount, nil
+}
+
+// GetEpisodesByPodcastIDAfter gets up to limit active episodes of a podcast following the cursor,
+// ordered by (created_at, id) descending, optionally only those of the given episode type and
+// leaving out explicit ones
+func (r *repository) GetEpisodesByPodcastIDAfter(ctx context.Context, podcastID uuid.UUID, episodeType string, hideExplicit bool, after *pagination.Cursor, limit int) ([]*models.Episode, error) {
+	whereClause := "WHERE podcast_id = $1 AND status = 'active'"
+	args := []interface{}{podcastID}
+	if episodeType != "" {
+		args = append(args, episodeType)
+		whereClause += fmt.Sprintf(" AND episode_type = $%d", len(args))
+	}
+	if hideExplicit {
+		whereClause += " AND NOT explicit"
+	}
+	if after != nil {
+		args = append(args, after.Time, after.ID)
+		whereClause += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			id, podcast_id, title, description, audio_url, duration, cover_image_url,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
+			created_at, updated_at
+		FROM episodes
+		%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, whereClause, len(args)+1)
+
+	var episodes []*models.Episode
+	err := r.db.SelectContext(ctx, &episodes, query, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return episodes, nil
+}
+
+// episodeSortColumns whitelists the columns episodes can be sorted by in searches
+var episodeSortColumns = map[string]string{
+	"publication_date": "publication_date",
+	"title":            "title",
+	"duration":         "duration",
+}
+
+// ListEpisodes searches active episodes across podcasts. A query is matched against the
+// title and description search vector and, unless another sort is requested, results are
+// ranked by relevance; otherwise the newest episodes come first.
+func (r *repository) ListEpisodes(ctx context.Context, params models.EpisodeSearchParams) ([]*models.Episode, int, error) {
+	// Episodes of suspended and deleted podcasts are hidden along with their podcast
+	conditions := []string{"status = 'active'", "podcast_id NOT IN (SELECT id FROM podcasts WHERE status IN ('suspended', 'deleted'))"}
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	// A query also matches episodes tagged with it as a keyword
+	queryArg := 0
+	if params.Query != "" {
+		args = append(args, params.Query, searchKeyword(params.Query))
+		queryArg = len(args) - 1
+		conditions = append(conditions, fmt.Sprintf("(search_vector @@ plainto_tsquery('%s', $%d) OR keywords @> ARRAY[$%d::text])", searchConfig(params.Query), queryArg, len(args)))
+	}
+	if params.PodcastID != "" {
+		addCondition("podcast_id = $%d", params.PodcastID)
+	}
+	if !params.FromDate.IsZero() {
+		addCondition("publication_date >= $%d", params.FromDate)
+	}
+	if !params.ToDate.IsZero() {
+		addCondition("publication_date < $%d", params.ToDate)
+	}
+	if params.HideExplicit {
+		conditions = append(conditions, "NOT explicit")
+	}
+
+	orderBy := "publication_date DESC"
+	switch {
+	case params.SortBy == "relevance" || (params.SortBy == "" && params.Query != ""):
+		if params.Query == "" {
+			return nil, 0, models.ErrInvalidSortField
+		}
+		orderBy = fmt.Sprintf("ts_rank(search_vector, plainto_tsquery('%s', $%d)) DESC, publication_date DESC", searchConfig(params.Query), queryArg)
+	case params.SortBy != "":
+		sortColumn, ok := episodeSortColumns[params.SortBy]
+		if !ok {
+			return nil, 0, models.ErrInvalidSortField
+		}
+		order := "DESC"
+		if strings.EqualFold(params.SortOrder, "asc") {
+			order = "ASC"
+		}
+		orderBy = fmt.Sprintf("%s %s NULLS LAST", sortColumn, order)
+	}
+
+	whereClause := "WHERE " + strings.Join(conditions, " AND ")
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM episodes " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get episodes with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT
+			id, podcast_id, title, description, audio_url, duration, cover_image_url,
+			publication_date, guid, episode_number, season_number, episode_type, transcript, transcripts, chapters, chapters_url, chapters_user_set, keywords, alternate_enclosures, file_size, mime_type, explicit, status,
+			created_at, updated_at
+		FROM episodes
+		%s
+		ORDER BY %s, id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, orderBy, len(args)+1, len(args)+2)
+
+	var episodes []*models.Episode
+	err = r.db.SelectContext(ctx, &episodes, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return episodes, totalCount, nil
+}
+
+// searchKeywordBoost is added to the text rank of results tagged with the query as a keyword
+const searchKeywordBoost = 0.1
+
+// Search searches active podcasts and episodes at once. Both are matched as in their own
+// listings and ranked together by text rank, so the best matches come first whatever their type.
+func (r *repository) Search(ctx context.Context, params models.SearchParams) ([]*models.SearchResult, int, error) {
+	tsQuery := prefixTSQuery(params.Query)
+	if tsQuery == "" {
+		return nil, 0, models.ErrEmptyQuery
+	}
+
+	config := searchConfig(params.Query)
+	args := []interface{}{tsQuery, searchKeyword(params.Query)}
+	podcastConditions := []string{
+		"p.status = 'active'",
+		fmt.Sprintf("(p.search_vector @@ to_tsquery('%s', $1) OR p.keywords @> ARRAY[$2::text])", config),
+	}
+	episodeConditions := []string{
+		"e.status = 'active'",
+		"p.status = 'active'",
+		fmt.Sprintf("(e.search_vector @@ to_tsquery('%s', $1) OR e.keywords @> ARRAY[$2::text])", config),
+	}
+
+	// Podcast filters apply to episodes through their podcast
+	addPodcastFilter := func(condition string, value interface{}) {
+		args = append(args, value)
+		condition = fmt.Sprintf(condition, len(args))
+		podcastConditions = append(podcastConditions, condition)
+		episodeConditions = append(episodeConditions, condition)
+	}
+	if params.Category != "" {
+		addPodcastFilter("p.id IN (SELECT podcast_id FROM podcast_categories WHERE category_id = $%d)", params.Category)
+	}
+	if params.Language != "" {
+		addPodcastFilter(languageCondition, strings.ToLower(params.Language))
+	}
+	if params.Explicit != nil {
+		args = append(args, *params.Explicit)
+		podcastConditions = append(podcastConditions, fmt.Sprintf("p.explicit = $%d", len(args)))
+		episodeConditions = append(episodeConditions, fmt.Sprintf("e.explicit = $%d", len(args)))
+	}
+	if params.HideExplicit {
+		podcastConditions = append(podcastConditions, "NOT p.explicit")
+		episodeConditions = append(episodeConditions, "NOT e.explicit")
+	}
+
+	podcastQuery := fmt.Sprintf(`
+		SELECT
+			p.id, 'podcast' AS type, p.title, p.description, p.cover_image_url AS image_url,
+			NULL::uuid AS podcast_id, '' AS podcast_title, p.language, p.explicit,
+			NULL::timestamptz AS publication_date, p.category,
+			(ts_rank(p.search_vector, to_tsquery('%[3]s', $1))
+				+ CASE WHEN p.keywords @> ARRAY[$2::text] THEN %[2]v ELSE 0 END)::float8 AS score
+		FROM podcasts p
+		WHERE %[1]s`, strings.Join(podcastConditions, " AND "), searchKeywordBoost, config)
+	episodeQuery := fmt.Sprintf(`
+		SELECT
+			e.id, 'episode' AS type, e.title, e.description,
+			COALESCE(NULLIF(e.cover_image_url, ''), p.cover_image_url) AS image_url,
+			p.id AS podcast_id, p.title AS podcast_title, p.language, e.explicit,
+			e.publication_date, p.category,
+			(ts_rank(e.search_vector, to_tsquery('%[3]s', $1))
+				+ CASE WHEN e.keywords @> ARRAY[$2::text] THEN %[2]v ELSE 0 END)::float8 AS score
+		FROM episodes e
+		JOIN podcasts p ON p.id = e.podcast_id
+		WHERE %[1]s`, strings.Join(episodeConditions, " AND "), searchKeywordBoost, config)
+
+	var unionQuery string
+	switch params.Type {
+	case models.SearchResultPodcast:
+		unionQuery = podcastQuery
+	case models.SearchResultEpisode:
+		unionQuery = episodeQuery
+	default:
+		unionQuery = podcastQuery + "\n\t\tUNION ALL" + episodeQuery
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM (" + unionQuery + ") results"
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get results with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s
+		) results
+		ORDER BY score DESC, publication_date DESC NULLS FIRST, id
+		LIMIT $%d OFFSET $%d
+	`, unionQuery, len(args)+1, len(args)+2)
+
+	var results []*models.SearchResult
+	err = r.db.SelectContext(ctx, &results, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return results, totalCount, nil
+}
+
+// TakeDownEpisode marks an episode as taken down and records the takedown in the audit table.
+// The audit entry, if any, is written in the same transaction with the previous status as its before state.
+func (r *repository) TakeDownEpisode(ctx context.Context, takedown *models.EpisodeTakedown, auditEntry *auditModels.AuditEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if takedown.ID == uuid.Nil {
+		takedown.ID = uuid.New()
+	}
+	takedown.CreatedAt = time.Now().UTC()
+
+	// Lock the episode and remember its current status
+	err = tx.GetContext(ctx, &takedown.PreviousStatus, `SELECT status FROM episodes WHERE id = $1 FOR UPDATE`, takedown.EpisodeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrEpisodeNotFound
+		}
+		return err
+	}
+
+	if takedown.PreviousStatus == "taken_down" {
+		return models.ErrEpisodeAlreadyTakenDown
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE episodes SET status = 'taken_down', updated_at = $2 WHERE id = $1`,
+		takedown.EpisodeID,
+		takedown.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO episode_takedowns (
+			id, episode_id, taken_down_by, reason, previous_status, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+	`
+
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		takedown.ID,
+		takedown.EpisodeID,
+		takedown.TakenDownBy,
+		takedown.Reason,
+		takedown.PreviousStatus,
+		takedown.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if auditEntry != nil {
+		auditEntry.Before, err = json.Marshal(map[string]string{"status": takedown.PreviousStatus})
+		if err != nil {
+			return err
+		}
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetCommentStatus changes the moderation status of any comment
+func (r *repository) SetCommentStatus(ctx context.Context, commentID uuid.UUID, status string, auditEntry *auditModels.AuditEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the comment and remember its current status
+	var previousStatus string
+	err = tx.GetContext(ctx, &previousStatus, `SELECT status FROM comments WHERE id = $1 FOR UPDATE`, commentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrCommentNotFound
+		}
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE comments SET status = $2, updated_at = $3 WHERE id = $1`,
+		commentID,
+		status,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if auditEntry != nil {
+		auditEntry.Before, err = json.Marshal(map[string]string{"status": previousStatus})
+		if err != nil {
+			return err
+		}
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RemoveComment deletes any comment, whoever wrote it. The audit entry keeps the removed comment.
+func (r *repository) RemoveComment(ctx context.Context, commentID uuid.UUID, auditEntry *auditModels.AuditEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var comment models.Comment
+	err = tx.GetContext(
+		ctx,
+		&comment,
+		`SELECT id, user_id, episode_id, content, status, flag_reason, created_at, updated_at
+		FROM comments WHERE id = $1 FOR UPDATE`,
+		commentID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrCommentNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM comments WHERE id = $1`, commentID); err != nil {
+		return err
+	}
+
+	if auditEntry != nil {
+		auditEntry.Before, err = json.Marshal(comment)
+		if err != nil {
+			return err
+		}
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SuspendPodcast sets a podcast's status to suspended, which hides it and its episodes
+func (r *repository) SuspendPodcast(ctx context.Context, podcastID uuid.UUID, auditEntry *auditModels.AuditEntry) error {
+	return r.setPodcastSuspended(ctx, podcastID, true, auditEntry)
+}
+
+// UnsuspendPodcast makes a suspended podcast active again
+func (r *repository) UnsuspendPodcast(ctx context.Context, podcastID uuid.UUID, auditEntry *auditModels.AuditEntry) error {
+	return r.setPodcastSuspended(ctx, podcastID, false, auditEntry)
+}
+
+func (r *repository) setPodcastSuspended(ctx context.Context, podcastID uuid.UUID, suspended bool, auditEntry *auditModels.AuditEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the podcast and remember its current status
+	var previousStatus string
+	err = tx.GetContext(ctx, &previousStatus, `SELECT status FROM podcasts WHERE id = $1 FOR UPDATE`, podcastID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrPodcastNotFound
+		}
+		return err
+	}
+
+	status := "active"
+	if suspended {
+		if previousStatus == "suspended" {
+			return models.ErrPodcastAlreadySuspended
+		}
+		status = "suspended"
+	} else if previousStatus != "suspended" {
+		return models.ErrPodcastNotSuspended
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE podcasts SET status = $2, updated_at = $3 WHERE id = $1`,
+		podcastID,
+		status,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if auditEntry != nil {
+		auditEntry.Before, err = json.Marshal(map[string]string{"status": previousStatus})
+		if err != nil {
+			return err
+		}
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCommentByID gets a comment of any status by ID
+func (r *repository) GetCommentByID(ctx context.Context, id uuid.UUID) (*models.Comment, error) {
+	query := `
+		SELECT 
+			c.id, c.user_id, c.episode_id, c.content, c.status, c.flag_reason, c.created_at, c.updated_at,
+			COALESCE(u.username, '') as username, COALESCE(u.full_name, '') as user_full_name,
+			COALESCE(u.profile_image_url, '') as user_profile_url
+		FROM comments c
+		LEFT JOIN users u ON c.user_id = u.id
+		WHERE c.id = $1
+	`
+
+	var comment models.Comment
+	err := r.db.GetContext(ctx, &comment, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// CreateReport stores a report of a comment or podcast. It returns false without an error
+// when the user already reported the target.
+func (r *repository) CreateReport(ctx context.Context, report *models.ContentReport) (bool, error) {
+	query := `
+		INSERT INTO content_reports (
+			id, reporter_id, target_type, target_id, reason, details, status, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, 'open', $7
+		)
+		ON CONFLICT (reporter_id, target_type, target_id) DO NOTHING
+	`
+
+	if report.ID == uuid.Nil {
+		report.ID = uuid.New()
+	}
+	report.Status = "open"
+	report.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		report.ID,
+		report.ReporterID,
+		report.TargetType,
+		report.TargetID,
+		report.Reason,
+		report.Details,
+		report.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// reportSortColumns whitelists the columns reports can be sorted by
+var reportSortColumns = map[string]string{
+	"created_at":  "cr.created_at",
+	"reviewed_at": "cr.reviewed_at",
+	"reason":      "cr.reason",
+}
+
+// ListReports lists content reports matching the given filters, oldest first unless sorted otherwise
+func (r *repository) ListReports(ctx context.Context, params models.ReportListParams) ([]*models.ContentReport, int, error) {
+	sortColumn, ok := reportSortColumns[params.Sort]
+	if params.Sort == "" {
+		sortColumn, ok = reportSortColumns["created_at"], true
+	}
+	if !ok {
+		return nil, 0, models.ErrInvalidSortField
+	}
+	order := "ASC"
+	if strings.EqualFold(params.Order, "desc") {
+		order = "DESC"
+	}
+
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if params.Status != "" {
+		addCondition("cr.status = $%d", params.Status)
+	}
+	if params.TargetType != "" {
+		addCondition("cr.target_type = $%d", params.TargetType)
+	}
+	if params.TargetID != "" {
+		addCondition("cr.target_id = $%d", params.TargetID)
+	}
+	if !params.From.IsZero() {
+		addCondition("cr.created_at >= $%d", params.From)
+	}
+	if !params.To.IsZero() {
+		addCondition("cr.created_at < $%d", params.To)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Get total count
+	countQuery := "SELECT COUNT(*) FROM content_reports cr " + whereClause
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get reports with pagination
+	offset := (params.Page - 1) * params.PageSize
+	query := fmt.Sprintf(`
+		SELECT 
+			cr.id, cr.reporter_id, cr.target_type, cr.target_id, cr.reason, cr.details, cr.status,
+			cr.reviewed_by, cr.reviewed_at, cr.created_at,
+			u.username as reporter_username
+		FROM content_reports cr
+		JOIN users u ON cr.reporter_id = u.id
+		%s
+		ORDER BY %s %s, cr.id
+		LIMIT $%d OFFSET $%d
+	`, whereClause, sortColumn, order, len(args)+1, len(args)+2)
+
+	var reports []*models.ContentReport
+	err = r.db.SelectContext(ctx, &reports, query, append(args, params.PageSize, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reports, totalCount, nil
+}
+
+// GetReportCountsByStatus counts the reports of each status
+func (r *repository) GetReportCountsByStatus(ctx context.Context) (map[string]int, error) {
+	query := `SELECT status, COUNT(*) as count FROM content_reports GROUP BY status`
+
+	var rows []struct {
+		Status string `db:"status"`
+		Count  int    `db:"count"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{"open": 0, "reviewed": 0, "dismissed": 0}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+
+	return counts, nil
+}
+
+// ResolveReport closes an open report as reviewed or dismissed
+func (r *repository) ResolveReport(ctx context.Context, reportID uuid.UUID, status string, reviewerID uuid.UUID, auditEntry *auditModels.AuditEntry) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the report and make sure it is still open
+	var previousStatus string
+	err = tx.GetContext(ctx, &previousStatus, `SELECT status FROM content_reports WHERE id = $1 FOR UPDATE`, reportID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.ErrReportNotFound
+		}
+		return err
+	}
+
+	if previousStatus != "open" {
+		return models.ErrReportAlreadyResolved
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE content_reports SET status = $2, reviewed_by = $3, reviewed_at = $4 WHERE id = $1`,
+		reportID,
+		status,
+		reviewerID,
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if auditEntry != nil {
+		auditEntry.Before, err = json.Marshal(map[string]string{"status": previousStatus})
+		if err != nil {
+			return err
+		}
+		if err := r.audit.CreateEntryTx(ctx, tx, auditEntry); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CreateShortLink stores a new short link. It returns false without an error when
+// the code or the target is already taken, so the caller can look up or retry.
+func (r *repository) CreateShortLink(ctx context.Context, link *models.ShortLink) (bool, error) {
+	query := `
+		INSERT INTO short_links (
+			id, code, target_type, target_id, click_count, created_at
+		) VALUES (
+			$1, $2, $3, $4, 0, $5
+		)
+		ON CONFLICT DO NOTHING
+	`
+
+	if link.ID == uuid.Nil {
+		link.ID = uuid.New()
+	}
+	link.CreatedAt = time.Now().UTC()
+
+	result, err := r.db.ExecContext(ctx, query, link.ID, link.Code, link.TargetType, link.TargetID, link.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// GetShortLinkByCode gets a short link by its code
+func (r *repository) GetShortLinkByCode(ctx context.Context, code string) (*models.ShortLink, error) {
+	query := `
+		SELECT id, code, target_type, target_id, click_count, created_at
+		FROM short_links
+		WHERE code = $1
+	`
+
+	var link models.ShortLink
+	err := r.db.GetContext(ctx, &link, query, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrShortLinkNotFound
+		}
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// GetShortLinkByTarget gets the short link of an episode or podcast, or nil if it has none yet
+func (r *repository) GetShortLinkByTarget(ctx context.Context, targetType string, targetID uuid.UUID) (*models.ShortLink, error) {
+	query := `
+		SELECT id, code, target_type, target_id, click_count, created_at
+		FROM short_links
+		WHERE target_type = $1 AND target_id = $2
+	`
+
+	var link models.ShortLink
+	err := r.db.GetContext(ctx, &link, query, targetType, targetID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Return nil if not found, not an error
+		}
+		return nil, err
+	}
+
+	return &link, nil
+}
+
+// RecordShortLinkClick records a click and bumps the short link's click counter
+func (r *repository) RecordShortLinkClick(ctx context.Context, click *models.ShortLinkClick) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if click.ID == uuid.Nil {
+		click.ID = uuid.New()
+	}
+	click.CreatedAt = time.Now().UTC()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO short_link_clicks (id, short_link_id, referrer, user_agent, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		click.ID,
+		click.ShortLinkID,
+		click.Referrer,
+		click.UserAgent,
+		click.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE short_links SET click_count = click_count + 1 WHERE id = $1`, click.ShortLinkID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetHideExplicit gets whether a user chose to hide explicit content. Unknown users see everything.
+func (r *repository) GetHideExplicit(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var hideExplicit bool
+	err := r.db.GetContext(ctx, &hideExplicit, `SELECT hide_explicit FROM users WHERE id = $1`, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return hideExplicit, nil
+}
+
+// GetPreferredLanguage gets the language a user prefers content in, empty for unknown users
+func (r *repository) GetPreferredLanguage(ctx context.Context, userID uuid.UUID) (string, error) {
+	var preferredLanguage sql.NullString
+	err := r.db.GetContext(ctx, &preferredLanguage, `SELECT preferred_language FROM users WHERE id = $1`, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return preferredLanguage.String, nil
+}
+
+// SubscribeToPodcast subscribes a listener to a podcast, reporting false when they were already subscribed
+func (r *repository) SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error) {
+	query := `
+		INSERT INTO subscriptions (listener_id, podcast_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (listener_id, podcast_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, listenerID, podcastID, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UnsubscribeFromPodcast unsubscribes a listener from a podcast. Unsubscribing from a podcast
+// the listener wasn't subscribed to is not an error.
+func (r *repository) UnsubscribeFromPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error {
+	query := `DELETE FROM subscriptions WHERE listener_id = $1 AND podcast_id = $2`
+
+	_, err := r.db.ExecContext(ctx, query, listenerID, podcastID)
+	return err
+}
+
+// GetSubscribedPodcasts gets the podcasts a listener is subscribed to, most recently subscribed first.
+// Suspended and deleted podcasts are left out.
+func (r *repository) GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.Podcast, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM subscriptions sub
+		JOIN podcasts ON sub.podcast_id = podcasts.id
+		WHERE sub.listener_id = $1 AND podcasts.status NOT IN ('suspended', 'deleted')
+	`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, listenerID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT
+			podcasts.id, podcasts.podcaster_id, podcasts.title, podcasts.description, podcasts.cover_image_url, podcasts.rss_url, podcasts.website_url,
+			podcasts.language, podcasts.author, podcasts.category, podcasts.subcategory, podcasts.explicit, podcasts.status, podcasts.created_at, podcasts.updated_at,
+			podcasts.last_synced_at, podcasts.sync_failure_count, podcasts.next_sync_retry_at, podcasts.sync_suspended,
+			podcasts.pinned_episode_id, podcasts.prune_missing, podcasts.feed_etag, podcasts.feed_last_modified,
+			podcasts.cover_image_path, podcasts.cover_image_user_set, podcasts.feed_cover_image_url, podcasts.keywords, podcasts.sync_interval_minutes, podcasts.listen_count,
+			(SELECT COUNT(*) FROM episodes e WHERE e.podcast_id = podcasts.id AND e.status = 'active') AS episode_count,
+			(SELECT COUNT(*) FROM subscriptions s WHERE s.podcast_id = podcasts.id) AS subscriber_count
+		FROM subscriptions sub
+		JOIN podcasts ON sub.podcast_id = podcasts.id
+		WHERE sub.listener_id = $1 AND podcasts.status NOT IN ('suspended', 'deleted')
+		ORDER BY sub.created_at DESC, podcasts.id
+		LIMIT $2 OFFSET $3
+	`
+
+	podcasts := []*models.Podcast{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &podcasts, query, listenerID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return podcasts, totalCount, nil
+}
+
+// IsSubscribed checks if a listener is subscribed to a podcast
+func (r *repository) IsSubscribed(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM subscriptions
+			WHERE listener_id = $1 AND podcast_id = $2
+		)
+	`
+
+	var subscribed bool
+	err := r.db.GetContext(ctx, &subscribed, query, listenerID, podcastID)
+	return subscribed, err
+}
+
+// GetCategories gets all categories by name
+func (r *repository) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	query := `
+		SELECT id, name, COALESCE(description, '') AS description, COALESCE(icon_url, '') AS icon_url, created_at, updated_at
+		FROM categories
+		ORDER BY name
+	`
+
+	categories := []*models.Category{}
+	err := r.db.SelectContext(ctx, &categories, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// AssociatePodcastWithCategories replaces the categories of a podcast
+func (r *repository) AssociatePodcastWithCategories(ctx context.Context, podcastID uuid.UUID, categoryIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM podcast_categories WHERE podcast_id = $1`, podcastID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO podcast_categories (podcast_id, category_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT (podcast_id, category_id) DO NOTHING
+	`
+
+	_, err = tx.ExecContext(ctx, query, podcastID, pq.Array(categoryIDs))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetCategoriesByPodcastID gets the categories of a podcast by name
+func (r *repository) GetCategoriesByPodcastID(ctx context.Context, podcastID uuid.UUID) ([]*models.Category, error) {
+	query := `
+		SELECT c.id, c.name, COALESCE(c.description, '') AS description, COALESCE(c.icon_url, '') AS icon_url, c.created_at, c.updated_at
+		FROM categories c
+		JOIN podcast_categories pc ON c.id = pc.category_id
+		WHERE pc.podcast_id = $1
+		ORDER BY c.name
+	`
+
+	categories := []*models.Category{}
+	err := r.db.SelectContext(ctx, &categories, query, podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// CreateNewEpisodeNotifications notifies every subscriber of a podcast of its new episodes.
+// Episodes a subscriber was already notified of are skipped. Returns the number of notifications created.
+func (r *repository) CreateNewEpisodeNotifications(ctx context.Context, podcastID uuid.UUID, episodeIDs []uuid.UUID) (int, error) {
+	query := `
+		INSERT INTO notifications (user_id, type, podcast_id, episode_id, created_at)
+		SELECT s.listener_id, $3, s.podcast_id, e.id, $4
+		FROM subscriptions s
+		CROSS JOIN unnest($2::uuid[]) AS e(id)
+		WHERE s.podcast_id = $1
+		ON CONFLICT (user_id, type, episode_id) DO NOTHING
+	`
+
+	result, err := r.db.ExecContext(ctx, query, podcastID, pq.Array(episodeIDs), models.NotificationTypeNewEpisode, time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// GetNotifications gets a user's notifications, newest first. Notifications of episodes that
+// are no longer active are left out.
+func (r *repository) GetNotifications(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Notification, int, error) {
+	countQuery := `
+		SELECT COUNT(*)
+		FROM notifications n
+		JOIN episodes e ON n.episode_id = e.id
+		WHERE n.user_id = $1 AND e.status = 'active'
+	`
+
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, countQuery, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT
+			n.id, n.type, n.podcast_id, p.title as podcast_title, n.episode_id, e.title as episode_title,
+			COALESCE(e.cover_image_url, p.cover_image_url) as cover_image_url, n.read_at, n.created_at
+		FROM notifications n
+		JOIN episodes e ON n.episode_id = e.id
+		JOIN podcasts p ON n.podcast_id = p.id
+		WHERE n.user_id = $1 AND e.status = 'active'
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	notifications := []*models.Notification{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &notifications, query, userID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return notifications, totalCount, nil
+}
+
+// MarkNotificationRead marks one of a user's notifications as read. Notifications read
+// before keep the time they were first read.
+func (r *repository) MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND user_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, notificationID, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhook registers a webhook for a podcast
+func (r *repository) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	query := `
+		INSERT INTO podcast_webhooks (id, podcast_id, url, secret, events, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		webhook.ID, webhook.PodcastID, webhook.URL, webhook.Secret, webhook.Events, webhook.CreatedBy, webhook.CreatedAt)
+	return err
+}
+
+// GetWebhookByID gets a webhook by ID
+func (r *repository) GetWebhookByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	query := `
+		SELECT id, podcast_id, url, secret, events, created_by, created_at
+		FROM podcast_webhooks
+		WHERE id = $1
+	`
+
+	var webhook models.Webhook
+	err := r.db.GetContext(ctx, &webhook, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// GetWebhooksByPodcastID gets the webhooks of a podcast, oldest first
+func (r *repository) GetWebhooksByPodcastID(ctx context.Context, podcastID uuid.UUID) ([]*models.Webhook, error) {
+	query := `
+		SELECT id, podcast_id, url, secret, events, created_by, created_at
+		FROM podcast_webhooks
+		WHERE podcast_id = $1
+		ORDER BY created_at, id
+	`
+
+	webhooks := []*models.Webhook{}
+	err := r.db.SelectContext(ctx, &webhooks, query, podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook along with its delivery log
+func (r *repository) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM podcast_webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return models.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateWebhookDeliveries queues an event for every webhook of a podcast subscribed to it.
+// Returns the number of deliveries queued.
+func (r *repository) CreateWebhookDeliveries(ctx context.Context, podcastID uuid.UUID, event string, payload []byte) (int, error) {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event, payload, status, next_attempt_at, created_at)
+		SELECT id, $2, $3, 'pending', $4, $4
+		FROM podcast_webhooks
+		WHERE podcast_id = $1 AND $2 = ANY(events)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, podcastID, event, string(payload), time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
+
+// ClaimDueWebhookDeliveries gets pending deliveries due by now, with their webhook's URL and
+// secret. Claimed deliveries aren't due again before leaseUntil, so concurrent workers don't
+// send them twice, and a worker that dies mid-delivery only delays them.
+func (r *repository) ClaimDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d
+			SET next_attempt_at = $2
+			FROM due
+			WHERE d.id = due.id
+			RETURNING d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+				d.response_status, d.last_error, d.delivered_at, d.created_at
+		)
+		SELECT c.*, w.url, w.secret
+		FROM claimed c
+		JOIN podcast_webhooks w ON c.webhook_id = w.id
+		ORDER BY c.created_at
+	`
+
+	deliveries := []*models.WebhookDelivery{}
+	err := r.db.SelectContext(ctx, &deliveries, query, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt
+func (r *repository) UpdateWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, response_status = $5, last_error = $6, delivered_at = $7
+		WHERE id = $1
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt)
+	return err
+}
+
+// GetWebhookDeliveries gets the delivery log of a webhook, newest first
+func (r *repository) GetWebhookDeliveries(ctx context.Context, webhookID uuid.UUID, page, pageSize int) ([]*models.WebhookDelivery, int, error) {
+	var totalCount int
+	err := r.db.GetContext(ctx, &totalCount, `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1`, webhookID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT id, webhook_id, event, payload, status, attempts, next_attempt_at,
+			response_status, last_error, delivered_at, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	deliveries := []*models.WebhookDelivery{}
+	offset := (page - 1) * pageSize
+	err = r.db.SelectContext(ctx, &deliveries, query, webhookID, pageSize, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, totalCount, nil
+}
@@ -0,0 +1,282 @@
+// pkg/content/repository/postgres/repository_test.go
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// newMockRepository returns a repository backed by sqlmock, failing the test on unmet expectations
+func newMockRepository(t *testing.T) (*repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+
+	return &repository{db: sqlx.NewDb(db, "postgres")}, mock
+}
+
+// utcTime matches time arguments in UTC
+type utcTime struct{}
+
+func (utcTime) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && t.Location() == time.UTC
+}
+
+// inNonUTCZone runs the test as if the server's local zone were three hours ahead of UTC,
+// so that timestamps taken in the local zone are told apart from UTC ones
+func inNonUTCZone(t *testing.T) {
+	local := time.Local
+	time.Local = time.FixedZone("EAT", 3*60*60)
+	t.Cleanup(func() { time.Local = local })
+}
+
+func TestTimestampsAreStoredInUTC(t *testing.T) {
+	inNonUTCZone(t)
+	ctx := context.Background()
+	listenerID, podcastID, episodeID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("CreatePodcast", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		args := make([]driver.Value, 17)
+		for i := range args {
+			args[i] = sqlmock.AnyArg()
+		}
+		args[13], args[14] = utcTime{}, utcTime{} // created_at, updated_at
+		mock.ExpectQuery("INSERT INTO podcasts").WithArgs(args...).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(podcastID))
+
+		podcast := &models.Podcast{ID: podcastID, Title: "Nile Stories", Status: "active"}
+		if err := repo.CreatePodcast(ctx, podcast); err != nil {
+			t.Fatalf("CreatePodcast() error = %v", err)
+		}
+		if podcast.CreatedAt.Location() != time.UTC || podcast.UpdatedAt.Location() != time.UTC {
+			t.Errorf("podcast timestamps in %v/%v, want UTC", podcast.CreatedAt.Location(), podcast.UpdatedAt.Location())
+		}
+	})
+
+	t.Run("SubscribeToPodcast", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectExec("INSERT INTO subscriptions").WithArgs(listenerID, podcastID, utcTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if _, err := repo.SubscribeToPodcast(ctx, listenerID, podcastID); err != nil {
+			t.Fatalf("SubscribeToPodcast() error = %v", err)
+		}
+	})
+
+	t.Run("SavePlaybackPosition", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectExec("INSERT INTO playback_history").WithArgs(listenerID, episodeID, 120, false, utcTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.SavePlaybackPosition(ctx, listenerID, episodeID, 120, false); err != nil {
+			t.Fatalf("SavePlaybackPosition() error = %v", err)
+		}
+	})
+
+	t.Run("DeletePodcast", func(t *testing.T) {
+		repo, mock := newMockRepository(t)
+		mock.ExpectExec("UPDATE podcasts SET status = 'deleted'").WithArgs(podcastID, utcTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := repo.DeletePodcast(ctx, podcastID); err != nil {
+			t.Fatalf("DeletePodcast() error = %v", err)
+		}
+	})
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "single word", query: "tech", want: "tech:*"},
+		{name: "partial word", query: "techn", want: "techn:*"},
+		{name: "multiple words", query: "tech pod", want: "tech:* & pod:*"},
+		{name: "extra whitespace", query: "  Tech \t  Talk ", want: "Tech:* & Talk:*"},
+		{name: "tsquery operators", query: "tech & !pod | (news):*", want: "tech:* & pod:* & news:*"},
+		{name: "arabic", query: "بودكاست سوداني", want: "بودكاست:* & سوداني:*"},
+		{name: "digits", query: "episode 42", want: "episode:* & 42:*"},
+		{name: "no words", query: " !& ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := prefixTSQuery(tt.query); got != tt.want {
+				t.Errorf("prefixTSQuery(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListPodcastsSearch(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		wantTSQuery string
+		wantKeyword string
+		wantConfig  string
+	}{
+		{name: "multi-word", query: "Sudan  History", wantTSQuery: "Sudan:* & History:*", wantKeyword: "sudan history", wantConfig: "english"},
+		{name: "partial", query: "hist", wantTSQuery: "hist:*", wantKeyword: "hist", wantConfig: "english"},
+		{name: "arabic", query: "تاريخ السودان", wantTSQuery: "تاريخ:* & السودان:*", wantKeyword: "تاريخ السودان", wantConfig: "arabic"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mock := newMockRepository(t)
+
+			// Every word matches as a prefix, the whole query as a keyword, ranked by relevance
+			condition := regexp.QuoteMeta("(p.search_vector @@ to_tsquery('" + tt.wantConfig + "', $1) OR p.keywords @> ARRAY[$2::text])")
+			mock.ExpectQuery(`SELECT COUNT\(\*\) FROM podcasts p WHERE p.status = 'active' AND ` + condition).
+				WithArgs(tt.wantTSQuery, tt.wantKeyword).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectQuery(condition + `(?s:.*)` + regexp.QuoteMeta("ORDER BY ts_rank(p.search_vector, to_tsquery('"+tt.wantConfig+"', $1)) DESC")).
+				WithArgs(tt.wantTSQuery, tt.wantKeyword, 20, 0).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+			_, _, err := repo.ListPodcasts(context.Background(), models.PodcastSearchParams{Query: tt.query, Page: 1, PageSize: 20})
+			if err != nil {
+				t.Fatalf("ListPodcasts() error = %v", err)
+			}
+		})
+	}
+}
+
+var playlistColumns = []string{"id", "user_id", "name", "description", "is_public", "created_at", "updated_at", "episode_count"}
+
+func TestGetUserPlaylistsQueryCount(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	userID := uuid.New()
+	now := time.Now().UTC()
+
+	rows := sqlmock.NewRows(playlistColumns)
+	wantCounts := []int{3, 0, 12}
+	for i, count := range wantCounts {
+		rows.AddRow(uuid.New(), userID, "playlist", "", i == 0, now, now, count)
+	}
+
+	// One query lists the page with its episode counts and one counts the playlists, however many
+	// playlists there are. sqlmock fails the test on any other query.
+	mock.ExpectQuery(`COUNT\(pi.episode_id\) AS episode_count\s+FROM playlists p`).
+		WithArgs(userID, 20, 0).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM playlists WHERE user_id = \$1`).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(wantCounts)))
+
+	playlists, total, err := repo.GetUserPlaylists(context.Background(), userID, 1, 20)
+	if err != nil {
+		t.Fatalf("GetUserPlaylists() error = %v", err)
+	}
+	if total != len(wantCounts) || len(playlists) != len(wantCounts) {
+		t.Fatalf("GetUserPlaylists() = %d playlists of %d, want %d of %d", len(playlists), total, len(wantCounts), len(wantCounts))
+	}
+	for i, playlist := range playlists {
+		if playlist.EpisodeCount != wantCounts[i] {
+			t.Errorf("playlist %d EpisodeCount = %d, want %d", i, playlist.EpisodeCount, wantCounts[i])
+		}
+	}
+}
+
+func TestGetPlaylistByIDQueryCount(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	playlistID, userID := uuid.New(), uuid.New()
+	now := time.Now().UTC()
+
+	// The playlist and its episode count come from a single query
+	mock.ExpectQuery(`AS episode_count\s+FROM playlists\s+WHERE id = \$1`).
+		WithArgs(playlistID, userID).
+		WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(playlistID, userID, "playlist", "", false, now, now, 7))
+
+	playlist, err := repo.GetPlaylistByID(context.Background(), playlistID, userID)
+	if err != nil {
+		t.Fatalf("GetPlaylistByID() error = %v", err)
+	}
+	if playlist.EpisodeCount != 7 {
+		t.Errorf("EpisodeCount = %d, want 7", playlist.EpisodeCount)
+	}
+}
+
+func TestListPodcastsLanguage(t *testing.T) {
+	repo, mock := newMockRepository(t)
+
+	// The language matches itself and its regional varieties by prefix, not as a LIKE pattern
+	condition := regexp.QuoteMeta("(lower(p.language) = $1 OR starts_with(lower(p.language), $1 || '-'))")
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM podcasts p WHERE p.status = 'active' AND ` + condition).
+		WithArgs("ar").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(condition).
+		WithArgs("ar", 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, _, err := repo.ListPodcasts(context.Background(), models.PodcastSearchParams{Language: "AR", Page: 1, PageSize: 20})
+	if err != nil {
+		t.Fatalf("ListPodcasts() error = %v", err)
+	}
+}
+
+func TestListReportsSortAndDates(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	condition := regexp.QuoteMeta("WHERE cr.status = $1 AND cr.created_at >= $2 AND cr.created_at < $3")
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM content_reports cr ` + condition).
+		WithArgs("open", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(condition + `(?s:.*)` + regexp.QuoteMeta("ORDER BY cr.reviewed_at DESC, cr.id")).
+		WithArgs("open", from, to, 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	params := models.ReportListParams{Status: "open", From: from, To: to, Sort: "reviewed_at", Order: "desc", Page: 1, PageSize: 20}
+	if _, _, err := repo.ListReports(context.Background(), params); err != nil {
+		t.Fatalf("ListReports() error = %v", err)
+	}
+
+	// Columns outside the whitelist never reach the query
+	params.Sort = "reporter_id; DROP TABLE content_reports"
+	if _, _, err := repo.ListReports(context.Background(), params); !errors.Is(err, models.ErrInvalidSortField) {
+		t.Errorf("ListReports() error = %v, want %v", err, models.ErrInvalidSortField)
+	}
+}
+
+func TestArchiveEpisodesSkipsTakenDown(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	podcastID := uuid.New()
+
+	// Taken down episodes stay taken down rather than becoming archived
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("WHERE podcast_id = $1 AND status NOT IN ('archived', 'taken_down')")).
+		WithArgs(podcastID, utcTime{}).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	archived, err := repo.ArchiveEpisodes(context.Background(), podcastID, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ArchiveEpisodes() error = %v", err)
+	}
+	if archived != 0 {
+		t.Errorf("ArchiveEpisodes() = %d, want 0", archived)
+	}
+}
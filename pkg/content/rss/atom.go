@@ -0,0 +1,235 @@
+// pkg/content/rss/atom.go
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// Atom feed structures. Atom has author, image and category elements of its own, so the
+// itunes: ones are told apart by namespace.
+type atomFeed struct {
+	XMLName          xml.Name       `xml:"feed"`
+	Lang             string         `xml:"lang,attr"` // xml:lang
+	Title            atomText       `xml:"title"`
+	Subtitle         atomText       `xml:"subtitle"`
+	Links            []atomLink     `xml:"link"`
+	Icon             string         `xml:"icon"`
+	Logo             string         `xml:"logo"`
+	ItunesImage      itunesImage    `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	ItunesAuthor     string         `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd author"`
+	Author           atomPerson     `xml:"author"`
+	ItunesCategories []rssCategory  `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd category"`
+	Categories       []atomCategory `xml:"category"`
+	Explicit         string         `xml:"explicit"` // itunes:explicit
+	Keywords         string         `xml:"keywords"` // itunes:keywords
+	Entries          []atomEntry    `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID          string          `xml:"id"`
+	Title       atomText        `xml:"title"`
+	Links       []atomLink      `xml:"link"`
+	Published   string          `xml:"published"`
+	Updated     string          `xml:"updated"`
+	Content     atomText        `xml:"content"`
+	Summary     atomText        `xml:"summary"`
+	ItunesImage itunesImage     `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+	Duration    string          `xml:"duration"`    // itunes:duration
+	Episode     string          `xml:"episode"`     // itunes:episode
+	Season      string          `xml:"season"`      // itunes:season
+	EpisodeType string          `xml:"episodeType"` // itunes:episodeType
+	Transcripts []rssTranscript `xml:"transcript"`  // podcast:transcript
+	Chapters    rssChapters     `xml:"chapters"`    // podcast:chapters
+	Keywords    string          `xml:"keywords"`    // itunes:keywords
+	Explicit    string          `xml:"explicit"`    // itunes:explicit
+}
+
+// atomText is an Atom text construct, which holds plain text, escaped HTML or inline XHTML
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// String returns the text of an Atom text construct with any markup removed
+func (t atomText) String() string {
+	switch t.Type {
+	case "xhtml":
+		return cleanHTMLContent(t.Inner)
+	case "html":
+		return cleanHTMLContent(t.Text)
+	default:
+		return strings.TrimSpace(t.Text)
+	}
+}
+
+// parseAtomFeed parses an Atom feed document into the same structure as RSS feeds. Entries
+// are episodes when they have a link with rel="enclosure", and the entry ID is their GUID.
+func parseAtomFeed(body []byte, warnings []string) (*models.RSSFeed, error) {
+	var feed atomFeed
+	if err := newFeedDecoder(body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse feed XML: %w", err)
+	}
+
+	// Check if feed has content
+	title := feed.Title.String()
+	if title == "" {
+		return nil, ErrEmptyFeed
+	}
+
+	result := &models.RSSFeed{
+		Title:       title,
+		Description: feed.Subtitle.String(),
+		Language:    feed.Lang,
+		WebsiteURL:  atomAlternateLink(feed.Links),
+		Explicit:    parseBooleanString(feed.Explicit),
+		Keywords:    parseKeywords(feed.Keywords),
+		Warnings:    warnings,
+	}
+
+	// Prefer the iTunes categories, as for RSS feeds
+	if len(feed.ItunesCategories) > 0 {
+		mainCategory := feed.ItunesCategories[0]
+		if mainCategory.AttrText != "" {
+			result.Category = mainCategory.AttrText
+		} else if mainCategory.Text != "" {
+			result.Category = mainCategory.Text
+		}
+		if mainCategory.Category != nil && mainCategory.Category.Text != "" {
+			result.Subcategory = mainCategory.Category.Text
+		}
+	} else if len(feed.Categories) > 0 {
+		if feed.Categories[0].Label != "" {
+			result.Category = feed.Categories[0].Label
+		} else {
+			result.Category = feed.Categories[0].Term
+		}
+	}
+
+	// Set the author from various possible fields
+	if feed.ItunesAuthor != "" {
+		result.Author = feed.ItunesAuthor
+	} else if feed.Author.Name != "" {
+		result.Author = strings.TrimSpace(feed.Author.Name)
+	} else {
+		result.Author = title // Fallback to title
+	}
+
+	// Get cover image URL
+	if feed.ItunesImage.Href != "" {
+		result.CoverImageURL = feed.ItunesImage.Href
+	} else if feed.Logo != "" {
+		result.CoverImageURL = strings.TrimSpace(feed.Logo)
+	} else if feed.Icon != "" {
+		result.CoverImageURL = strings.TrimSpace(feed.Icon)
+	}
+
+	// Parse episodes
+	var problems itemProblems
+	result.Items = make([]models.RSSFeedItem, 0, len(feed.Entries))
+	for _, entry := range feed.Entries {
+		enclosure, alternates, ok := selectEnclosure(parseEnclosures(atomEnclosures(entry.Links)))
+		guid := strings.TrimSpace(entry.ID)
+
+		// Skip entries without enclosures or IDs
+		if !ok {
+			problems.missingEnclosure++
+			continue
+		}
+		if guid == "" {
+			problems.missingGUID++
+			continue
+		}
+
+		episode := models.RSSFeedItem{
+			Title:               entry.Title.String(),
+			GUID:                guid,
+			AudioURL:            enclosure.URL,
+			Enclosure:           enclosure,
+			AlternateEnclosures: alternates,
+		}
+
+		// Atom content is the full entry text; the summary is optional
+		if content := entry.Content.String(); content != "" {
+			episode.Description = content
+		} else {
+			episode.Description = entry.Summary.String()
+		}
+
+		episode.Duration = parseDuration(entry.Duration)
+
+		// Atom entries must have an updated date, and may also have the date they were first published
+		pubDate, err := parsePubDate(strings.TrimSpace(entry.Published))
+		if err != nil {
+			pubDate, err = parsePubDate(strings.TrimSpace(entry.Updated))
+		}
+		if err == nil {
+			episode.PublicationDate = pubDate.UTC()
+		} else {
+			episode.PublicationDate = time.Now().UTC() // Fallback to current time
+			problems.badPubDate++
+		}
+
+		// Get episode cover image
+		if entry.ItunesImage.Href != "" {
+			episode.CoverImageURL = entry.ItunesImage.Href
+		} else {
+			episode.CoverImageURL = result.CoverImageURL // Fallback to podcast image
+		}
+
+		episode.EpisodeNumber = parseItemNumber(entry.Episode)
+		episode.SeasonNumber = parseItemNumber(entry.Season)
+		episode.EpisodeType = parseEpisodeType(entry.EpisodeType)
+		episode.Transcripts = parseTranscripts(entry.Transcripts)
+		episode.ChaptersURL = parseChaptersURL(entry.Chapters)
+		episode.Keywords = parseKeywords(entry.Keywords)
+		episode.Explicit = parseItemExplicit(entry.Explicit, result.Explicit)
+
+		result.Items = append(result.Items, episode)
+	}
+	result.Warnings = append(result.Warnings, problems.warnings()...)
+
+	return result, nil
+}
+
+// atomAlternateLink returns the website link of an Atom feed. Links without a rel are alternate links.
+func atomAlternateLink(links []atomLink) string {
+	for _, link := range links {
+		if (link.Rel == "" || link.Rel == "alternate") && link.Href != "" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// atomEnclosures returns the media links of an Atom entry as enclosures
+func atomEnclosures(links []atomLink) []rssEnclosure {
+	var enclosures []rssEnclosure
+	for _, link := range links {
+		if link.Rel == "enclosure" {
+			enclosures = append(enclosures, rssEnclosure{URL: link.Href, Length: link.Length, Type: link.Type})
+		}
+	}
+	return enclosures
+}
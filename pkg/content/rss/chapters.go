@@ -0,0 +1,94 @@
+// pkg/content/rss/chapters.go
+package rss
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// chaptersContentType is the MIME type of Podcasting 2.0 JSON chapters files
+const chaptersContentType = "application/json+chapters"
+
+// Limits on chapters files, which are fetched from podcast hosts on every new episode
+const (
+	maxChaptersFileSize = 1 << 20
+	maxChapters         = 500
+)
+
+// JSON chapters file structures
+type chaptersFile struct {
+	Chapters []chaptersFileChapter `json:"chapters"`
+}
+
+type chaptersFileChapter struct {
+	StartTime float64 `json:"startTime"`
+	Title     string  `json:"title"`
+	Img       string  `json:"img"`
+	URL       string  `json:"url"`
+	TOC       *bool   `json:"toc"` // false for chapters hidden from the table of contents
+}
+
+// FetchChapters fetches and parses a podcast:chapters JSON file. Chapters hidden from the table
+// of contents are left out, and the rest are returned in playback order.
+func (p *parser) FetchChapters(ctx context.Context, url string) (models.Chapters, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Sudanese Podcast Platform RSS Parser/1.0")
+	req.Header.Set("Accept", chaptersContentType+", application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch chapters: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxChaptersFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chapters: %w", err)
+	}
+	if len(body) > maxChaptersFileSize {
+		return nil, fmt.Errorf("chapters file is larger than %d bytes", maxChaptersFileSize)
+	}
+
+	var file chaptersFile
+	if err := json.Unmarshal(body, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse chapters: %w", err)
+	}
+
+	chapters := make(models.Chapters, 0, len(file.Chapters))
+	for _, chapter := range file.Chapters {
+		if chapter.TOC != nil && !*chapter.TOC {
+			continue
+		}
+		if chapter.StartTime < 0 {
+			continue
+		}
+		chapters = append(chapters, models.Chapter{
+			StartTime: chapter.StartTime,
+			Title:     strings.TrimSpace(chapter.Title),
+			ImageURL:  chapter.Img,
+			URL:       chapter.URL,
+		})
+		if len(chapters) == maxChapters {
+			break
+		}
+	}
+	sort.SliceStable(chapters, func(i, j int) bool {
+		return chapters[i].StartTime < chapters[j].StartTime
+	})
+
+	return chapters, nil
+}
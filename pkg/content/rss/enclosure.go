@@ -0,0 +1,119 @@
+// pkg/content/rss/enclosure.go
+package rss
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// enclosureTypePriority ranks the audio formats feeds offer side by side, most preferred first.
+// MP3 plays everywhere, so it wins over AAC and Opus versions of the same episode.
+var enclosureTypePriority = []string{
+	"audio/mpeg",
+	"audio/mp3",
+	"audio/mp4",
+	"audio/x-m4a",
+	"audio/m4a",
+	"audio/aac",
+	"audio/ogg",
+	"audio/opus",
+}
+
+// enclosureExtensionTypes guesses the MIME type of enclosures that don't give one
+var enclosureExtensionTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".aac":  "audio/aac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/opus",
+	".mp4":  "video/mp4",
+}
+
+// parseEnclosures converts enclosure tags, skipping those without a URL
+func parseEnclosures(enclosures []rssEnclosure) []models.Enclosure {
+	parsed := make([]models.Enclosure, 0, len(enclosures))
+	for _, enclosure := range enclosures {
+		url := strings.TrimSpace(enclosure.URL)
+		if url == "" {
+			continue
+		}
+		parsed = append(parsed, models.Enclosure{
+			URL:    url,
+			Type:   enclosureType(enclosure.Type, url),
+			Length: parseEnclosureLength(enclosure.Length),
+		})
+	}
+	return parsed
+}
+
+// selectEnclosure picks the enclosure to play from those of a feed item, returning the
+// others as alternates. Ties go to the enclosure listed first.
+func selectEnclosure(enclosures []models.Enclosure) (models.Enclosure, models.Enclosures, bool) {
+	if len(enclosures) == 0 {
+		return models.Enclosure{}, nil, false
+	}
+
+	best := 0
+	for i := 1; i < len(enclosures); i++ {
+		if enclosureRank(enclosures[i]) < enclosureRank(enclosures[best]) {
+			best = i
+		}
+	}
+
+	var alternates models.Enclosures
+	for i, enclosure := range enclosures {
+		if i != best {
+			alternates = append(alternates, enclosure)
+		}
+	}
+	return enclosures[best], alternates, true
+}
+
+// enclosureRank orders enclosures by format: the listed audio types first, then any other
+// audio, then video, then anything else
+func enclosureRank(enclosure models.Enclosure) int {
+	for i, enclosureType := range enclosureTypePriority {
+		if enclosure.Type == enclosureType {
+			return i
+		}
+	}
+	switch {
+	case strings.HasPrefix(enclosure.Type, "audio/"):
+		return len(enclosureTypePriority)
+	case strings.HasPrefix(enclosure.Type, "video/"):
+		return len(enclosureTypePriority) + 1
+	default:
+		return len(enclosureTypePriority) + 2
+	}
+}
+
+// enclosureType normalizes an enclosure's MIME type, guessing it from the URL's extension
+// when the feed leaves it out
+func enclosureType(mimeType, url string) string {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if i := strings.IndexByte(mimeType, ';'); i >= 0 {
+		mimeType = strings.TrimSpace(mimeType[:i])
+	}
+	if mimeType != "" {
+		return mimeType
+	}
+
+	if i := strings.IndexAny(url, "?#"); i >= 0 {
+		url = url[:i]
+	}
+	return enclosureExtensionTypes[strings.ToLower(path.Ext(url))]
+}
+
+// parseEnclosureLength parses an enclosure's length in bytes. Feeds often leave it out or
+// put garbage there, which counts as unknown.
+func parseEnclosureLength(length string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(length), 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,51 @@
+// pkg/content/rss/opml.go
+package rss
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// OPML document structures
+type opmlDocument struct {
+	XMLName xml.Name    `xml:"opml"`
+	Body    opmlOutline `xml:"body"`
+}
+
+type opmlOutline struct {
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ParseOPML reads an OPML subscription list and returns the feed URLs of its
+// outlines, in document order and without duplicates. Outlines may be nested
+// in folders; those without an xmlUrl attribute are ignored.
+func ParseOPML(r io.Reader) ([]string, error) {
+	var doc opmlDocument
+	decoder := xml.NewDecoder(r)
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OPML: %w", err)
+	}
+
+	var feedURLs []string
+	seen := make(map[string]bool)
+	var collect func(outlines []opmlOutline)
+	collect = func(outlines []opmlOutline) {
+		for _, outline := range outlines {
+			feedURL := strings.TrimSpace(outline.XMLURL)
+			if feedURL != "" && !seen[feedURL] {
+				seen[feedURL] = true
+				feedURLs = append(feedURLs, feedURL)
+			}
+			collect(outline.Outlines)
+		}
+	}
+	collect(doc.Body.Outlines)
+
+	return feedURLs, nil
+}
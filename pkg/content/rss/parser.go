@@ -3,38 +3,166 @@ package rss
 
 import (
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/publicnet"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"golang.org/x/net/html/charset"
 )
 
+// ErrNotModified is returned by ParseFeedConditional when the feed has not changed
+// since the fetch its cache validators came from
+var ErrNotModified = errors.New("feed not modified")
+
+// ErrFeedGone is returned when the feed server answers 410 Gone, i.e. the feed was
+// deliberately taken down and won't come back
+var ErrFeedGone = errors.New("feed is gone")
+
+// ErrEmptyFeed is returned when the feed parses but has no podcast content
+var ErrEmptyFeed = errors.New("feed has no content or is not a valid podcast feed")
+
+// StatusError is returned when the feed server answers with an unexpected HTTP status
+type StatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("feed request failed with status: %s", e.Status)
+}
+
+// fetchError marks failures to reach the feed server at all, e.g. DNS errors or refused connections
+type fetchError struct {
+	err error
+}
+
+func (e *fetchError) Error() string {
+	return fmt.Sprintf("failed to fetch RSS feed: %v", e.err)
+}
+
+func (e *fetchError) Unwrap() error {
+	return e.err
+}
+
+// FailureReason classifies an error returned by the parser as one of the sync failure
+// reasons, along with the HTTP status the feed server answered with, if any
+func FailureReason(err error) (string, int) {
+	var statusErr *StatusError
+	var netErr net.Error
+	var fetchErr *fetchError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return models.SyncFailureTimeout, 0
+	case errors.As(err, &statusErr):
+		return models.SyncFailureHTTPError, statusErr.StatusCode
+	case errors.Is(err, ErrFeedGone):
+		return models.SyncFailureHTTPError, http.StatusGone
+	case errors.As(err, &fetchErr):
+		return models.SyncFailureHTTPError, 0
+	case errors.Is(err, ErrEmptyFeed):
+		return models.SyncFailureEmptyFeed, 0
+	default:
+		return models.SyncFailureParseError, 0
+	}
+}
+
+// DescribeFailure describes why a feed couldn't be fetched or parsed without echoing the
+// error itself, which could reveal what answers at an address the platform can reach
+func DescribeFailure(err error) string {
+	reason, httpStatus := FailureReason(err)
+	switch {
+	case reason == models.SyncFailureTimeout:
+		return "the feed host took too long to respond"
+	case reason == models.SyncFailureHTTPError && httpStatus != 0:
+		return fmt.Sprintf("the feed host answered with HTTP %d", httpStatus)
+	case reason == models.SyncFailureHTTPError:
+		return "the feed host couldn't be reached"
+	case reason == models.SyncFailureEmptyFeed:
+		return "the feed has no podcast content"
+	default:
+		return "the feed is not a readable RSS or Atom document"
+	}
+}
+
 // Parser defines the interface for RSS feed parser
 type Parser interface {
 	ParseFeed(ctx context.Context, url string) (*models.RSSFeed, error)
+	ParseFeedConditional(ctx context.Context, url string, validators models.FeedValidators) (*models.RSSFeed, models.FeedValidators, error)
+	FetchChapters(ctx context.Context, url string) (models.Chapters, error)
+	FetchTranscript(ctx context.Context, link models.TranscriptLink) ([]models.TranscriptSegment, error)
 }
 
 type parser struct {
 	httpClient *http.Client
 }
 
-// NewParser creates a new RSS feed parser
+// maxRedirects is the longest redirect chain followed to reach a feed, as for http.Client's default policy
+const maxRedirects = 10
+
+// maxFeedSize bounds feeds as fetched and once decompressed, so a small gzip bomb can't exhaust memory
+const maxFeedSize = 20 << 20
+
+// NewParser creates a new RSS feed parser. Feed URLs come from users, so feeds, chapters and
+// transcripts are only fetched from public addresses, never from the platform's own network.
 func NewParser(timeout time.Duration) Parser {
+	return newParser(timeout, publicnet.NewTransport(30*time.Second))
+}
+
+// newParser creates a parser fetching through the given transport
+func newParser(timeout time.Duration, transport http.RoundTripper) *parser {
 	return &parser{
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:       timeout,
+			Transport:     transport,
+			CheckRedirect: checkRedirect,
 		},
 	}
 }
 
+// redirectTraceKey is the context key of the redirect trace of a feed request
+type redirectTraceKey struct{}
+
+// redirectTrace records whether every redirect followed by a feed request was permanent
+type redirectTrace struct {
+	redirected bool
+	permanent  bool
+}
+
+// checkRedirect follows redirects like the default policy, noting in the request's trace
+// whether they were all permanent (301 or 308)
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if trace, ok := req.Context().Value(redirectTraceKey{}).(*redirectTrace); ok && req.Response != nil {
+		status := req.Response.StatusCode
+		permanent := status == http.StatusMovedPermanently || status == http.StatusPermanentRedirect
+		if !trace.redirected {
+			trace.permanent = permanent
+		} else {
+			trace.permanent = trace.permanent && permanent
+		}
+		trace.redirected = true
+	}
+	return nil
+}
+
 // RSS feed structures
 type rssChannel struct {
 	XMLName     xml.Name    `xml:"channel"`
@@ -51,11 +179,12 @@ type rssChannel struct {
 	Author      string      `xml:"author"`
 	Owner       rssOwner    `xml:"itunes:owner"`
 	Categories  []rssCategory `xml:"itunes:category"`
-	Explicit    string      `xml:"itunes:explicit"`
+	Explicit    string      `xml:"explicit"` // itunes:explicit
 	Items       []rssItem   `xml:"item"`
 	ItunesImage itunesImage `xml:"itunes:image"`
 	ItunesAuthor string   `xml:"itunes:author"`
 	ItunesSummary string   `xml:"itunes:summary"`
+	Keywords    string      `xml:"keywords"` // itunes:keywords
 }
 
 type rssFeed struct {
@@ -94,6 +223,18 @@ type rssEnclosure struct {
 	Type   string `xml:"type,attr"`
 }
 
+type rssTranscript struct {
+	URL      string `xml:"url,attr"`
+	Type     string `xml:"type,attr"`
+	Language string `xml:"language,attr"`
+	Rel      string `xml:"rel,attr"`
+}
+
+type rssChapters struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
 type rssItem struct {
 	Title           string        `xml:"title"`
 	Description     string        `xml:"description"`
@@ -104,55 +245,137 @@ type rssItem struct {
 	Author          string        `xml:"itunes:author"`
 	Subtitle        string        `xml:"itunes:subtitle"`
 	Summary         string        `xml:"itunes:summary"`
-	Enclosure       rssEnclosure  `xml:"enclosure"`
+	Enclosures      []rssEnclosure `xml:"enclosure"` // usually one, some feeds offer several formats
 	ItunesImage     itunesImage   `xml:"itunes:image"`
 	ItunesEpisode   string        `xml:"itunes:episode"`
 	ItunesSeason    string        `xml:"itunes:season"`
+	EpisodeType     string        `xml:"episodeType"` // itunes:episodeType, matched by local name since the prefix resolves to a namespace URL
+	Transcripts     []rssTranscript `xml:"transcript"` // podcast:transcript, one per format
+	Chapters        rssChapters   `xml:"chapters"`   // podcast:chapters
 	Content         string        `xml:"content:encoded"`
-	Explicit        string        `xml:"itunes:explicit"`
+	Explicit        string        `xml:"explicit"`   // itunes:explicit
+	Keywords        string        `xml:"keywords"`   // itunes:keywords
 }
 
 // ParseFeed parses an RSS feed from a URL
 func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, error) {
+	feed, _, err := p.ParseFeedConditional(ctx, url, models.FeedValidators{})
+	return feed, err
+}
+
+// ParseFeedConditional parses an RSS feed from a URL unless it is unchanged since the
+// fetch the given cache validators came from, in which case ErrNotModified is returned.
+// The validators of the fetched feed are returned for the next fetch, along with the new
+// feed URL when the request was permanently redirected.
+func (p *parser) ParseFeedConditional(ctx context.Context, url string, validators models.FeedValidators) (*models.RSSFeed, models.FeedValidators, error) {
+	// Trace redirects to tell when the feed has moved for good
+	trace := &redirectTrace{}
+	ctx = context.WithValue(ctx, redirectTraceKey{}, trace)
+
 	// Create a request with the provided context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, validators, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set appropriate headers
 	req.Header.Set("User-Agent", "Sudanese Podcast Platform RSS Parser/1.0")
 	req.Header.Set("Accept", "application/rss+xml, application/xml, text/xml")
+	// Asking for an encoding explicitly turns off the transport's transparent gzip
+	// handling, so the response is decompressed by decodeBody instead
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
 
 	// Make the request
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch RSS feed: %w", err)
+		return nil, validators, &fetchError{err: err}
 	}
 	defer resp.Body.Close()
 
+	// Report the final location when only permanent redirects led there, so it is used from now on
+	movedTo := ""
+	if trace.redirected && trace.permanent && resp.Request.URL.String() != url {
+		movedTo = resp.Request.URL.String()
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		validators.MovedTo = movedTo
+		return nil, validators, ErrNotModified
+	}
+
+	if resp.StatusCode == http.StatusGone {
+		return nil, validators, fmt.Errorf("%w: %s", ErrFeedGone, resp.Status)
+	}
+
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("feed request failed with status: %s", resp.Status)
+		return nil, validators, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 
 	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read feed body: %w", err)
+		return nil, validators, fmt.Errorf("failed to read feed body: %w", err)
+	}
+	if len(body) > maxFeedSize {
+		return nil, validators, fmt.Errorf("feed is larger than %d bytes", maxFeedSize)
+	}
+
+	body, err = decodeBody(body, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		return nil, validators, err
+	}
+
+	feed, err := parseFeedBody(body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, validators, err
+	}
+
+	return feed, models.FeedValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		MovedTo:      movedTo,
+	}, nil
+}
+
+// parseFeedBody parses a fetched feed document served with the given content type
+func parseFeedBody(body []byte, contentType string) (*models.RSSFeed, error) {
+	// Misconfigured hosts serve valid feeds with odd content types, so only warn about
+	// them, but fail fast on HTML pages (login walls, error pages) instead of reporting
+	// a confusing XML parse error
+	if looksLikeHTML(body) {
+		return nil, fmt.Errorf("feed returned an HTML page instead of XML (content type %q)", contentType)
+	}
+
+	var warnings []string
+	if !isXMLContentType(contentType) {
+		warnings = append(warnings, fmt.Sprintf("feed served with unexpected content type %q", contentType))
+	}
+
+	// Atom feeds share nothing with RSS beyond being XML, so they get a parser of their own
+	root, err := feedRootElement(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed XML: %w", err)
+	}
+	if root == "feed" {
+		return parseAtomFeed(body, warnings)
 	}
 
 	// Parse the XML
 	var feed rssFeed
-	decoder := xml.NewDecoder(bytes.NewReader(body))
-	decoder.Strict = false // Be lenient with XML parsing errors
-	if err := decoder.Decode(&feed); err != nil {
+	if err := newFeedDecoder(body).Decode(&feed); err != nil {
 		return nil, fmt.Errorf("failed to parse feed XML: %w", err)
 	}
 
 	// Check if feed has content
 	if feed.Channel.Title == "" {
-		return nil, errors.New("feed has no content or is not a valid podcast feed")
+		return nil, ErrEmptyFeed
 	}
 
 	// Convert RSS feed to our model
@@ -162,6 +385,8 @@ func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, er
 		Language:     feed.Channel.Language,
 		WebsiteURL:   feed.Channel.Link,
 		Explicit:     parseBooleanString(feed.Channel.Explicit),
+		Keywords:     parseKeywords(feed.Channel.Keywords),
+		Warnings:     warnings,
 	}
 
 	// Get main category and subcategory
@@ -200,10 +425,17 @@ func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, er
 	}
 
 	// Parse episodes
+	var problems itemProblems
 	result.Items = make([]models.RSSFeedItem, 0, len(feed.Channel.Items))
 	for _, item := range feed.Channel.Items {
 		// Skip items without enclosures or GUIDs
-		if item.Enclosure.URL == "" || item.Guid == "" {
+		enclosure, alternates, ok := selectEnclosure(parseEnclosures(item.Enclosures))
+		if !ok {
+			problems.missingEnclosure++
+			continue
+		}
+		if item.Guid == "" {
+			problems.missingGUID++
 			continue
 		}
 		
@@ -211,7 +443,9 @@ func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, er
 		episode := models.RSSFeedItem{
 			Title:       item.Title,
 			GUID:        item.Guid,
-			AudioURL:    item.Enclosure.URL,
+			AudioURL:    enclosure.URL,
+			Enclosure:   enclosure,
+			AlternateEnclosures: alternates,
 		}
 		
 		// Get description from various possible fields
@@ -232,9 +466,10 @@ func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, er
 		// Parse publication date
 		pubDate, err := parsePubDate(item.PubDate)
 		if err == nil {
-			episode.PublicationDate = pubDate
+			episode.PublicationDate = pubDate.UTC()
 		} else {
-			episode.PublicationDate = time.Now() // Fallback to current time
+			episode.PublicationDate = time.Now().UTC() // Fallback to current time
+			problems.badPubDate++
 		}
 		
 		// Get episode cover image
@@ -245,26 +480,165 @@ func (p *parser) ParseFeed(ctx context.Context, url string) (*models.RSSFeed, er
 		}
 		
 		// Parse episode and season numbers
-		if item.ItunesEpisode != "" {
-			episodeNum, err := strconv.Atoi(item.ItunesEpisode)
-			if err == nil {
-				episode.EpisodeNumber = &episodeNum
-			}
-		}
+		episode.EpisodeNumber = parseItemNumber(item.ItunesEpisode)
+		episode.SeasonNumber = parseItemNumber(item.ItunesSeason)
 		
-		if item.ItunesSeason != "" {
-			seasonNum, err := strconv.Atoi(item.ItunesSeason)
-			if err == nil {
-				episode.SeasonNumber = &seasonNum
-			}
-		}
+		episode.EpisodeType = parseEpisodeType(item.EpisodeType)
+		episode.Transcripts = parseTranscripts(item.Transcripts)
+		episode.ChaptersURL = parseChaptersURL(item.Chapters)
+		episode.Keywords = parseKeywords(item.Keywords)
+		episode.Explicit = parseItemExplicit(item.Explicit, result.Explicit)
 		
 		result.Items = append(result.Items, episode)
 	}
+	result.Warnings = append(result.Warnings, problems.warnings()...)
 
 	return result, nil
 }
 
+// itemProblems counts the feed items that were skipped or only partly understood, so that
+// podcasters can fix their feeds
+type itemProblems struct {
+	missingEnclosure int
+	missingGUID      int
+	badPubDate       int
+}
+
+// warnings describes the problems, one warning per kind of problem
+func (p itemProblems) warnings() []string {
+	var warnings []string
+	if p.missingEnclosure > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d item(s) skipped for having no audio enclosure", p.missingEnclosure))
+	}
+	if p.missingGUID > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d item(s) skipped for having no GUID", p.missingGUID))
+	}
+	if p.badPubDate > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d item(s) have a missing or unparseable publication date", p.badPubDate))
+	}
+	return warnings
+}
+
+// newFeedDecoder creates the XML decoder feed documents are read with
+func newFeedDecoder(body []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false // Be lenient with XML parsing errors
+	decoder.CharsetReader = charset.NewReaderLabel // Convert feeds declaring e.g. ISO-8859-1 or windows-1252 to UTF-8
+	return decoder
+}
+
+// feedRootElement returns the local name of a feed document's root element, e.g. "rss" or "feed"
+func feedRootElement(body []byte) (string, error) {
+	decoder := newFeedDecoder(body)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// parseItemNumber parses itunes:episode and itunes:season, which are missing on many feeds
+func parseItemNumber(value string) *int {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+// Limits on itunes:keywords, which some feeds stuff with whole sentences
+const (
+	maxKeywords      = 20
+	maxKeywordLength = 50
+)
+
+// parseKeywords splits comma-separated itunes:keywords into distinct lowercased keywords
+func parseKeywords(value string) models.Keywords {
+	var keywords models.Keywords
+	seen := make(map[string]bool)
+	for _, keyword := range strings.Split(value, ",") {
+		keyword = strings.ToLower(strings.Join(strings.Fields(keyword), " "))
+		if keyword == "" || seen[keyword] || utf8.RuneCountInString(keyword) > maxKeywordLength {
+			continue
+		}
+		seen[keyword] = true
+		keywords = append(keywords, keyword)
+		if len(keywords) == maxKeywords {
+			break
+		}
+	}
+	return keywords
+}
+
+// parseTranscripts keeps every podcast:transcript format so clients can pick the one they support
+func parseTranscripts(transcripts []rssTranscript) models.TranscriptLinks {
+	var links models.TranscriptLinks
+	for _, transcript := range transcripts {
+		if transcript.URL == "" {
+			continue
+		}
+		links = append(links, models.TranscriptLink{
+			URL:      transcript.URL,
+			Type:     transcript.Type,
+			Language: transcript.Language,
+			Rel:      transcript.Rel,
+		})
+	}
+	return links
+}
+
+// parseChaptersURL returns the URL of a podcast:chapters file. Only JSON chapters are
+// supported; the file is fetched separately.
+func parseChaptersURL(chapters rssChapters) string {
+	if chapters.URL != "" && (chapters.Type == "" || chapters.Type == chaptersContentType) {
+		return chapters.URL
+	}
+	return ""
+}
+
+// decodeBody decompresses a response body according to its Content-Encoding header
+func decodeBody(body []byte, contentEncoding string) ([]byte, error) {
+	var reader io.Reader
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		gzipReader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip feed: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case "deflate":
+		// "deflate" is meant to be zlib-wrapped, but some servers send a raw deflate stream
+		zlibReader, err := zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			reader = flate.NewReader(bytes.NewReader(body))
+		} else {
+			defer zlibReader.Close()
+			reader = zlibReader
+		}
+	default:
+		return nil, fmt.Errorf("feed served with unsupported content encoding %q", contentEncoding)
+	}
+
+	decoded, err := io.ReadAll(io.LimitReader(reader, maxFeedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress feed: %w", err)
+	}
+	if len(decoded) > maxFeedSize {
+		return nil, fmt.Errorf("decompressed feed is larger than %d bytes", maxFeedSize)
+	}
+	return decoded, nil
+}
+
 // parseDuration parses a duration string in various formats
 // (e.g. "HH:MM:SS", "MM:SS", or seconds) to seconds
 func parseDuration(duration string) int {
@@ -313,6 +687,7 @@ func parsePubDate(pubDate string) (time.Time, error) {
 		"Mon, 02 Jan 2006 15:04:05 -0700",
 		"Mon, 2 Jan 2006 15:04:05 -0700",
 		"2006-01-02T15:04:05-07:00",
+		time.RFC3339, // Atom dates, which may end in Z and have fractional seconds
 		"2006-01-02 15:04:05",
 	}
 
@@ -326,10 +701,28 @@ func parsePubDate(pubDate string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("could not parse date: %s", pubDate)
 }
 
+// parseEpisodeType normalizes itunes:episodeType, treating missing or unknown types as "full"
+func parseEpisodeType(episodeType string) string {
+	switch episodeType = strings.ToLower(strings.TrimSpace(episodeType)); episodeType {
+	case "trailer", "bonus":
+		return episodeType
+	default:
+		return "full"
+	}
+}
+
 // parseBooleanString parses itunes:explicit and similar boolean strings
 func parseBooleanString(s string) bool {
-	s = strings.ToLower(s)
-	return s == "yes" || s == "true" || s == "1"
+	s = strings.ToLower(strings.TrimSpace(s))
+	return s == "yes" || s == "true" || s == "1" || s == "explicit"
+}
+
+// parseItemExplicit parses an item's itunes:explicit, which items without one inherit from the feed
+func parseItemExplicit(value string, feedExplicit bool) bool {
+	if strings.TrimSpace(value) == "" {
+		return feedExplicit
+	}
+	return parseBooleanString(value)
 }
 
 // cleanHTMLContent removes HTML tags from a string
@@ -366,4 +759,24 @@ func decodeHTMLEntities(content string) string {
 	}
 	
 	return content
+}
+
+// isXMLContentType reports whether a Content-Type header names an XML type such as
+// application/rss+xml or text/xml
+func isXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "text/xml" || mediaType == "application/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// looksLikeHTML reports whether a response body is an HTML document rather than a feed
+func looksLikeHTML(body []byte) bool {
+	start := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(start) > 512 {
+		start = start[:512]
+	}
+	start = bytes.ToLower(start)
+	return bytes.HasPrefix(start, []byte("<!doctype html")) || bytes.HasPrefix(start, []byte("<html"))
 }
\ No newline at end of file
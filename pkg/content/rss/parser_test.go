@@ -0,0 +1,265 @@
+// pkg/content/rss/parser_test.go
+package rss
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/publicnet"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+	<channel>
+		<title>Nile Stories</title>
+		<description>Stories from along the Nile</description>
+		<link>https://example.com/nile-stories</link>
+		<language>en</language>
+		<itunes:author>Nile Stories</itunes:author>
+		<item>
+			<title>The Confluence</title>
+			<description>Where the Blue and White Nile meet</description>
+			<guid>nile-stories-1</guid>
+			<pubDate>Mon, 02 Jan 2023 10:00:00 +0000</pubDate>
+			<itunes:duration>12:30</itunes:duration>
+			<enclosure url="https://example.com/episodes/1.mp3" length="1200000" type="audio/mpeg"/>
+		</item>
+	</channel>
+</rss>`
+
+// newTestParser creates a parser allowed to fetch from the loopback test servers
+func newTestParser() *parser {
+	return newParser(5*time.Second, http.DefaultTransport)
+}
+
+// serveFeed serves a feed document with the given headers for the duration of the test
+func serveFeed(t *testing.T, contentType, contentEncoding string, body []byte) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		if contentEncoding != "" {
+			w.Header().Set("Content-Encoding", contentEncoding)
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+// gzipped compresses a feed document as a server would before sending it
+func gzipped(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		t.Fatalf("failed to compress feed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to compress feed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseFeedContentEncoding(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentEncoding string
+		body            []byte
+	}{
+		{name: "plain", body: []byte(testFeed)},
+		{name: "gzip", contentEncoding: "gzip", body: gzipped(t, []byte(testFeed))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := serveFeed(t, "application/rss+xml", tt.contentEncoding, tt.body)
+
+			feed, err := newTestParser().ParseFeed(context.Background(), url)
+			if err != nil {
+				t.Fatalf("ParseFeed() error = %v", err)
+			}
+
+			if feed.Title != "Nile Stories" {
+				t.Errorf("Title = %q, want %q", feed.Title, "Nile Stories")
+			}
+			if len(feed.Items) != 1 {
+				t.Fatalf("got %d items, want 1", len(feed.Items))
+			}
+			item := feed.Items[0]
+			if item.Title != "The Confluence" {
+				t.Errorf("item Title = %q, want %q", item.Title, "The Confluence")
+			}
+			if item.AudioURL != "https://example.com/episodes/1.mp3" {
+				t.Errorf("item AudioURL = %q, want %q", item.AudioURL, "https://example.com/episodes/1.mp3")
+			}
+			if len(feed.Warnings) != 0 {
+				t.Errorf("Warnings = %v, want none", feed.Warnings)
+			}
+		})
+	}
+}
+
+func TestParseFeedRejectsOversizedDecompressedFeed(t *testing.T) {
+	// Compresses to a few kilobytes but decompresses past maxFeedSize
+	padding := strings.Repeat(" ", maxFeedSize)
+	body := gzipped(t, []byte(strings.Replace(testFeed, "<channel>", "<channel>"+padding, 1)))
+	if len(body) > maxFeedSize/100 {
+		t.Fatalf("compressed feed is %d bytes, expected it to be small", len(body))
+	}
+
+	url := serveFeed(t, "application/rss+xml", "gzip", body)
+
+	_, err := newTestParser().ParseFeed(context.Background(), url)
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Fatalf("ParseFeed() error = %v, want a feed size error", err)
+	}
+}
+
+func TestParseFeedLatin1(t *testing.T) {
+	// "Café Nil" encoded as ISO-8859-1, where é is the single byte 0xE9
+	body := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+		"<rss version=\"2.0\"><channel>" +
+		"<title>Caf\xe9 Nil</title>" +
+		"<item><title>Premi\xe8re</title><guid>cafe-nil-1</guid>" +
+		"<enclosure url=\"https://example.com/episodes/premiere.mp3\" type=\"audio/mpeg\"/></item>" +
+		"</channel></rss>")
+	url := serveFeed(t, "application/rss+xml; charset=ISO-8859-1", "", body)
+
+	feed, err := newTestParser().ParseFeed(context.Background(), url)
+	if err != nil {
+		t.Fatalf("ParseFeed() error = %v", err)
+	}
+
+	if feed.Title != "Café Nil" {
+		t.Errorf("Title = %q, want %q", feed.Title, "Café Nil")
+	}
+	if len(feed.Items) != 1 || feed.Items[0].Title != "Première" {
+		t.Errorf("Items = %+v, want one item titled %q", feed.Items, "Première")
+	}
+}
+
+// testAtomFeed is testFeed as an Atom feed
+const testAtomFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xml:lang="en">
+	<title>Nile Stories</title>
+	<subtitle>Stories from along the Nile</subtitle>
+	<link href="https://example.com/nile-stories"/>
+	<itunes:author>Nile Stories</itunes:author>
+	<updated>2023-01-02T10:00:00Z</updated>
+	<entry>
+		<id>nile-stories-1</id>
+		<title>The Confluence</title>
+		<summary>Where the Blue and White Nile meet</summary>
+		<published>2023-01-02T10:00:00Z</published>
+		<updated>2023-01-03T08:00:00Z</updated>
+		<link rel="enclosure" href="https://example.com/episodes/1.mp3" length="1200000" type="audio/mpeg"/>
+	</entry>
+</feed>`
+
+func TestParseFeedAtomMatchesRSS(t *testing.T) {
+	parser := newTestParser()
+
+	rssFeed, err := parser.ParseFeed(context.Background(), serveFeed(t, "application/rss+xml", "", []byte(testFeed)))
+	if err != nil {
+		t.Fatalf("ParseFeed(RSS) error = %v", err)
+	}
+	atomFeed, err := parser.ParseFeed(context.Background(), serveFeed(t, "application/atom+xml", "", []byte(testAtomFeed)))
+	if err != nil {
+		t.Fatalf("ParseFeed(Atom) error = %v", err)
+	}
+
+	if atomFeed.Title != rssFeed.Title || atomFeed.Description != rssFeed.Description ||
+		atomFeed.Language != rssFeed.Language || atomFeed.Author != rssFeed.Author || atomFeed.WebsiteURL != rssFeed.WebsiteURL {
+		t.Errorf("Atom channel = %q/%q/%q/%q/%q, want RSS %q/%q/%q/%q/%q",
+			atomFeed.Title, atomFeed.Description, atomFeed.Language, atomFeed.Author, atomFeed.WebsiteURL,
+			rssFeed.Title, rssFeed.Description, rssFeed.Language, rssFeed.Author, rssFeed.WebsiteURL)
+	}
+
+	if len(atomFeed.Items) != len(rssFeed.Items) {
+		t.Fatalf("got %d Atom items, want %d as from RSS", len(atomFeed.Items), len(rssFeed.Items))
+	}
+	for i := range rssFeed.Items {
+		atomItem, rssItem := atomFeed.Items[i], rssFeed.Items[i]
+		if atomItem.Title != rssItem.Title || atomItem.Description != rssItem.Description || atomItem.GUID != rssItem.GUID {
+			t.Errorf("item %d = %q/%q/%q, want %q/%q/%q", i,
+				atomItem.Title, atomItem.Description, atomItem.GUID, rssItem.Title, rssItem.Description, rssItem.GUID)
+		}
+		if atomItem.AudioURL != rssItem.AudioURL || !reflect.DeepEqual(atomItem.Enclosure, rssItem.Enclosure) {
+			t.Errorf("item %d enclosure = %+v, want %+v", i, atomItem.Enclosure, rssItem.Enclosure)
+		}
+		if !atomItem.PublicationDate.Equal(rssItem.PublicationDate) {
+			t.Errorf("item %d PublicationDate = %v, want %v", i, atomItem.PublicationDate, rssItem.PublicationDate)
+		}
+		if atomItem.EpisodeType != rssItem.EpisodeType || atomItem.Explicit != rssItem.Explicit {
+			t.Errorf("item %d type/explicit = %q/%v, want %q/%v", i,
+				atomItem.EpisodeType, atomItem.Explicit, rssItem.EpisodeType, rssItem.Explicit)
+		}
+	}
+}
+
+func TestParseFeedPrefersMP3Enclosure(t *testing.T) {
+	body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+	<channel>
+		<title>Nile Stories</title>
+		<item>
+			<title>The Confluence</title>
+			<guid>nile-stories-1</guid>
+			<enclosure url="https://example.com/episodes/1.opus" length="600000" type="audio/opus"/>
+			<enclosure url="https://example.com/episodes/1.m4a" length="900000" type="audio/x-m4a"/>
+			<enclosure url="https://example.com/episodes/1.mp3" length="1200000" type="audio/mpeg"/>
+		</item>
+	</channel>
+</rss>`)
+	url := serveFeed(t, "application/rss+xml", "", body)
+
+	feed, err := newTestParser().ParseFeed(context.Background(), url)
+	if err != nil {
+		t.Fatalf("ParseFeed() error = %v", err)
+	}
+	if len(feed.Items) != 1 {
+		t.Fatalf("got %d items, want 1", len(feed.Items))
+	}
+
+	item := feed.Items[0]
+	if item.AudioURL != "https://example.com/episodes/1.mp3" {
+		t.Errorf("AudioURL = %q, want the mp3", item.AudioURL)
+	}
+	if item.Enclosure.Type != "audio/mpeg" || item.Enclosure.Length != 1200000 {
+		t.Errorf("Enclosure = %+v, want the mp3's", item.Enclosure)
+	}
+
+	var alternates []string
+	for _, enclosure := range item.AlternateEnclosures {
+		alternates = append(alternates, enclosure.URL)
+	}
+	want := []string{"https://example.com/episodes/1.opus", "https://example.com/episodes/1.m4a"}
+	if !reflect.DeepEqual(alternates, want) {
+		t.Errorf("AlternateEnclosures = %v, want %v", alternates, want)
+	}
+}
+
+func TestParseFeedRefusesNonPublicAddresses(t *testing.T) {
+	url := serveFeed(t, "application/rss+xml", "", []byte(testFeed))
+
+	// The test server listens on a loopback address, like services on the platform's own network
+	_, err := NewParser(5*time.Second).ParseFeed(context.Background(), url)
+	if !errors.Is(err, publicnet.ErrNonPublicAddress) {
+		t.Fatalf("ParseFeed() error = %v, want %v", err, publicnet.ErrNonPublicAddress)
+	}
+	if reason, status := FailureReason(err); reason != models.SyncFailureHTTPError || status != 0 {
+		t.Errorf("FailureReason() = %q, %d, want an unreachable feed host", reason, status)
+	}
+}
@@ -0,0 +1,232 @@
+// pkg/content/rss/transcript.go
+package rss
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// maxTranscriptFileSize bounds transcript files, which are fetched from podcast hosts
+const maxTranscriptFileSize = 5 << 20
+
+// transcriptTypes are the transcript formats that can be searched, most precise first. Timed
+// formats come first, as their matches can be jumped to.
+var transcriptTypes = []string{
+	"text/vtt",
+	"application/x-subrip",
+	"application/srt",
+	"text/srt",
+	"application/json",
+	"text/plain",
+	"text/html",
+}
+
+// cueTagPattern matches the voice, class and timestamp tags of VTT cue text
+var cueTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// paragraphPattern separates the paragraphs of plain text transcripts
+var paragraphPattern = regexp.MustCompile(`\n\s*\n`)
+
+// JSON transcript structures, as in the Podcasting 2.0 transcript spec
+type jsonTranscript struct {
+	Segments []jsonTranscriptSegment `json:"segments"`
+}
+
+type jsonTranscriptSegment struct {
+	Speaker   string  `json:"speaker"`
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime"`
+	Body      string  `json:"body"`
+}
+
+// PreferredTranscript picks the transcript link to search from those of an episode
+func PreferredTranscript(links models.TranscriptLinks) (models.TranscriptLink, bool) {
+	for _, transcriptType := range transcriptTypes {
+		for _, link := range links {
+			if strings.EqualFold(link.Type, transcriptType) {
+				return link, true
+			}
+		}
+	}
+	return models.TranscriptLink{}, false
+}
+
+// FetchTranscript fetches a transcript file and splits it into searchable segments
+func (p *parser) FetchTranscript(ctx context.Context, link models.TranscriptLink) ([]models.TranscriptSegment, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Sudanese Podcast Platform RSS Parser/1.0")
+	req.Header.Set("Accept", link.Type)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transcript: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTranscriptFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if len(body) > maxTranscriptFileSize {
+		return nil, fmt.Errorf("transcript file is larger than %d bytes", maxTranscriptFileSize)
+	}
+
+	switch strings.ToLower(link.Type) {
+	case "text/vtt", "application/x-subrip", "application/srt", "text/srt":
+		return parseCues(string(body)), nil
+	case "application/json":
+		return parseJSONTranscript(body)
+	case "text/html":
+		return SplitTranscriptText(cleanHTMLContent(string(body))), nil
+	default:
+		return SplitTranscriptText(string(body)), nil
+	}
+}
+
+// SplitTranscriptText splits a plain text transcript into paragraphs
+func SplitTranscriptText(text string) []models.TranscriptSegment {
+	var segments []models.TranscriptSegment
+	offset := 0
+	rest := text
+	for rest != "" {
+		paragraph := rest
+		next := ""
+		if loc := paragraphPattern.FindStringIndex(rest); loc != nil {
+			paragraph = rest[:loc[0]]
+			next = rest[loc[1]:]
+		}
+
+		// Offsets point at the first non-space character of the paragraph in the original text
+		trimmedLeft := strings.TrimLeft(paragraph, " \t\r\n")
+		start := offset + utf8.RuneCountInString(paragraph[:len(paragraph)-len(trimmedLeft)])
+		if trimmed := strings.TrimSpace(trimmedLeft); trimmed != "" {
+			segments = append(segments, models.TranscriptSegment{
+				CharOffset: start,
+				Text:       trimmed,
+			})
+		}
+
+		offset += utf8.RuneCountInString(rest[:len(rest)-len(next)])
+		rest = next
+	}
+	return segments
+}
+
+// parseCues reads the cues of a WebVTT or SubRip transcript. Cue settings, identifiers and
+// styling tags are dropped; offsets count characters of the cue texts joined by newlines.
+func parseCues(body string) []models.TranscriptSegment {
+	var segments []models.TranscriptSegment
+	offset := 0
+
+	var current *models.TranscriptSegment
+	var lines []string
+	flush := func() {
+		if current != nil {
+			text := strings.TrimSpace(cueTagPattern.ReplaceAllString(strings.Join(lines, " "), ""))
+			if text != "" {
+				current.CharOffset = offset
+				current.Text = decodeHTMLEntities(text)
+				segments = append(segments, *current)
+				offset += utf8.RuneCountInString(current.Text) + 1
+			}
+		}
+		current = nil
+		lines = nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxTranscriptFileSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+		case strings.Contains(line, "-->"):
+			flush()
+			parts := strings.SplitN(line, "-->", 2)
+			start, startErr := parseCueTime(parts[0])
+			end, endErr := parseCueTime(strings.Fields(parts[1] + " ")[0])
+			if startErr != nil {
+				continue
+			}
+			current = &models.TranscriptSegment{StartTime: &start}
+			if endErr == nil {
+				current.EndTime = &end
+			}
+		case current != nil:
+			lines = append(lines, line)
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// parseCueTime parses a cue timestamp such as 01:02:03.456, 02:03.456 or 01:02:03,456
+func parseCueTime(value string) (float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", ".")
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid cue time: %q", value)
+	}
+
+	var seconds float64
+	for _, part := range parts {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid cue time: %q", value)
+		}
+		seconds = seconds*60 + n
+	}
+	return seconds, nil
+}
+
+// parseJSONTranscript reads the segments of a JSON transcript, keeping the speaker names
+func parseJSONTranscript(body []byte) ([]models.TranscriptSegment, error) {
+	var transcript jsonTranscript
+	if err := json.Unmarshal(body, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+
+	segments := make([]models.TranscriptSegment, 0, len(transcript.Segments))
+	offset := 0
+	for _, segment := range transcript.Segments {
+		text := strings.TrimSpace(segment.Body)
+		if text == "" {
+			continue
+		}
+		if segment.Speaker != "" {
+			text = segment.Speaker + ": " + text
+		}
+
+		start, end := segment.StartTime, segment.EndTime
+		transcriptSegment := models.TranscriptSegment{
+			StartTime:  &start,
+			CharOffset: offset,
+			Text:       text,
+		}
+		if end > start {
+			transcriptSegment.EndTime = &end
+		}
+		segments = append(segments, transcriptSegment)
+		offset += utf8.RuneCountInString(text) + 1
+	}
+	return segments, nil
+}
@@ -0,0 +1,96 @@
+// pkg/content/rss/url.go
+package rss
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidFeedURL is returned for feed URLs that can't be fetched, e.g. with a scheme other than http(s)
+var ErrInvalidFeedURL = errors.New("invalid RSS URL")
+
+// podcastSchemes are the schemes podcast apps register for subscribe links, which stand for https
+var podcastSchemes = []string{"feed", "itpc", "pcast", "podcast"}
+
+// trackingParams are query parameters added by newsletters and social networks, which don't
+// change the feed. Parameters starting with utm_ are dropped as well.
+var trackingParams = map[string]bool{
+	"fbclid": true,
+	"gclid":  true,
+	"mc_cid": true,
+	"mc_eid": true,
+}
+
+// NormalizeFeedURL cleans up a pasted feed URL so that the same feed always gets the same URL:
+// surrounding whitespace, podcast app schemes, host casing, default ports, fragments and
+// tracking parameters are removed. URLs without a scheme are taken to be https.
+func NormalizeFeedURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrInvalidFeedURL
+	}
+
+	// feed://example.com/rss and feed:https://example.com/rss both stand for a web URL
+	for _, scheme := range podcastSchemes {
+		if len(raw) > len(scheme) && strings.EqualFold(raw[:len(scheme)+1], scheme+":") {
+			raw = strings.TrimPrefix(raw[len(scheme)+1:], "//")
+			break
+		}
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", ErrInvalidFeedURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", ErrInvalidFeedURL
+	}
+
+	// Hosts need a dot, which also rules out pasted text that merely parses as a URL
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]" // IPv6 literal
+	} else if !strings.Contains(host, ".") || strings.ContainsAny(host, " \t") {
+		return "", ErrInvalidFeedURL
+	}
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.RawQuery = stripTrackingParams(u.RawQuery)
+
+	return u.String(), nil
+}
+
+// stripTrackingParams removes tracking parameters from a raw query, keeping the order of the rest
+func stripTrackingParams(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	var kept []string
+	for _, param := range strings.Split(rawQuery, "&") {
+		if param == "" {
+			continue
+		}
+		name := param
+		if i := strings.IndexByte(param, '='); i >= 0 {
+			name = param[:i]
+		}
+		name = strings.ToLower(name)
+		if trackingParams[name] || strings.HasPrefix(name, "utm_") {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	return strings.Join(kept, "&")
+}
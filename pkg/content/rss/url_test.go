@@ -0,0 +1,69 @@
+// pkg/content/rss/url_test.go
+package rss
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeFeedURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr error
+	}{
+		{name: "already normal", raw: "https://example.com/feed.xml", want: "https://example.com/feed.xml"},
+		{name: "surrounding whitespace", raw: "  https://example.com/feed.xml\n", want: "https://example.com/feed.xml"},
+		{name: "no scheme", raw: "example.com/feed", want: "https://example.com/feed"},
+		{name: "host and scheme casing", raw: "HTTPS://Example.COM/Feed", want: "https://example.com/Feed"},
+
+		// Podcast app schemes
+		{name: "feed scheme", raw: "feed://example.com/rss", want: "https://example.com/rss"},
+		{name: "feed scheme around a URL", raw: "feed:https://example.com/rss", want: "https://example.com/rss"},
+		{name: "feed scheme around an http URL", raw: "pcast:http://example.com/rss", want: "http://example.com/rss"},
+		{name: "itpc scheme", raw: "itpc://example.com/rss", want: "https://example.com/rss"},
+		{name: "podcast scheme in capitals", raw: "PODCAST://example.com/rss", want: "https://example.com/rss"},
+
+		// Ports
+		{name: "default http port", raw: "http://example.com:80/rss", want: "http://example.com/rss"},
+		{name: "default https port", raw: "https://example.com:443/rss", want: "https://example.com/rss"},
+		{name: "other port", raw: "https://example.com:8443/rss", want: "https://example.com:8443/rss"},
+		{name: "https port over http", raw: "http://example.com:443/rss", want: "http://example.com:443/rss"},
+
+		// IPv6 literals keep their brackets
+		{name: "IPv6 with default port", raw: "http://[2001:DB8::1]:80/rss", want: "http://[2001:db8::1]/rss"},
+		{name: "IPv6 with other port", raw: "https://[2001:db8::1]:8443/rss", want: "https://[2001:db8::1]:8443/rss"},
+		{name: "IPv6 without port", raw: "https://[2001:db8::1]/rss", want: "https://[2001:db8::1]/rss"},
+
+		// Tracking parameters and fragments
+		{
+			name: "tracking parameters",
+			raw:  "https://example.com/rss?utm_source=news&id=5&fbclid=abc&UTM_Medium=email&gclid=1&mc_cid=2&mc_eid=3",
+			want: "https://example.com/rss?id=5",
+		},
+		{name: "only tracking parameters", raw: "https://example.com/rss?utm_source=news", want: "https://example.com/rss"},
+		{name: "other parameters keep their order", raw: "https://example.com/rss?b=2&utm_campaign=x&a=1", want: "https://example.com/rss?b=2&a=1"},
+		{name: "fragment", raw: "https://example.com/rss#latest", want: "https://example.com/rss"},
+
+		// Not feed URLs
+		{name: "empty", raw: "", wantErr: ErrInvalidFeedURL},
+		{name: "blank", raw: "   ", wantErr: ErrInvalidFeedURL},
+		{name: "other scheme", raw: "ftp://example.com/rss", wantErr: ErrInvalidFeedURL},
+		{name: "host without a dot", raw: "localhost/rss", wantErr: ErrInvalidFeedURL},
+		{name: "text", raw: "not a url", wantErr: ErrInvalidFeedURL},
+		{name: "javascript", raw: "javascript:alert(1)", wantErr: ErrInvalidFeedURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeFeedURL(tt.raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeFeedURL(%q) error = %v, want %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeFeedURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
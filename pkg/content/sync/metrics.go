@@ -0,0 +1,94 @@
+// pkg/content/sync/metrics.go
+package sync
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/metrics"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+)
+
+// syncMetrics are the collectors of the sync service. Prometheus collectors are safe for
+// concurrent use, so syncs running in parallel can record their results without extra locking.
+type syncMetrics struct {
+	feedsSyncedTotal     *prometheus.CounterVec
+	episodesSyncedTotal  *prometheus.CounterVec
+	syncDurationSeconds  *prometheus.HistogramVec
+	syncsInProgress      prometheus.Gauge
+	lastSyncAllTimestamp prometheus.Gauge
+}
+
+// newSyncMetrics creates the sync collectors and registers them with reg, unless reg is nil
+func newSyncMetrics(reg prometheus.Registerer) *syncMetrics {
+	factory := promauto.With(reg)
+
+	m := &syncMetrics{
+		feedsSyncedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "rss_sync",
+			Name:      "feeds_total",
+			Help:      "Number of podcast feed syncs, by result status.",
+		}, []string{"status"}),
+
+		episodesSyncedTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "rss_sync",
+			Name:      "episodes_total",
+			Help:      "Number of episodes changed by feed syncs, by action (added, updated, removed).",
+		}, []string{"action"}),
+
+		syncDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "rss_sync",
+			Name:      "duration_seconds",
+			Help:      "Duration of a single podcast feed sync, by result status.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+		}, []string{"status"}),
+
+		syncsInProgress: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "rss_sync",
+			Name:      "in_progress",
+			Help:      "Number of podcast feed syncs currently running.",
+		}),
+
+		lastSyncAllTimestamp: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: "rss_sync",
+			Name:      "last_sync_all_timestamp_seconds",
+			Help:      "Unix time at which the last full sync of all podcasts completed.",
+		}),
+	}
+
+	// Export every series from startup so dashboards don't show gaps before the first sync
+	for _, status := range []string{"success", "not_modified", "failure"} {
+		m.feedsSyncedTotal.WithLabelValues(status)
+		m.syncDurationSeconds.WithLabelValues(status)
+	}
+	for _, action := range []string{"added", "updated", "removed"} {
+		m.episodesSyncedTotal.WithLabelValues(action)
+	}
+
+	return m
+}
+
+// recordSync records the outcome of a single podcast sync
+func (m *syncMetrics) recordSync(result *models.RSSFeedSyncResult, err error, duration time.Duration) {
+	status := "success"
+	if err != nil || result == nil || !result.Success {
+		status = "failure"
+	} else if result.NotModified {
+		status = "not_modified"
+	}
+
+	m.feedsSyncedTotal.WithLabelValues(status).Inc()
+	m.syncDurationSeconds.WithLabelValues(status).Observe(duration.Seconds())
+
+	if result != nil {
+		m.episodesSyncedTotal.WithLabelValues("added").Add(float64(result.EpisodesAdded))
+		m.episodesSyncedTotal.WithLabelValues("updated").Add(float64(result.EpisodesUpdated))
+		m.episodesSyncedTotal.WithLabelValues("removed").Add(float64(result.EpisodesRemoved))
+	}
+}
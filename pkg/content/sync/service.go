@@ -3,16 +3,34 @@ package sync
 
 import (
 	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/notification"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/repository/postgres"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/rss"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/webhook"
+)
+
+const (
+	// maxSyncStaggerFraction bounds the offset added to a podcast's sync interval to a tenth of it
+	maxSyncStaggerFraction = 10
+	// syncAllJobTimeout bounds a full sync started by an admin, as for scheduled ones
+	syncAllJobTimeout = 1 * time.Hour
+	// maxSyncAllJobs is how many full sync jobs are kept for their status to be looked up
+	maxSyncAllJobs = 20
 )
 
 // Service defines the interface for the RSS sync service
@@ -20,8 +38,21 @@ type Service interface {
 	// SyncPodcast synchronizes a podcast feed by ID
 	SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncResult, error)
 	
-	// SyncAllPodcasts synchronizes all active podcasts
-	SyncAllPodcasts(ctx context.Context) ([]models.RSSFeedSyncResult, error)
+	// SyncAllPodcasts synchronizes the active podcasts that are due for a sync, or all of them when force is set
+	SyncAllPodcasts(ctx context.Context, force bool) ([]models.RSSFeedSyncResult, error)
+	
+	// StartSyncAll starts a full sync in the background, returning the job to follow it by.
+	// Fails with ErrFullSyncInProgress while another full sync is running.
+	StartSyncAll(force bool) (*models.SyncAllJob, error)
+	
+	// GetSyncAllJob gets the progress of a full sync job started by StartSyncAll
+	GetSyncAllJob(jobID uuid.UUID) (*models.SyncAllJob, error)
+	
+	// RetryFailedSyncs re-synchronizes podcasts whose failed sync is due for a retry
+	RetryFailedSyncs(ctx context.Context) ([]models.RSSFeedSyncResult, error)
+	
+	// RefreshEpisode re-reads a single episode's metadata from its podcast's feed
+	RefreshEpisode(ctx context.Context, episodeID uuid.UUID) (*models.Episode, error)
 	
 	// GetSyncStatus gets the latest sync status for a podcast
 	GetSyncStatus(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error)
@@ -34,16 +65,29 @@ type service struct {
 	repo       postgres.Repository
 	parser     rss.Parser
 	db         *sqlx.DB
+	cfg        *config.Config
+	notifier   notification.Notifier
+	webhooks   webhook.Service
 	syncMutex  *sync.Map // To prevent concurrent syncs for the same podcast
+	metrics    *syncMetrics
+
+	fullSyncLock sync.Mutex // Held while a full sync runs, so full syncs don't overlap
+	jobsMutex    sync.Mutex
+	jobs         []*models.SyncAllJob // Full sync jobs, oldest first
 }
 
-// NewService creates a new RSS sync service
-func NewService(repo postgres.Repository, parser rss.Parser, db *sqlx.DB) Service {
+// NewService creates a new RSS sync service, registering its metrics with reg. Subscribers are
+// told of new episodes through notifier, and podcasters' webhooks of new episodes and failed syncs.
+func NewService(repo postgres.Repository, parser rss.Parser, db *sqlx.DB, cfg *config.Config, notifier notification.Notifier, webhooks webhook.Service, reg prometheus.Registerer) Service {
 	return &service{
 		repo:      repo,
 		parser:    parser,
 		db:        db,
+		cfg:       cfg,
+		notifier:  notifier,
+		webhooks:  webhooks,
 		syncMutex: &sync.Map{},
+		metrics:   newSyncMetrics(reg),
 	}
 }
 
@@ -60,6 +104,23 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 	}
 	defer s.syncMutex.Delete(podcastID.String())
 
+	s.metrics.syncsInProgress.Inc()
+	defer s.metrics.syncsInProgress.Dec()
+
+	start := time.Now()
+	result, err := s.syncPodcast(ctx, podcastID)
+	s.metrics.recordSync(result, err, time.Since(start))
+
+	// A result is only returned once the feed was actually fetched, so only then does it count towards the retry state
+	if result != nil {
+		s.updateRetryState(ctx, podcastID, result.Success)
+	}
+
+	return result, err
+}
+
+// syncPodcast fetches the podcast feed and applies it to the stored podcast and its episodes
+func (s *service) syncPodcast(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncResult, error) {
 	// Get podcast from database
 	podcast, err := s.repo.GetPodcastByID(ctx, podcastID)
 	if err != nil {
@@ -67,6 +128,7 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 	}
 
 	if podcast.RSSUrl == "" {
+		s.logSyncFailure(ctx, podcastID, 0, 0, models.SyncFailureNoRSSURL, 0, "Podcast has no RSS URL")
 		return nil, fmt.Errorf("podcast has no RSS URL")
 	}
 
@@ -76,18 +138,55 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 		Success:   false,
 	}
 
-	// Parse the feed
-	feed, err := s.parser.ParseFeed(ctx, podcast.RSSUrl)
+	// Parse the feed, unless it is unchanged since the last sync
+	feed, validators, err := s.parser.ParseFeedConditional(ctx, podcast.RSSUrl, models.FeedValidators{
+		ETag:         podcast.FeedETag,
+		LastModified: podcast.FeedLastModified,
+	})
+	if errors.Is(err, rss.ErrNotModified) {
+		if err := s.repo.UpdatePodcastSyncedAt(ctx, podcastID, time.Now().UTC()); err != nil {
+			logger.FromContext(ctx).Error("Failed to update last synced time",
+				logger.Field("podcast_id", podcastID),
+				logger.Field("error", err))
+		}
+		if movedTo := s.movedFeedURL(ctx, podcast, validators.MovedTo); movedTo != "" {
+			if err := s.repo.UpdatePodcastRSSURL(ctx, podcastID, movedTo); err != nil {
+				logger.FromContext(ctx).Error("Failed to update RSS URL",
+					logger.Field("podcast_id", podcastID),
+					logger.Field("error", err))
+			}
+		}
+		s.createSyncLog(ctx, &models.RSSFeedSyncLog{
+			PodcastID: podcastID,
+			Status:    "not_modified",
+		})
+		result.Success = true
+		result.NotModified = true
+		return result, nil
+	}
+	if errors.Is(err, rss.ErrFeedGone) {
+		// Logged apart from other failures, as retrying won't help and the feed should be disabled
+		s.createSyncLog(ctx, &models.RSSFeedSyncLog{
+			PodcastID:     podcastID,
+			Status:        "gone",
+			ErrorMessage:  err.Error(),
+			FailureReason: models.SyncFailureHTTPError,
+			HTTPStatus:    http.StatusGone,
+		})
+		result.ErrorMessage = err.Error()
+		return result, fmt.Errorf("%w: %w", models.ErrFeedFetch, err)
+	}
 	if err != nil {
-		s.logSyncFailure(ctx, podcastID, 0, 0, err.Error())
+		reason, httpStatus := rss.FailureReason(err)
+		s.logSyncFailure(ctx, podcastID, 0, 0, reason, httpStatus, err.Error())
 		result.ErrorMessage = err.Error()
-		return result, fmt.Errorf("failed to parse feed: %w", err)
+		return result, fmt.Errorf("%w: %w", models.ErrFeedFetch, err)
 	}
 
 	// Start a transaction
 	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		s.logSyncFailure(ctx, podcastID, 0, 0, "Failed to start transaction")
+		s.logSyncFailure(ctx, podcastID, 0, 0, models.SyncFailureInternal, 0, "Failed to start transaction")
 		result.ErrorMessage = "Database error"
 		return result, fmt.Errorf("failed to start transaction: %w", err)
 	}
@@ -117,8 +216,12 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 		updated = true
 	}
 
-	if feed.CoverImageURL != "" && feed.CoverImageURL != podcast.CoverImageURL {
+	// Compare against the feed image seen last, so a cover uploaded by the podcaster is only
+	// replaced once the feed publishes new artwork
+	if feed.CoverImageURL != "" && feed.CoverImageURL != podcast.FeedCoverImageURL {
 		updatedPodcast.CoverImageURL = feed.CoverImageURL
+		updatedPodcast.FeedCoverImageURL = feed.CoverImageURL
+		updatedPodcast.CoverImageUserSet = false
 		updated = true
 	}
 
@@ -137,21 +240,34 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 		updated = true
 	}
 
+	if len(feed.Keywords) > 0 && !slices.Equal(feed.Keywords, podcast.Keywords) {
+		updatedPodcast.Keywords = feed.Keywords
+		updated = true
+	}
+
 	// Update the podcast explicit flag
 	if feed.Explicit != podcast.Explicit {
 		updatedPodcast.Explicit = feed.Explicit
 		updated = true
 	}
 
-	// Set the last synced time
-	now := time.Now()
+	// Follow a permanent move of the feed
+	if movedTo := s.movedFeedURL(ctx, podcast, validators.MovedTo); movedTo != "" {
+		updatedPodcast.RSSUrl = movedTo
+		updated = true
+	}
+
+	// Set the last synced time and remember the validators for the next conditional fetch
+	now := time.Now().UTC()
 	updatedPodcast.LastSyncedAt = &now
+	updatedPodcast.FeedETag = validators.ETag
+	updatedPodcast.FeedLastModified = validators.LastModified
 	updated = true
 
 	// Update podcast if metadata has changed
 	if updated {
 		if err := s.repo.UpdatePodcastTx(ctx, tx, &updatedPodcast); err != nil {
-			s.logSyncFailure(ctx, podcastID, 0, 0, "Failed to update podcast metadata")
+			s.logSyncFailure(ctx, podcastID, 0, 0, models.SyncFailureInternal, 0, "Failed to update podcast metadata")
 			result.ErrorMessage = "Failed to update podcast metadata"
 			return result, fmt.Errorf("failed to update podcast: %w", err)
 		}
@@ -160,7 +276,7 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 	// Get existing episodes for this podcast
 	existingEpisodes, err := s.repo.GetAllEpisodesByPodcastIDTx(ctx, tx, podcastID)
 	if err != nil {
-		s.logSyncFailure(ctx, podcastID, 0, 0, "Failed to get existing episodes")
+		s.logSyncFailure(ctx, podcastID, 0, 0, models.SyncFailureInternal, 0, "Failed to get existing episodes")
 		result.ErrorMessage = "Failed to get existing episodes"
 		return result, fmt.Errorf("failed to get existing episodes: %w", err)
 	}
@@ -174,68 +290,47 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 	// Process episodes in the feed
 	episodesAdded := 0
 	episodesUpdated := 0
+	feedGUIDs := make(map[string]bool, len(feed.Items))
+	var chaptersToFetch []*models.Episode
+	var transcriptsToFetch []*models.Episode
+	var newEpisodes []*models.Episode
 
 	for _, item := range feed.Items {
 		// Skip if GUID is empty
 		if item.GUID == "" {
 			continue
 		}
+		feedGUIDs[item.GUID] = true
 
 		// Check if episode already exists
 		existingEpisode, exists := existingEpisodeMap[item.GUID]
 		if exists {
-			// Update episode if needed
-			updated := false
-			updatedEpisode := *existingEpisode
-
-			if item.Title != "" && item.Title != existingEpisode.Title {
-				updatedEpisode.Title = item.Title
-				updated = true
-			}
-
-			if item.Description != "" && item.Description != existingEpisode.Description {
-				updatedEpisode.Description = item.Description
-				updated = true
-			}
-
-			if item.AudioURL != "" && item.AudioURL != existingEpisode.AudioURL {
-				updatedEpisode.AudioURL = item.AudioURL
-				updated = true
-			}
-
-			if item.Duration > 0 && item.Duration != existingEpisode.Duration {
-				updatedEpisode.Duration = item.Duration
-				updated = true
-			}
-
-			if item.CoverImageURL != "" && item.CoverImageURL != existingEpisode.CoverImageURL {
-				updatedEpisode.CoverImageURL = item.CoverImageURL
-				updated = true
-			}
-
-			if !item.PublicationDate.IsZero() && !item.PublicationDate.Equal(existingEpisode.PublicationDate) {
-				updatedEpisode.PublicationDate = item.PublicationDate
-				updated = true
-			}
-
-			if item.EpisodeNumber != nil && (existingEpisode.EpisodeNumber == nil || *item.EpisodeNumber != *existingEpisode.EpisodeNumber) {
-				updatedEpisode.EpisodeNumber = item.EpisodeNumber
-				updated = true
-			}
+			// Update episode if metadata has changed
+			updatedEpisode, updated := mergeFeedItem(existingEpisode, &item)
 
-			if item.SeasonNumber != nil && (existingEpisode.SeasonNumber == nil || *item.SeasonNumber != *existingEpisode.SeasonNumber) {
-				updatedEpisode.SeasonNumber = item.SeasonNumber
+			// Bring back episodes removed by an earlier sync once they reappear in the feed
+			if existingEpisode.Status == "removed" {
+				updatedEpisode.Status = "active"
 				updated = true
 			}
 
-			// Update episode if metadata has changed
 			if updated {
-				updatedEpisode.UpdatedAt = time.Now()
+				updatedEpisode.UpdatedAt = time.Now().UTC()
 				if err := s.repo.UpdateEpisodeTx(ctx, tx, &updatedEpisode); err != nil {
-					log.Printf("Failed to update episode %s: %v", existingEpisode.ID, err)
+					logger.FromContext(ctx).Error("Failed to update episode",
+						logger.Field("podcast_id", podcastID),
+						logger.Field("episode_id", existingEpisode.ID),
+						logger.Field("error", err))
 					continue
 				}
 				episodesUpdated++
+
+				if updatedEpisode.ChaptersURL != existingEpisode.ChaptersURL {
+					chaptersToFetch = append(chaptersToFetch, &updatedEpisode)
+				}
+				if !slices.Equal(updatedEpisode.Transcripts, existingEpisode.Transcripts) {
+					transcriptsToFetch = append(transcriptsToFetch, &updatedEpisode)
+				}
 			}
 		} else {
 			// Create new episode
@@ -251,32 +346,683 @@ func (s *service) SyncPodcast(ctx context.Context, podcastID uuid.UUID) (*models
 				GUID:            item.GUID,
 				EpisodeNumber:   item.EpisodeNumber,
 				SeasonNumber:    item.SeasonNumber,
+				EpisodeType:     item.EpisodeType,
+				Transcripts:     item.Transcripts,
+				ChaptersURL:     item.ChaptersURL,
+				Keywords:        item.Keywords,
+				AlternateEnclosures: item.AlternateEnclosures,
+				FileSize:        item.Enclosure.Length,
+				MimeType:        item.Enclosure.Type,
+				Explicit:        item.Explicit,
 				Status:          "active",
-				CreatedAt:       time.Now(),
-				UpdatedAt:       time.Now(),
+				CreatedAt:       time.Now().UTC(),
+				UpdatedAt:       time.Now().UTC(),
 			}
 
 			if err := s.repo.CreateEpisodeTx(ctx, tx, newEpisode); err != nil {
-				log.Printf("Failed to create episode with GUID %s: %v", item.GUID, err)
+				logger.FromContext(ctx).Error("Failed to create episode",
+					logger.Field("podcast_id", podcastID),
+					logger.Field("guid", item.GUID),
+					logger.Field("error", err))
 				continue
 			}
 			episodesAdded++
+			newEpisodes = append(newEpisodes, newEpisode)
+
+			if newEpisode.ChaptersURL != "" {
+				chaptersToFetch = append(chaptersToFetch, newEpisode)
+			}
+			if len(newEpisode.Transcripts) > 0 {
+				transcriptsToFetch = append(transcriptsToFetch, newEpisode)
+			}
+		}
+	}
+
+	// Mark episodes that are no longer in the feed as removed, keeping them for playback history.
+	// An empty feed is more likely a publishing glitch than a deliberate purge, so it prunes nothing.
+	episodesRemoved := 0
+	if podcast.PruneMissing && len(feedGUIDs) > 0 {
+		var missingIDs []uuid.UUID
+		for guid, episode := range existingEpisodeMap {
+			if episode.Status == "active" && !feedGUIDs[guid] {
+				missingIDs = append(missingIDs, episode.ID)
+			}
+		}
+
+		if len(missingIDs) > 0 {
+			episodesRemoved, err = s.repo.MarkEpisodesRemovedTx(ctx, tx, missingIDs)
+			if err != nil {
+				s.logSyncFailure(ctx, podcastID, episodesAdded, episodesUpdated, models.SyncFailureInternal, 0, "Failed to remove missing episodes")
+				result.ErrorMessage = "Failed to remove missing episodes"
+				return result, fmt.Errorf("failed to remove missing episodes: %w", err)
+			}
 		}
 	}
 
 	// Commit the transaction
 	if err := tx.Commit(); err != nil {
-		s.logSyncFailure(ctx, podcastID, episodesAdded, episodesUpdated, "Failed to commit transaction")
+		s.logSyncFailure(ctx, podcastID, episodesAdded, episodesUpdated, models.SyncFailureInternal, 0, "Failed to commit transaction")
 		result.ErrorMessage = "Database error"
 		return result, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	// Chapters and transcript files are fetched once the episodes are saved, so slow hosts don't
+	// hold the transaction open
+	for _, episode := range chaptersToFetch {
+		s.syncChapters(ctx, episode)
+	}
+	for _, episode := range transcriptsToFetch {
+		s.syncTranscript(ctx, episode)
+	}
+
+	// The episodes of a podcast's first sync are its back catalog rather than news, so only
+	// later syncs notify subscribers
+	if episodesAdded > 0 && podcast.LastSyncedAt != nil {
+		if err := s.notifier.NotifyNewEpisodes(ctx, podcast, newEpisodes); err != nil {
+			logger.FromContext(ctx).Error("Failed to notify subscribers of new episodes",
+				logger.Field("podcast_id", podcastID),
+				logger.Field("error", err))
+		}
+	}
+	for _, episode := range newEpisodes {
+		s.dispatchWebhook(ctx, podcastID, models.WebhookEventEpisodeAdded, models.WebhookEpisodeAddedData{Episode: episode})
+	}
+
 	// Log success
-	s.logSyncSuccess(ctx, podcastID, episodesAdded, episodesUpdated)
+	s.logSyncSuccess(ctx, podcastID, episodesAdded, episodesUpdated, episodesRemoved, feed.Warnings)
 
 	// Update result
 	result.Success = true
 	result.EpisodesAdded = episodesAdded
 	result.EpisodesUpdated = episodesUpdated
+	result.EpisodesRemoved = episodesRemoved
+	result.Warnings = feed.Warnings
 
-	return result, nil
\ No newline at end of file
+	return result, nil
+}
+
+// RefreshEpisode re-reads a single episode's metadata from its podcast's feed and updates
+// just that episode, leaving the rest of the podcast untouched
+func (s *service) RefreshEpisode(ctx context.Context, episodeID uuid.UUID) (*models.Episode, error) {
+	episode, err := s.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	podcast, err := s.repo.GetPodcastByID(ctx, episode.PodcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	if podcast.RSSUrl == "" {
+		return nil, models.ErrNoRSSURL
+	}
+
+	// Don't race a full sync of the same podcast
+	if _, loaded := s.syncMutex.LoadOrStore(podcast.ID.String(), true); loaded {
+		return nil, models.ErrSyncInProgress
+	}
+	defer s.syncMutex.Delete(podcast.ID.String())
+
+	feed, err := s.parser.ParseFeed(ctx, podcast.RSSUrl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", models.ErrFeedFetch, err)
+	}
+
+	var feedItem *models.RSSFeedItem
+	for i := range feed.Items {
+		if feed.Items[i].GUID == episode.GUID {
+			feedItem = &feed.Items[i]
+			break
+		}
+	}
+	if feedItem == nil {
+		return nil, models.ErrEpisodeNotInFeed
+	}
+
+	updatedEpisode, updated := mergeFeedItem(episode, feedItem)
+	if !updated {
+		// A refresh is asked for explicitly, so fetch the chapters and transcript again even if
+		// their URLs are the same
+		s.syncChapters(ctx, episode)
+		s.syncTranscript(ctx, episode)
+		return episode, nil
+	}
+	updatedEpisode.UpdatedAt = time.Now().UTC()
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.repo.UpdateEpisodeTx(ctx, tx, &updatedEpisode); err != nil {
+		return nil, fmt.Errorf("failed to update episode: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.syncChapters(ctx, &updatedEpisode)
+	s.syncTranscript(ctx, &updatedEpisode)
+
+	return &updatedEpisode, nil
+}
+
+// syncChapters reads an episode's chapters from its podcast:chapters file, unless the podcaster
+// set them by hand. Failures are only logged, as chapters are not essential to the episode.
+func (s *service) syncChapters(ctx context.Context, episode *models.Episode) {
+	if episode.ChaptersUserSet || (episode.ChaptersURL == "" && len(episode.Chapters) == 0) {
+		return
+	}
+
+	var chapters models.Chapters
+	if episode.ChaptersURL != "" {
+		var err error
+		chapters, err = s.parser.FetchChapters(ctx, episode.ChaptersURL)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Failed to fetch chapters",
+				logger.Field("episode_id", episode.ID),
+				logger.Field("url", episode.ChaptersURL),
+				logger.Field("error", err))
+			return
+		}
+	}
+
+	if err := s.repo.UpdateFeedChapters(ctx, episode.ID, chapters); err != nil {
+		logger.FromContext(ctx).Error("Failed to update chapters",
+			logger.Field("episode_id", episode.ID),
+			logger.Field("error", err))
+		return
+	}
+	episode.Chapters = chapters
+}
+
+// syncTranscript splits an episode's transcript into segments for transcript search, preferring
+// timed formats. Episodes without podcast:transcript links fall back to their plain transcript
+// text. Like chapters, failures are only logged.
+func (s *service) syncTranscript(ctx context.Context, episode *models.Episode) {
+	var segments []models.TranscriptSegment
+	if link, ok := rss.PreferredTranscript(episode.Transcripts); ok {
+		var err error
+		segments, err = s.parser.FetchTranscript(ctx, link)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Failed to fetch transcript",
+				logger.Field("episode_id", episode.ID),
+				logger.Field("url", link.URL),
+				logger.Field("error", err))
+			return
+		}
+	} else if episode.Transcript != "" {
+		segments = rss.SplitTranscriptText(episode.Transcript)
+	}
+
+	if err := s.repo.ReplaceTranscriptSegments(ctx, episode.ID, segments); err != nil {
+		logger.FromContext(ctx).Error("Failed to update transcript segments",
+			logger.Field("episode_id", episode.ID),
+			logger.Field("error", err))
+	}
+}
+
+// mergeFeedItem returns a copy of the episode with the metadata of its feed item applied,
+// and whether anything changed. Empty or missing feed values never overwrite stored ones.
+func mergeFeedItem(episode *models.Episode, item *models.RSSFeedItem) (models.Episode, bool) {
+	updated := false
+	updatedEpisode := *episode
+
+	if item.Title != "" && item.Title != episode.Title {
+		updatedEpisode.Title = item.Title
+		updated = true
+	}
+
+	if item.Description != "" && item.Description != episode.Description {
+		updatedEpisode.Description = item.Description
+		updated = true
+	}
+
+	// The size and type describe the audio file, so a new file replaces them even when unknown
+	if item.AudioURL != "" && item.AudioURL != episode.AudioURL {
+		updatedEpisode.AudioURL = item.AudioURL
+		updatedEpisode.FileSize = item.Enclosure.Length
+		updatedEpisode.MimeType = item.Enclosure.Type
+		updated = true
+	}
+
+	if item.Enclosure.Length > 0 && item.Enclosure.Length != updatedEpisode.FileSize {
+		updatedEpisode.FileSize = item.Enclosure.Length
+		updated = true
+	}
+
+	if item.Enclosure.Type != "" && item.Enclosure.Type != updatedEpisode.MimeType {
+		updatedEpisode.MimeType = item.Enclosure.Type
+		updated = true
+	}
+
+	// Alternates come with the audio enclosure, so an item offering a single format clears them
+	if item.AudioURL != "" && !slices.Equal(item.AlternateEnclosures, episode.AlternateEnclosures) {
+		updatedEpisode.AlternateEnclosures = item.AlternateEnclosures
+		updated = true
+	}
+
+	if item.Duration > 0 && item.Duration != episode.Duration {
+		updatedEpisode.Duration = item.Duration
+		updated = true
+	}
+
+	if item.CoverImageURL != "" && item.CoverImageURL != episode.CoverImageURL {
+		updatedEpisode.CoverImageURL = item.CoverImageURL
+		updated = true
+	}
+
+	if !item.PublicationDate.IsZero() && !item.PublicationDate.Equal(episode.PublicationDate) {
+		updatedEpisode.PublicationDate = item.PublicationDate
+		updated = true
+	}
+
+	if item.EpisodeNumber != nil && (episode.EpisodeNumber == nil || *item.EpisodeNumber != *episode.EpisodeNumber) {
+		updatedEpisode.EpisodeNumber = item.EpisodeNumber
+		updated = true
+	}
+
+	if item.SeasonNumber != nil && (episode.SeasonNumber == nil || *item.SeasonNumber != *episode.SeasonNumber) {
+		updatedEpisode.SeasonNumber = item.SeasonNumber
+		updated = true
+	}
+
+	if item.EpisodeType != "" && item.EpisodeType != episode.EpisodeType {
+		updatedEpisode.EpisodeType = item.EpisodeType
+		updated = true
+	}
+
+	if len(item.Transcripts) > 0 && !slices.Equal(item.Transcripts, episode.Transcripts) {
+		updatedEpisode.Transcripts = item.Transcripts
+		updated = true
+	}
+
+	if len(item.Keywords) > 0 && !slices.Equal(item.Keywords, episode.Keywords) {
+		updatedEpisode.Keywords = item.Keywords
+		updated = true
+	}
+
+	// The flag is always known, falling back to the podcast's, so it is always applied
+	if item.Explicit != episode.Explicit {
+		updatedEpisode.Explicit = item.Explicit
+		updated = true
+	}
+
+	// A feed dropping its chapters tag also drops the chapters read from it
+	if item.ChaptersURL != episode.ChaptersURL {
+		updatedEpisode.ChaptersURL = item.ChaptersURL
+		updated = true
+	}
+
+	return updatedEpisode, updated
+}
+
+// SyncAllPodcasts synchronizes the active podcasts that are due for a sync, skipping those
+// synced more recently than their interval. A forced sync, for manual full resyncs, syncs
+// every active podcast.
+func (s *service) SyncAllPodcasts(ctx context.Context, force bool) ([]models.RSSFeedSyncResult, error) {
+	if !s.fullSyncLock.TryLock() {
+		return nil, models.ErrFullSyncInProgress
+	}
+	defer s.fullSyncLock.Unlock()
+
+	return s.syncAll(ctx, force, nil)
+}
+
+// StartSyncAll starts a full sync in the background, returning the job to follow it by
+func (s *service) StartSyncAll(force bool) (*models.SyncAllJob, error) {
+	if !s.fullSyncLock.TryLock() {
+		return nil, models.ErrFullSyncInProgress
+	}
+
+	job := &models.SyncAllJob{
+		ID:        uuid.New(),
+		Status:    models.SyncJobRunning,
+		Force:     force,
+		StartedAt: time.Now().UTC(),
+	}
+	s.jobsMutex.Lock()
+	s.jobs = append(s.jobs, job)
+	if len(s.jobs) > maxSyncAllJobs {
+		s.jobs = s.jobs[len(s.jobs)-maxSyncAllJobs:]
+	}
+	started := *job
+	s.jobsMutex.Unlock()
+
+	logger.Info("Full RSS feed sync job started",
+		logger.Field("job_id", job.ID),
+		logger.Field("force", force))
+
+	go func() {
+		defer s.fullSyncLock.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), syncAllJobTimeout)
+		defer cancel()
+
+		_, err := s.syncAll(ctx, force, job)
+
+		s.jobsMutex.Lock()
+		finishedAt := time.Now().UTC()
+		job.FinishedAt = &finishedAt
+		if err != nil {
+			job.Status = models.SyncJobFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = models.SyncJobCompleted
+		}
+		s.jobsMutex.Unlock()
+
+		logger.Info("Full RSS feed sync job finished",
+			logger.Field("job_id", job.ID),
+			logger.Field("error", err))
+	}()
+
+	return &started, nil
+}
+
+// GetSyncAllJob gets a copy of a full sync job, safe to read while the job runs
+func (s *service) GetSyncAllJob(jobID uuid.UUID) (*models.SyncAllJob, error) {
+	s.jobsMutex.Lock()
+	defer s.jobsMutex.Unlock()
+
+	for _, job := range s.jobs {
+		if job.ID == jobID {
+			found := *job
+			return &found, nil
+		}
+	}
+	return nil, models.ErrSyncJobNotFound
+}
+
+// syncAll syncs the active podcasts that are due, or all of them when forced, counting the
+// results on job if there is one. The caller holds fullSyncLock.
+func (s *service) syncAll(ctx context.Context, force bool, job *models.SyncAllJob) ([]models.RSSFeedSyncResult, error) {
+	podcasts, err := s.repo.GetActivePodcasts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active podcasts: %w", err)
+	}
+
+	if !force {
+		now := time.Now().UTC()
+		due := make([]*models.Podcast, 0, len(podcasts))
+		for _, podcast := range podcasts {
+			if s.syncDue(podcast, now) {
+				due = append(due, podcast)
+			}
+		}
+		logger.FromContext(ctx).Info("Syncing podcasts due for a sync",
+			logger.Field("due", len(due)),
+			logger.Field("active", len(podcasts)))
+		podcasts = due
+	}
+
+	var onResult func(models.RSSFeedSyncResult)
+	if job != nil {
+		s.jobsMutex.Lock()
+		job.PodcastsTotal = len(podcasts)
+		s.jobsMutex.Unlock()
+
+		onResult = func(result models.RSSFeedSyncResult) {
+			s.jobsMutex.Lock()
+			defer s.jobsMutex.Unlock()
+			if result.Success {
+				job.PodcastsSynced++
+			} else {
+				job.PodcastsFailed++
+			}
+			job.EpisodesAdded += result.EpisodesAdded
+			job.EpisodesUpdated += result.EpisodesUpdated
+			job.EpisodesRemoved += result.EpisodesRemoved
+		}
+	}
+
+	results, err := s.syncPodcasts(ctx, podcasts, onResult)
+	if err != nil {
+		return results, err
+	}
+
+	s.metrics.lastSyncAllTimestamp.SetToCurrentTime()
+
+	return results, nil
+}
+
+// syncDue reports whether a podcast's interval has passed since its last sync. Podcasts whose
+// last sync failed are left to RetryFailedSyncs and its backoff.
+//
+// Each podcast's interval is stretched by a fixed offset of its own, so podcasts synced
+// together, e.g. after a restart or a forced sync, drift apart over the following cycles
+// rather than all coming due on the same check.
+func (s *service) syncDue(podcast *models.Podcast, now time.Time) bool {
+	if podcast.NextSyncRetryAt != nil {
+		return false
+	}
+	if podcast.LastSyncedAt == nil {
+		return true
+	}
+	interval := s.syncInterval(podcast)
+	return !podcast.LastSyncedAt.Add(interval + syncStagger(podcast.ID, interval)).After(now)
+}
+
+// syncStagger returns a podcast's offset for its sync interval, derived from its ID
+func syncStagger(podcastID uuid.UUID, interval time.Duration) time.Duration {
+	spread := uint64(interval / maxSyncStaggerFraction)
+	if spread == 0 {
+		return 0
+	}
+	return time.Duration(binary.BigEndian.Uint64(podcastID[8:]) % spread)
+}
+
+// syncInterval returns the time between scheduled syncs of a podcast
+func (s *service) syncInterval(podcast *models.Podcast) time.Duration {
+	if podcast.SyncIntervalMinutes != nil && *podcast.SyncIntervalMinutes > 0 {
+		return time.Duration(*podcast.SyncIntervalMinutes) * time.Minute
+	}
+	return s.cfg.Content.SyncInterval
+}
+
+// RetryFailedSyncs re-synchronizes podcasts whose failed sync is due for a retry
+func (s *service) RetryFailedSyncs(ctx context.Context) ([]models.RSSFeedSyncResult, error) {
+	podcasts, err := s.repo.GetPodcastsDueForSyncRetry(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get podcasts due for retry: %w", err)
+	}
+
+	return s.syncPodcasts(ctx, podcasts, nil)
+}
+
+// syncPodcasts syncs the given podcasts with at most the configured number of syncs in flight.
+// Once ctx is cancelled no further syncs are started, but those already running are still collected.
+// Results are in completion order, and are also passed to onResult, if set, as each sync finishes.
+func (s *service) syncPodcasts(ctx context.Context, podcasts []*models.Podcast, onResult func(models.RSSFeedSyncResult)) ([]models.RSSFeedSyncResult, error) {
+	workers := s.cfg.Content.SyncConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(podcasts) {
+		workers = len(podcasts)
+	}
+
+	jobs := make(chan *models.Podcast)
+	resultCh := make(chan models.RSSFeedSyncResult, len(podcasts))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for podcast := range jobs {
+				// A podcast already being synced, e.g. manually, is reported as failed rather than synced twice
+				result, err := s.SyncPodcast(ctx, podcast.ID)
+				if result == nil {
+					result = &models.RSSFeedSyncResult{PodcastID: podcast.ID}
+					if err != nil {
+						result.ErrorMessage = err.Error()
+					}
+				}
+				if onResult != nil {
+					onResult(*result)
+				}
+				resultCh <- *result
+			}
+		}()
+	}
+
+dispatch:
+	for _, podcast := range podcasts {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- podcast:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	close(resultCh)
+
+	results := make([]models.RSSFeedSyncResult, 0, len(podcasts))
+	for result := range resultCh {
+		results = append(results, result)
+	}
+
+	return results, ctx.Err()
+}
+
+// GetSyncStatus gets the latest sync status for a podcast
+func (s *service) GetSyncStatus(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error) {
+	return s.repo.GetLatestSyncLog(ctx, podcastID)
+}
+
+// logSyncSuccess records a successful sync in the sync log
+func (s *service) logSyncSuccess(ctx context.Context, podcastID uuid.UUID, episodesAdded, episodesUpdated, episodesRemoved int, warnings []string) {
+	s.createSyncLog(ctx, &models.RSSFeedSyncLog{
+		PodcastID:       podcastID,
+		Status:          "success",
+		EpisodesAdded:   episodesAdded,
+		EpisodesUpdated: episodesUpdated,
+		EpisodesRemoved: episodesRemoved,
+		Warnings:        strings.Join(warnings, "; "),
+	})
+}
+
+// logSyncFailure records a failed sync in the sync log, with the reason it failed and the
+// HTTP status the feed server answered with, if any, and tells the podcast's webhooks
+func (s *service) logSyncFailure(ctx context.Context, podcastID uuid.UUID, episodesAdded, episodesUpdated int, reason string, httpStatus int, errorMessage string) {
+	s.createSyncLog(ctx, &models.RSSFeedSyncLog{
+		PodcastID:       podcastID,
+		Status:          "failure",
+		EpisodesAdded:   episodesAdded,
+		EpisodesUpdated: episodesUpdated,
+		ErrorMessage:    errorMessage,
+		FailureReason:   reason,
+		HTTPStatus:      httpStatus,
+	})
+	s.dispatchWebhook(ctx, podcastID, models.WebhookEventSyncFailed, models.WebhookSyncFailedData{
+		FailureReason:  reason,
+		HTTPStatus:     httpStatus,
+		FailureMessage: models.SyncFailureMessage(reason, httpStatus),
+	})
+}
+
+// movedFeedURL returns the URL a podcast's feed permanently moved to, or an empty string when
+// it hasn't moved or the new URL already belongs to another podcast
+func (s *service) movedFeedURL(ctx context.Context, podcast *models.Podcast, movedTo string) string {
+	if movedTo == "" || movedTo == podcast.RSSUrl {
+		return ""
+	}
+
+	existing, err := s.repo.GetPodcastByRSSURL(ctx, movedTo)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to check moved RSS URL",
+			logger.Field("podcast_id", podcast.ID),
+			logger.Field("error", err))
+		return ""
+	}
+	if existing != nil && existing.ID != podcast.ID {
+		logger.FromContext(ctx).Warn("Feed moved to the feed of another podcast, keeping its URL",
+			logger.Field("podcast_id", podcast.ID),
+			logger.Field("moved_to", movedTo),
+			logger.Field("other_podcast_id", existing.ID),
+			logger.Field("rss_url", podcast.RSSUrl))
+		return ""
+	}
+
+	logger.FromContext(ctx).Info("Feed permanently moved",
+		logger.Field("podcast_id", podcast.ID),
+		logger.Field("from", podcast.RSSUrl),
+		logger.Field("to", movedTo))
+	return movedTo
+}
+
+// createSyncLog writes a sync log entry; failures are only logged so they never mask the sync outcome
+func (s *service) createSyncLog(ctx context.Context, syncLog *models.RSSFeedSyncLog) {
+	if err := s.repo.CreateSyncLog(ctx, syncLog); err != nil {
+		logger.FromContext(ctx).Error("Failed to write sync log",
+			logger.Field("podcast_id", syncLog.PodcastID),
+			logger.Field("error", err))
+	}
+}
+
+// dispatchWebhook queues an event for the podcast's webhooks; failures are only logged so they never fail the sync
+func (s *service) dispatchWebhook(ctx context.Context, podcastID uuid.UUID, event string, data interface{}) {
+	if err := s.webhooks.Dispatch(ctx, podcastID, event, data); err != nil {
+		logger.FromContext(ctx).Error("Failed to queue webhook event",
+			logger.Field("podcast_id", podcastID),
+			logger.Field("event", event),
+			logger.Field("error", err))
+	}
+}
+
+// updateRetryState resets the failure count after a successful sync, or schedules a backed-off
+// retry after a failed one, suspending the feed once it has failed too many times in a row
+func (s *service) updateRetryState(ctx context.Context, podcastID uuid.UUID, success bool) {
+	if success {
+		if err := s.repo.ResetSyncFailures(ctx, podcastID); err != nil {
+			logger.FromContext(ctx).Error("Failed to reset sync failures",
+				logger.Field("podcast_id", podcastID),
+				logger.Field("error", err))
+		}
+		return
+	}
+
+	failureCount, suspended, err := s.repo.RecordSyncFailure(ctx, podcastID, s.cfg.Content.SyncMaxFailures)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to record sync failure",
+			logger.Field("podcast_id", podcastID),
+			logger.Field("error", err))
+		return
+	}
+
+	if suspended {
+		logger.FromContext(ctx).Warn("Suspended sync after consecutive failures",
+			logger.Field("podcast_id", podcastID),
+			logger.Field("failures", failureCount))
+		return
+	}
+
+	retryAt := time.Now().UTC().Add(s.retryBackoff(failureCount))
+	if err := s.repo.ScheduleSyncRetry(ctx, podcastID, retryAt); err != nil {
+		logger.FromContext(ctx).Error("Failed to schedule sync retry",
+			logger.Field("podcast_id", podcastID),
+			logger.Field("error", err))
+	}
+}
+
+// retryBackoff returns the delay before retrying a feed that has failed failureCount times in a row,
+// doubling from the base delay up to the configured maximum
+func (s *service) retryBackoff(failureCount int) time.Duration {
+	delay := s.cfg.Content.SyncRetryBaseDelay
+	maxDelay := s.cfg.Content.SyncRetryMaxDelay
+	for i := 1; i < failureCount && i < 32 && (maxDelay <= 0 || delay < maxDelay); i++ {
+		delay *= 2
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
\ No newline at end of file
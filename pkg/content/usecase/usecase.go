@@ -3,52 +3,177 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"html"
+	"image"
+	_ "image/gif"  // register decoders for cover image uploads
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"math"
+	"mime/multipart"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	auditModels "github.com/MHK-26/pod_platfrom_go/pkg/audit/models"
+	auditUsecase "github.com/MHK-26/pod_platfrom_go/pkg/audit/usecase"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/pagination"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/publicnet"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/storage"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/moderation"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/repository/postgres"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/rss"
 	"github.com/MHK-26/pod_platfrom_go/pkg/content/sync"
 )
 
+// DuplicateFeedError is returned when a feed is already in the catalog, so that clients can
+// point the user at the existing podcast instead
+type DuplicateFeedError struct {
+	PodcastID uuid.UUID
+}
+
+func (e *DuplicateFeedError) Error() string {
+	return "a podcast with this RSS feed already exists"
+}
+
+// maxCommentLength is the longest comment accepted, in characters
+const maxCommentLength = 2000
+
+// maxReviewLength is the longest podcast review accepted, in characters
+const maxReviewLength = 5000
+
+// Limits on chapters set by hand
+const (
+	maxChapters          = 500
+	maxChapterTitleLength = 200
+)
+
+// maxTranscriptQueryLength is the longest transcript search query accepted, in characters
+const maxTranscriptQueryLength = 200
+
+// maxPreviewEpisodes is the most episodes shown in a feed preview
+const maxPreviewEpisodes = 10
+
+// maxBatchEpisodes is the most episodes that can be fetched in one batch
+const maxBatchEpisodes = 100
+
+// Bounds on the width and height of uploaded cover art, in pixels
+const (
+	minCoverImageDimension = 300
+	maxCoverImageDimension = 3000
+)
+
 // Usecase defines the methods for the content usecase
 type Usecase interface {
 	// Podcast methods
 	CreatePodcast(ctx context.Context, podcasterID uuid.UUID, req *models.CreatePodcastRequest, feed *models.RSSFeed) (*models.Podcast, error)
-	GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.PodcastResponse, error)
-	GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, page, pageSize int) ([]*models.PodcastResponse, int, error)
+	GetPodcastByID(ctx context.Context, id uuid.UUID, viewer models.Viewer) (*models.PodcastResponse, error)
+	GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, viewer models.Viewer, page, pageSize int) ([]*models.PodcastResponse, int, error)
 	UpdatePodcast(ctx context.Context, id, podcasterID uuid.UUID, req *models.UpdatePodcastRequest) (*models.Podcast, error)
 	DeletePodcast(ctx context.Context, id, podcasterID uuid.UUID) error
-	ListPodcasts(ctx context.Context, params models.PodcastSearchParams) ([]*models.PodcastResponse, int, error)
+	ListPodcasts(ctx context.Context, params models.PodcastSearchParams, viewer models.Viewer) ([]*models.PodcastResponse, int, error)
 	IsUserAuthorizedForPodcast(ctx context.Context, podcastID, userID uuid.UUID) (bool, error)
+	PinEpisode(ctx context.Context, podcastID, podcasterID, episodeID uuid.UUID) error
+	UnpinEpisode(ctx context.Context, podcastID, podcasterID uuid.UUID) error
+	UploadPodcastCover(ctx context.Context, podcastID, podcasterID uuid.UUID, file *multipart.FileHeader) (*models.Podcast, error)
 	
 	// RSS feed methods
 	ParseRSSFeed(ctx context.Context, url string) (*models.RSSFeed, error)
+	PreviewFeed(ctx context.Context, url string) (*models.FeedPreview, error)
 	SyncPodcastFromRSS(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncResult, error)
-	SyncAllPodcasts(ctx context.Context) ([]models.RSSFeedSyncResult, error)
-	GetLatestSyncLog(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error)
-	GetSyncLogs(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.RSSFeedSyncLog, int, error)
+	SyncAllPodcasts(ctx context.Context, force bool) ([]models.RSSFeedSyncResult, error)
+	StartSyncAll(ctx context.Context, force bool) (*models.SyncAllJob, error)
+	GetSyncAllJob(ctx context.Context, jobID uuid.UUID) (*models.SyncAllJob, error)
+	RetryFailedSyncs(ctx context.Context) ([]models.RSSFeedSyncResult, error)
+	RefreshPodcastListenCounts(ctx context.Context) (int, error)
+	GetSyncSuspendedPodcasts(ctx context.Context, page, pageSize int) ([]*models.PodcastResponse, int, error)
+	ResumePodcastSync(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool) error
+	GetLatestSyncLog(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool) (*models.RSSFeedSyncLog, error)
+	GetSyncLogs(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool, page, pageSize int) ([]*models.RSSFeedSyncLog, int, error)
+	
+	// Webhook methods
+	CreateWebhook(ctx context.Context, podcastID, userID uuid.UUID, req *models.CreateWebhookRequest) (*models.CreateWebhookResponse, error)
+	GetWebhooks(ctx context.Context, podcastID, userID uuid.UUID) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, podcastID, webhookID, userID uuid.UUID) error
+	GetWebhookDeliveries(ctx context.Context, podcastID, webhookID, userID uuid.UUID, page, pageSize int) ([]*models.WebhookDelivery, int, error)
 	
 	// Episode methods
-	GetEpisodeByID(ctx context.Context, id uuid.UUID) (*models.EpisodeResponse, error)
-	GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.EpisodeResponse, int, error)
+	GetEpisodeByID(ctx context.Context, id uuid.UUID, viewer models.Viewer) (*models.EpisodeResponse, error)
+	GetEpisodesByIDs(ctx context.Context, ids []uuid.UUID, viewer models.Viewer) ([]*models.EpisodeResponse, error)
+	GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, episodeType string, viewer models.Viewer, page, pageSize int) ([]*models.EpisodeResponse, int, error)
+	GetEpisodesByPodcastIDAfter(ctx context.Context, podcastID uuid.UUID, episodeType string, viewer models.Viewer, after *pagination.Cursor, pageSize int) ([]*models.EpisodeResponse, *pagination.Cursor, error)
+	SearchEpisodes(ctx context.Context, params models.EpisodeSearchParams, viewer models.Viewer) ([]*models.EpisodeResponse, int, error)
+	Search(ctx context.Context, params models.SearchParams, viewer models.Viewer) ([]*models.SearchResult, int, error)
+	BulkDeleteEpisodes(ctx context.Context, podcastID, podcasterID uuid.UUID, req *models.BulkDeleteEpisodesRequest) (int, error)
+	GetEpisodeStreamURL(ctx context.Context, id uuid.UUID, viewer models.Viewer) (string, error)
+	GetEpisodeOEmbed(ctx context.Context, shareURL string, maxWidth, maxHeight int) (*models.OEmbedResponse, error)
+	TakeDownEpisode(ctx context.Context, id, adminID uuid.UUID, reason string) error
+	RefreshEpisode(ctx context.Context, id, userID uuid.UUID) (*models.EpisodeResponse, error)
+	SetEpisodeChapters(ctx context.Context, id, userID uuid.UUID, chapters models.Chapters) (*models.EpisodeResponse, error)
+	SearchTranscript(ctx context.Context, id uuid.UUID, query string, page, pageSize int) ([]*models.TranscriptMatch, int, error)
+	
+	// Short link methods
+	GetOrCreateShortLink(ctx context.Context, req *models.CreateShortLinkRequest) (*models.ShortLinkResponse, bool, error)
+	ResolveShortLink(ctx context.Context, code, referrer, userAgent string) (string, error)
 	
 	// Category methods
 	GetCategories(ctx context.Context) ([]*models.Category, error)
 	
+	// Comment methods
+	AddComment(ctx context.Context, userID uuid.UUID, req *models.CreateCommentRequest) (*models.Comment, error)
+	ListComments(ctx context.Context, episodeID uuid.UUID, page, pageSize int) ([]*models.Comment, int, error)
+	DeleteComment(ctx context.Context, commentID, userID uuid.UUID) error
+	
+	// Review methods
+	SaveReview(ctx context.Context, podcastID, userID uuid.UUID, req *models.SaveReviewRequest) (*models.PodcastReview, error)
+	ListReviews(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.PodcastReview, int, error)
+	DeleteReview(ctx context.Context, podcastID, userID uuid.UUID) error
+	ListCommentsForModeration(ctx context.Context, params models.CommentModerationParams) ([]*models.Comment, int, error)
+	GetCommentCountsByStatus(ctx context.Context) (map[string]int, error)
+	ModerateComment(ctx context.Context, commentID, adminID uuid.UUID, status string) error
+	RemoveComment(ctx context.Context, commentID, adminID uuid.UUID) error
+	
+	// Report methods
+	ReportContent(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID, req *models.CreateReportRequest) (*models.ContentReport, error)
+	ListReports(ctx context.Context, params models.ReportListParams) ([]*models.ContentReport, int, error)
+	GetReportCountsByStatus(ctx context.Context) (map[string]int, error)
+	ResolveReport(ctx context.Context, reportID, adminID uuid.UUID, status string) error
+	
+	// Suspension methods
+	SuspendPodcast(ctx context.Context, podcastID, adminID uuid.UUID, reason string) error
+	UnsuspendPodcast(ctx context.Context, podcastID, adminID uuid.UUID) error
+	
 	// Subscription methods
-	SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error
+	SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error)
 	UnsubscribeFromPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error
 	GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PodcastResponse, int, error)
 	IsSubscribed(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error)
+	ImportSubscriptions(ctx context.Context, listenerID uuid.UUID, opml io.Reader) (*models.OPMLImportResult, error)
 	
 	// Playback history methods
 	SavePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID, position int, completed bool) error
 	GetPlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) (int, bool, error)
+	GetPlaybackPositions(ctx context.Context, listenerID uuid.UUID, episodeIDs []uuid.UUID) ([]*models.PlaybackPosition, error)
+	MarkEpisodePlayed(ctx context.Context, listenerID, episodeID uuid.UUID) error
+	MarkEpisodeUnplayed(ctx context.Context, listenerID, episodeID uuid.UUID) error
 	GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PlaybackHistory, int, error)
+	GetContinueListening(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ContinueListeningItem, int, error)
+	
+	// Notification methods
+	GetNotifications(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Notification, int, error)
+	MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error
 	
 	// Like methods
 	LikeEpisode(ctx context.Context, listenerID, episodeID uuid.UUID) error
@@ -61,15 +186,24 @@ type usecase struct {
 	repo           postgres.Repository
 	rssParser      rss.Parser
 	syncService    sync.Service
+	audit          auditUsecase.Usecase
+	storage        storage.Service
+	commentFilter  moderation.Filter
 	cfg            *config.Config
 	contextTimeout time.Duration
 }
 
 // NewUsecase creates a new content usecase
-func NewUsecase(repo postgres.Repository, syncService sync.Service, cfg *config.Config, timeout time.Duration) Usecase {
+func NewUsecase(repo postgres.Repository, syncService sync.Service, audit auditUsecase.Usecase, store storage.Service, cfg *config.Config, timeout time.Duration) Usecase {
 	return &usecase{
 		repo:           repo,
 		syncService:    syncService,
+		audit:          audit,
+		storage:        store,
+		commentFilter: moderation.Chain(
+			moderation.NewWordListFilter(cfg.Content.CommentBlockedWords),
+			moderation.NewLinkFilter(cfg.Content.CommentMaxLinks),
+		),
 		cfg:            cfg,
 		contextTimeout: timeout,
 	}
@@ -80,10 +214,15 @@ func (u *usecase) CreatePodcast(ctx context.Context, podcasterID uuid.UUID, req
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
+	rssURL, err := rss.NormalizeFeedURL(req.RSSUrl)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Create podcast model using the feed data
 	podcast := &models.Podcast{
 		PodcasterID:    podcasterID,
-		RSSUrl:         req.RSSUrl,
+		RSSUrl:         rssURL,
 		Status:         "active",
 	}
 	
@@ -92,12 +231,14 @@ func (u *usecase) CreatePodcast(ctx context.Context, podcasterID uuid.UUID, req
 		podcast.Title = feed.Title
 		podcast.Description = feed.Description 
 		podcast.CoverImageURL = feed.CoverImageURL
+		podcast.FeedCoverImageURL = feed.CoverImageURL
 		podcast.WebsiteURL = feed.WebsiteURL
 		podcast.Language = feed.Language
 		podcast.Author = feed.Author
 		podcast.Category = feed.Category
 		podcast.Subcategory = feed.Subcategory
 		podcast.Explicit = feed.Explicit
+		podcast.Keywords = feed.Keywords
 	} else {
 		// Otherwise use data from request
 		podcast.Description = req.Description
@@ -106,7 +247,7 @@ func (u *usecase) CreatePodcast(ctx context.Context, podcasterID uuid.UUID, req
 	}
 	
 	// Create podcast in database
-	err := u.repo.CreatePodcast(ctx, podcast)
+	err = u.repo.CreatePodcast(ctx, podcast)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +256,7 @@ func (u *usecase) CreatePodcast(ctx context.Context, podcasterID uuid.UUID, req
 }
 
 // GetPodcastByID gets a podcast by ID
-func (u *usecase) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.PodcastResponse, error) {
+func (u *usecase) GetPodcastByID(ctx context.Context, id uuid.UUID, viewer models.Viewer) (*models.PodcastResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
@@ -123,9 +264,20 @@ func (u *usecase) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.Pod
 	if err != nil {
 		return nil, err
 	}
+	if !canViewPodcast(podcast, viewer) {
+		return nil, models.ErrPodcastNotFound
+	}
 	
 	// Get latest episodes
-	episodes, _, err := u.repo.GetEpisodesByPodcastID(ctx, id, 1, 5)
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+	episodes, _, err := u.repo.GetEpisodesByPodcastID(ctx, id, "", hideExplicit, 1, 5)
+	if err != nil {
+		return nil, err
+	}
+	listenStats, err := u.episodeListenStats(ctx, episodes)
 	if err != nil {
 		return nil, err
 	}
@@ -133,26 +285,59 @@ func (u *usecase) GetPodcastByID(ctx context.Context, id uuid.UUID) (*models.Pod
 	// Convert episodes to episode responses
 	latestEpisodes := make([]models.EpisodeResponse, 0, len(episodes))
 	for _, episode := range episodes {
-		latestEpisodes = append(latestEpisodes, models.EpisodeResponse{
+		episodeResponse := models.EpisodeResponse{
 			Episode:          *episode,
 			PodcastTitle:     podcast.Title,
 			PodcastAuthor:    podcast.Author,
-			PodcastImageURL:  podcast.CoverImageURL,
-		})
+			PodcastImageURL:  u.podcastCoverURL(podcast),
+		}
+		u.applyDefaultEpisodeCover(&episodeResponse, podcast.Category)
+		u.applyListenStats(&episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
+		latestEpisodes = append(latestEpisodes, episodeResponse)
 	}
 	
 	// Create podcast response
 	podcastResponse := &models.PodcastResponse{
-		Podcast:        *podcast,
-		EpisodeCount:   podcast.EpisodeCount,
-		LatestEpisodes: latestEpisodes,
+		Podcast:         *podcast,
+		EpisodeCount:    podcast.EpisodeCount,
+		SubscriberCount: podcast.SubscriberCount,
+		LatestEpisodes:  latestEpisodes,
+	}
+	u.applyDefaultPodcastCover(podcastResponse)
+	podcastResponse.ListenCount, podcastResponse.ListenCountBadge = u.visibleListenCount(podcast.ListenCount, podcastResponse.PodcasterID, viewer)
+	
+	// Surface the pinned episode as long as it is still listenable
+	if podcast.PinnedEpisodeID != nil {
+		pinned, err := u.repo.GetEpisodeByID(ctx, *podcast.PinnedEpisodeID)
+		if err != nil && !errors.Is(err, models.ErrEpisodeNotFound) {
+			return nil, err
+		}
+		if pinned != nil && pinned.Status == "active" {
+			pinnedStats, err := u.episodeListenStats(ctx, []*models.Episode{pinned})
+			if err != nil {
+				return nil, err
+			}
+			pinnedResponse := &models.EpisodeResponse{
+				Episode:         *pinned,
+				PodcastTitle:    podcast.Title,
+				PodcastAuthor:   podcast.Author,
+				PodcastImageURL: u.podcastCoverURL(podcast),
+			}
+			u.applyDefaultEpisodeCover(pinnedResponse, podcast.Category)
+			u.applyListenStats(pinnedResponse, pinnedStats[pinned.ID], podcast.PodcasterID, viewer)
+			podcastResponse.PinnedEpisode = pinnedResponse
+		}
+	}
+	
+	if err := u.applyRatings(ctx, []*models.PodcastResponse{podcastResponse}); err != nil {
+		return nil, err
 	}
 	
 	return podcastResponse, nil
 }
 
 // GetPodcastsByPodcasterID gets podcasts by podcaster ID
-func (u *usecase) GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, page, pageSize int) ([]*models.PodcastResponse, int, error) {
+func (u *usecase) GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid.UUID, viewer models.Viewer, page, pageSize int) ([]*models.PodcastResponse, int, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
@@ -165,12 +350,19 @@ func (u *usecase) GetPodcastsByPodcasterID(ctx context.Context, podcasterID uuid
 	podcastResponses := make([]*models.PodcastResponse, 0, len(podcasts))
 	for _, podcast := range podcasts {
 		podcastResponse := &models.PodcastResponse{
-			Podcast:      *podcast,
-			EpisodeCount: podcast.EpisodeCount,
+			Podcast:         *podcast,
+			EpisodeCount:    podcast.EpisodeCount,
+			SubscriberCount: podcast.SubscriberCount,
 		}
+		u.applyDefaultPodcastCover(podcastResponse)
+		podcastResponse.ListenCount, podcastResponse.ListenCountBadge = u.visibleListenCount(podcast.ListenCount, podcastResponse.PodcasterID, viewer)
 		podcastResponses = append(podcastResponses, podcastResponse)
 	}
 	
+	if err := u.applyRatings(ctx, podcastResponses); err != nil {
+		return nil, 0, err
+	}
+	
 	return podcastResponses, totalCount, nil
 }
 
@@ -187,15 +379,26 @@ func (u *usecase) UpdatePodcast(ctx context.Context, id, podcasterID uuid.UUID,
 	
 	// Check if user is authorized to update podcast
 	if podcast.PodcasterID != podcasterID {
-		return nil, errors.New("not authorized")
+		return nil, models.ErrNotAuthorized
 	}
+	before := *podcast
 	
 	// Update fields
 	if req.Description != "" {
 		podcast.Description = req.Description
 	}
 	if req.RSSUrl != "" {
+		rssURL, err := rss.NormalizeFeedURL(req.RSSUrl)
+		if err != nil {
+			return nil, err
+		}
+		req.RSSUrl = rssURL
+	}
+	if req.RSSUrl != "" && req.RSSUrl != podcast.RSSUrl {
 		podcast.RSSUrl = req.RSSUrl
+		// Cache validators of the old feed mean nothing to the new one
+		podcast.FeedETag = ""
+		podcast.FeedLastModified = ""
 	}
 	if req.Category != "" {
 		podcast.Category = req.Category
@@ -203,7 +406,20 @@ func (u *usecase) UpdatePodcast(ctx context.Context, id, podcasterID uuid.UUID,
 	if req.Subcategory != "" {
 		podcast.Subcategory = req.Subcategory
 	}
-	podcast.UpdatedAt = time.Now()
+	if req.PruneMissing != nil {
+		podcast.PruneMissing = *req.PruneMissing
+	}
+	if req.SyncIntervalMinutes != nil {
+		switch minutes := *req.SyncIntervalMinutes; {
+		case minutes == 0:
+			podcast.SyncIntervalMinutes = nil
+		case minutes < models.MinSyncIntervalMinutes:
+			return nil, models.ErrInvalidSyncInterval
+		default:
+			podcast.SyncIntervalMinutes = &minutes
+		}
+	}
+	podcast.UpdatedAt = time.Now().UTC()
 	
 	// Update podcast in database
 	err = u.repo.UpdatePodcast(ctx, podcast)
@@ -211,6 +427,8 @@ func (u *usecase) UpdatePodcast(ctx context.Context, id, podcasterID uuid.UUID,
 		return nil, err
 	}
 	
+	u.recordAudit(ctx, auditModels.NewAuditEntry(podcasterID, auditModels.ActionPodcastUpdate, auditModels.TargetPodcast, id.String(), before, podcast))
+	
 	return podcast, nil
 }
 
@@ -227,18 +445,34 @@ func (u *usecase) DeletePodcast(ctx context.Context, id, podcasterID uuid.UUID)
 	
 	// Check if user is authorized to delete podcast
 	if podcast.PodcasterID != podcasterID {
-		return errors.New("not authorized")
+		return models.ErrNotAuthorized
 	}
 	
 	// Delete podcast from database
-	return u.repo.DeletePodcast(ctx, id)
+	if err := u.repo.DeletePodcast(ctx, id); err != nil {
+		return err
+	}
+	
+	u.recordAudit(ctx, auditModels.NewAuditEntry(podcasterID, auditModels.ActionPodcastDelete, auditModels.TargetPodcast, id.String(), podcast, nil))
+	
+	return nil
 }
 
 // ListPodcasts lists podcasts with optional filtering
-func (u *usecase) ListPodcasts(ctx context.Context, params models.PodcastSearchParams) ([]*models.PodcastResponse, int, error) {
+func (u *usecase) ListPodcasts(ctx context.Context, params models.PodcastSearchParams, viewer models.Viewer) ([]*models.PodcastResponse, int, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	params.HideExplicit = hideExplicit
+	params.Language, err = u.listingLanguage(ctx, params.Language, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	
 	podcasts, totalCount, err := u.repo.ListPodcasts(ctx, params)
 	if err != nil {
 		return nil, 0, err
@@ -248,159 +482,260 @@ func (u *usecase) ListPodcasts(ctx context.Context, params models.PodcastSearchP
 	podcastResponses := make([]*models.PodcastResponse, 0, len(podcasts))
 	for _, podcast := range podcasts {
 		podcastResponse := &models.PodcastResponse{
-			Podcast:      *podcast,
-			EpisodeCount: podcast.EpisodeCount,
+			Podcast:         *podcast,
+			EpisodeCount:    podcast.EpisodeCount,
+			SubscriberCount: podcast.SubscriberCount,
 		}
+		u.applyDefaultPodcastCover(podcastResponse)
+		podcastResponse.ListenCount, podcastResponse.ListenCountBadge = u.visibleListenCount(podcast.ListenCount, podcastResponse.PodcasterID, viewer)
 		podcastResponses = append(podcastResponses, podcastResponse)
 	}
 	
+	if err := u.applyRatings(ctx, podcastResponses); err != nil {
+		return nil, 0, err
+	}
+	
 	return podcastResponses, totalCount, nil
 }
 
-// IsUserAuthorizedForPodcast checks if a user is authorized for a podcast
-func (u *usecase) IsUserAuthorizedForPodcast(ctx context.Context, podcastID, userID uuid.UUID) (bool, error) {
+// PinEpisode pins one of the podcast's episodes to the top of its page, replacing any previous pin
+func (u *usecase) PinEpisode(ctx context.Context, podcastID, podcasterID, episodeID uuid.UUID) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.IsUserAuthorizedForPodcast(ctx, podcastID, userID)
-}
-
-// ParseRSSFeed parses an RSS feed from a URL
-func (u *usecase) ParseRSSFeed(ctx context.Context, url string) (*models.RSSFeed, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+	// Get podcast
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return err
+	}
 	
-	// Check if a podcast with this RSS URL already exists
-	existingPodcast, err := u.repo.GetPodcastByRSSURL(ctx, url)
-	if err == nil && existingPodcast != nil {
-		return nil, errors.New("a podcast with this RSS feed already exists")
+	// Check if user is authorized to pin episodes of this podcast
+	if podcast.PodcasterID != podcasterID {
+		return models.ErrNotAuthorized
 	}
 	
-	// Parse the feed using the RSS parser from the sync service
-	return u.syncService.(sync.Service).ParseFeed(ctx, url)
-}
-
-// SyncPodcastFromRSS syncs a podcast from its RSS feed
-func (u *usecase) SyncPodcastFromRSS(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncResult, error) {
-	return u.syncService.SyncPodcast(ctx, podcastID)
-}
-
-// SyncAllPodcasts syncs all podcasts from their RSS feeds
-func (u *usecase) SyncAllPodcasts(ctx context.Context) ([]models.RSSFeedSyncResult, error) {
-	return u.syncService.SyncAllPodcasts(ctx)
-}
-
-// GetLatestSyncLog gets the latest sync log for a podcast
-func (u *usecase) GetLatestSyncLog(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncLog, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+	// Only an active episode of this very podcast can be pinned
+	episode, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+	if episode.PodcastID != podcastID {
+		return models.ErrEpisodeNotInPodcast
+	}
+	if episode.Status != "active" {
+		return models.ErrEpisodeNotActive
+	}
 	
-	return u.repo.GetLatestSyncLog(ctx, podcastID)
+	return u.repo.SetPinnedEpisode(ctx, podcastID, &episodeID)
 }
 
-// GetSyncLogs gets the sync logs for a podcast
-func (u *usecase) GetSyncLogs(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.RSSFeedSyncLog, int, error) {
+// UploadPodcastCover stores uploaded cover art for a podcast, replacing the cover from its feed.
+// The cover is marked as set by the podcaster so feed syncs keep it until the feed image changes.
+func (u *usecase) UploadPodcastCover(ctx context.Context, podcastID, podcasterID uuid.UUID, file *multipart.FileHeader) (*models.Podcast, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.GetSyncLogs(ctx, podcastID, page, pageSize)
-}
-
-// GetEpisodeByID gets an episode by ID
-func (u *usecase) GetEpisodeByID(ctx context.Context, id uuid.UUID) (*models.EpisodeResponse, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+	if u.storage == nil {
+		return nil, errors.New("file storage is not configured")
+	}
 	
-	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	// Get podcast
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
 	if err != nil {
 		return nil, err
 	}
 	
-	// Get podcast details
-	podcast, err := u.repo.GetPodcastByID(ctx, episode.PodcastID)
+	// Check if user is authorized to change the cover of this podcast
+	if podcast.PodcasterID != podcasterID {
+		return nil, models.ErrNotAuthorized
+	}
+	
+	if err := checkCoverImage(file); err != nil {
+		return nil, err
+	}
+	before := *podcast
+	
+	// Save the image under the podcast's own directory
+	path, err := u.storage.SaveImage(file, "podcast-covers/"+podcastID.String())
 	if err != nil {
 		return nil, err
 	}
+	imageURL := u.storage.GetFileURL(path)
 	
-	// Create episode response
-	episodeResponse := &models.EpisodeResponse{
-		Episode:         *episode,
-		PodcastTitle:    podcast.Title,
-		PodcastAuthor:   podcast.Author,
-		PodcastImageURL: podcast.CoverImageURL,
+	if err := u.repo.UpdatePodcastCoverImage(ctx, podcastID, imageURL, path); err != nil {
+		_ = u.storage.DeleteFile(path)
+		return nil, err
 	}
+	podcast.CoverImageURL = imageURL
+	podcast.CoverImagePath = path
+	podcast.CoverImageUserSet = true
 	
-	return episodeResponse, nil
+	// Remove the previously uploaded cover, which is no longer referenced
+	if before.CoverImagePath != "" {
+		_ = u.storage.DeleteFile(before.CoverImagePath)
+	}
+	
+	u.recordAudit(ctx, auditModels.NewAuditEntry(podcasterID, auditModels.ActionPodcastUpdate, auditModels.TargetPodcast, podcastID.String(), before, podcast))
+	
+	return podcast, nil
 }
 
-// GetEpisodesByPodcastID gets episodes by podcast ID
-func (u *usecase) GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.EpisodeResponse, int, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+// checkCoverImage checks that an upload decodes as an image with cover-sized dimensions
+func checkCoverImage(file *multipart.FileHeader) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
 	
-	episodes, totalCount, err := u.repo.GetEpisodesByPodcastID(ctx, podcastID, page, pageSize)
+	imageConfig, _, err := image.DecodeConfig(src)
 	if err != nil {
-		return nil, 0, err
+		return models.ErrInvalidImage
 	}
 	
-	// Get podcast details
+	for _, dimension := range []int{imageConfig.Width, imageConfig.Height} {
+		if dimension < minCoverImageDimension || dimension > maxCoverImageDimension {
+			return models.ErrInvalidImageDimensions
+		}
+	}
+	
+	return nil
+}
+
+// UnpinEpisode removes the pinned episode of a podcast
+func (u *usecase) UnpinEpisode(ctx context.Context, podcastID, podcasterID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Get podcast
 	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
 	if err != nil {
-		return nil, 0, err
+		return err
 	}
 	
-	// Convert episodes to episode responses
-	episodeResponses := make([]*models.EpisodeResponse, 0, len(episodes))
-	for _, episode := range episodes {
-		episodeResponse := &models.EpisodeResponse{
-			Episode:         *episode,
-			PodcastTitle:    podcast.Title,
-			PodcastAuthor:   podcast.Author,
-			PodcastImageURL: podcast.CoverImageURL,
-		}
-		episodeResponses = append(episodeResponses, episodeResponse)
+	// Check if user is authorized to unpin episodes of this podcast
+	if podcast.PodcasterID != podcasterID {
+		return models.ErrNotAuthorized
 	}
 	
-	return episodeResponses, totalCount, nil
+	return u.repo.SetPinnedEpisode(ctx, podcastID, nil)
 }
 
-// GetCategories gets all categories
-func (u *usecase) GetCategories(ctx context.Context) ([]*models.Category, error) {
+// IsUserAuthorizedForPodcast checks if a user is authorized for a podcast
+func (u *usecase) IsUserAuthorizedForPodcast(ctx context.Context, podcastID, userID uuid.UUID) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.GetCategories(ctx)
+	return u.repo.IsUserAuthorizedForPodcast(ctx, podcastID, userID)
 }
 
-// SubscribeToPodcast subscribes a listener to a podcast
-func (u *usecase) SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error {
+// ParseRSSFeed parses an RSS feed from a URL
+func (u *usecase) ParseRSSFeed(ctx context.Context, url string) (*models.RSSFeed, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	// Check if podcast exists
-	_, err := u.repo.GetPodcastByID(ctx, podcastID)
+	// Catch malformed URLs before fetching anything, and compare URLs the way they are stored
+	url, err := rss.NormalizeFeedURL(url)
 	if err != nil {
-		return errors.New("podcast not found")
+		return nil, err
 	}
 	
-	// Subscribe to podcast
-	return u.repo.SubscribeToPodcast(ctx, listenerID, podcastID)
+	// Check if a podcast with this RSS URL already exists
+	existingPodcast, err := u.repo.GetPodcastByRSSURL(ctx, url)
+	if err == nil && existingPodcast != nil {
+		return nil, &DuplicateFeedError{PodcastID: existingPodcast.ID}
+	}
+	
+	// Parse the feed using the RSS parser from the sync service
+	return u.syncService.(sync.Service).ParseFeed(ctx, url)
 }
 
-// UnsubscribeFromPodcast unsubscribes a listener from a podcast
-func (u *usecase) UnsubscribeFromPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error {
+// PreviewFeed parses a feed and describes the podcast and episodes importing it would create,
+// without saving anything. Unlike ParseRSSFeed it doesn't reject feeds already in the catalog.
+func (u *usecase) PreviewFeed(ctx context.Context, url string) (*models.FeedPreview, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.UnsubscribeFromPodcast(ctx, listenerID, podcastID)
+	url, err := rss.NormalizeFeedURL(url)
+	if err != nil {
+		return nil, err
+	}
+	
+	feed, err := u.syncService.ParseFeed(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	
+	sample := feed.Items
+	if len(sample) > maxPreviewEpisodes {
+		sample = sample[:maxPreviewEpisodes]
+	}
+	
+	return &models.FeedPreview{
+		Title:          feed.Title,
+		Description:    feed.Description,
+		Language:       feed.Language,
+		Author:         feed.Author,
+		CoverImageURL:  feed.CoverImageURL,
+		WebsiteURL:     feed.WebsiteURL,
+		Category:       feed.Category,
+		Subcategory:    feed.Subcategory,
+		Explicit:       feed.Explicit,
+		Keywords:       feed.Keywords,
+		EpisodeCount:   len(feed.Items),
+		SampleEpisodes: sample,
+		Warnings:       feed.Warnings,
+	}, nil
 }
 
-// GetSubscribedPodcasts gets podcasts subscribed by a listener
-func (u *usecase) GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PodcastResponse, int, error) {
+// SyncPodcastFromRSS syncs a podcast from its RSS feed
+func (u *usecase) SyncPodcastFromRSS(ctx context.Context, podcastID uuid.UUID) (*models.RSSFeedSyncResult, error) {
+	return u.syncService.SyncPodcast(ctx, podcastID)
+}
+
+// SyncAllPodcasts syncs the podcasts due for a sync from their RSS feeds, or all of them when force is set
+func (u *usecase) SyncAllPodcasts(ctx context.Context, force bool) ([]models.RSSFeedSyncResult, error) {
+	return u.syncService.SyncAllPodcasts(ctx, force)
+}
+
+// StartSyncAll starts a full sync in the background. It runs past the request, so it doesn't
+// take the request's context.
+func (u *usecase) StartSyncAll(ctx context.Context, force bool) (*models.SyncAllJob, error) {
+	return u.syncService.StartSyncAll(force)
+}
+
+// GetSyncAllJob gets the progress of a full sync job
+func (u *usecase) GetSyncAllJob(ctx context.Context, jobID uuid.UUID) (*models.SyncAllJob, error) {
+	return u.syncService.GetSyncAllJob(jobID)
+}
+
+// RetryFailedSyncs re-syncs podcasts whose failed sync is due for a retry
+func (u *usecase) RetryFailedSyncs(ctx context.Context) ([]models.RSSFeedSyncResult, error) {
+	return u.syncService.RetryFailedSyncs(ctx)
+}
+
+// RefreshPodcastListenCounts copies the podcasts' listen counts from the listen events, for
+// listings sorted by listens
+func (u *usecase) RefreshPodcastListenCounts(ctx context.Context) (int, error) {
+	return u.repo.RefreshPodcastListenCounts(ctx)
+}
+
+// GetSyncSuspendedPodcasts gets podcasts whose feed sync was suspended after repeated failures
+func (u *usecase) GetSyncSuspendedPodcasts(ctx context.Context, page, pageSize int) ([]*models.PodcastResponse, int, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	podcasts, totalCount, err := u.repo.GetSubscribedPodcasts(ctx, listenerID, page, pageSize)
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	
+	// Only admins list suspended feeds
+	viewer := models.Viewer{IsAdmin: true}
+	
+	podcasts, totalCount, err := u.repo.GetSyncSuspendedPodcasts(ctx, page, pageSize)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -409,62 +744,1541 @@ func (u *usecase) GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUI
 	podcastResponses := make([]*models.PodcastResponse, 0, len(podcasts))
 	for _, podcast := range podcasts {
 		podcastResponse := &models.PodcastResponse{
-			Podcast:      *podcast,
-			EpisodeCount: podcast.EpisodeCount,
+			Podcast:         *podcast,
+			EpisodeCount:    podcast.EpisodeCount,
+			SubscriberCount: podcast.SubscriberCount,
 		}
+		u.applyDefaultPodcastCover(podcastResponse)
+		podcastResponse.ListenCount, podcastResponse.ListenCountBadge = u.visibleListenCount(podcast.ListenCount, podcastResponse.PodcasterID, viewer)
 		podcastResponses = append(podcastResponses, podcastResponse)
 	}
 	
-	return podcastResponses, totalCount, nil
-}
-
-// IsSubscribed checks if a listener is subscribed to a podcast
-func (u *usecase) IsSubscribed(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+	if err := u.applyRatings(ctx, podcastResponses); err != nil {
+		return nil, 0, err
+	}
 	
-	return u.repo.IsSubscribed(ctx, listenerID, podcastID)
+	return podcastResponses, totalCount, nil
 }
 
-// SavePlaybackPosition saves the playback position for an episode
-func (u *usecase) SavePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID, position int, completed bool) error {
+// ResumePodcastSync re-enables the feed sync of a podcast, clearing its failure history
+func (u *usecase) ResumePodcastSync(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	// Check if episode exists
-	_, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	// Get podcast
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
 	if err != nil {
-		return errors.New("episode not found")
+		return err
 	}
 	
-	return u.repo.SavePlaybackPosition(ctx, listenerID, episodeID, position, completed)
-}
-
-// GetPlaybackPosition gets the playback position for an episode
-func (u *usecase) GetPlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) (int, bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
-	defer cancel()
+	// Only the owner or an admin may resume the sync
+	if !isAdmin && podcast.PodcasterID != userID {
+		return models.ErrNotAuthorized
+	}
 	
-	return u.repo.GetPlaybackPosition(ctx, listenerID, episodeID)
+	if err := u.repo.ResetSyncFailures(ctx, podcastID); err != nil {
+		return err
+	}
+	
+	u.recordAudit(ctx, auditModels.NewAuditEntry(
+		userID,
+		auditModels.ActionPodcastSyncResume,
+		auditModels.TargetPodcast,
+		podcastID.String(),
+		map[string]interface{}{"sync_suspended": podcast.SyncSuspended, "sync_failure_count": podcast.SyncFailureCount},
+		map[string]interface{}{"sync_suspended": false, "sync_failure_count": 0},
+	))
+	
+	return nil
 }
 
-// GetListeningHistory gets the listening history for a listener
-func (u *usecase) GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PlaybackHistory, int, error) {
+// GetLatestSyncLog gets the latest sync log for a podcast. Only the owner or an admin may see it.
+func (u *usecase) GetLatestSyncLog(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool) (*models.RSSFeedSyncLog, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.GetListeningHistory(ctx, listenerID, page, pageSize)
+	if err := u.checkSyncLogAccess(ctx, podcastID, userID, isAdmin); err != nil {
+		return nil, err
+	}
+	
+	syncLog, err := u.repo.GetLatestSyncLog(ctx, podcastID)
+	if err != nil || syncLog == nil {
+		return syncLog, err
+	}
+	
+	syncLog.FailureMessage = models.SyncFailureMessage(syncLog.FailureReason, syncLog.HTTPStatus)
+	return syncLog, nil
 }
 
-// LikeEpisode likes an episode
-func (u *usecase) LikeEpisode(ctx context.Context, listenerID, episodeID uuid.UUID) error {
+// GetSyncLogs gets the sync history of a podcast, newest first. Only the owner or an admin may see it.
+func (u *usecase) GetSyncLogs(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool, page, pageSize int) ([]*models.RSSFeedSyncLog, int, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	// Check if episode exists
-	_, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err := u.checkSyncLogAccess(ctx, podcastID, userID, isAdmin); err != nil {
+		return nil, 0, err
+	}
+	
+	logs, total, err := u.repo.GetSyncLogs(ctx, podcastID, page, pageSize)
 	if err != nil {
-		return errors.New("episode not found")
+		return nil, 0, err
+	}
+	
+	for _, syncLog := range logs {
+		syncLog.FailureMessage = models.SyncFailureMessage(syncLog.FailureReason, syncLog.HTTPStatus)
+	}
+	
+	return logs, total, nil
+}
+
+// checkSyncLogAccess makes sure the user may see the sync logs of a podcast, which only its owner and admins can
+func (u *usecase) checkSyncLogAccess(ctx context.Context, podcastID, userID uuid.UUID, isAdmin bool) error {
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return err
+	}
+	
+	if !isAdmin && podcast.PodcasterID != userID {
+		return models.ErrNotAuthorized
+	}
+	
+	return nil
+}
+
+// maxWebhooksPerPodcast bounds the webhooks of a podcast, as every event is sent to each of them
+const maxWebhooksPerPodcast = 10
+
+// CreateWebhook registers a webhook for a podcast. The secret its payloads are signed with is
+// generated here and only returned this once.
+func (u *usecase) CreateWebhook(ctx context.Context, podcastID, userID uuid.UUID, req *models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if err := u.checkWebhookAccess(ctx, podcastID, userID); err != nil {
+		return nil, err
+	}
+	
+	webhookURL, err := url.Parse(req.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return nil, models.ErrInvalidWebhookURL
+	}
+	
+	// Webhooks may not point into the platform's own network
+	if err := publicnet.CheckHost(ctx, webhookURL.Hostname()); err != nil {
+		return nil, models.ErrInvalidWebhookURL
+	}
+	
+	existing, err := u.repo.GetWebhooksByPodcastID(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebhooksPerPodcast {
+		return nil, models.ErrTooManyWebhooks
+	}
+	
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	
+	// Each event only once, in a stable order
+	events := append([]string(nil), req.Events...)
+	sort.Strings(events)
+	events = slices.Compact(events)
+	
+	webhook := &models.Webhook{
+		ID:        uuid.New(),
+		PodcastID: podcastID,
+		URL:       webhookURL.String(),
+		Secret:    secret,
+		Events:    events,
+		CreatedBy: &userID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := u.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	
+	return &models.CreateWebhookResponse{Webhook: *webhook, Secret: secret}, nil
+}
+
+// GetWebhooks gets the webhooks of a podcast
+func (u *usecase) GetWebhooks(ctx context.Context, podcastID, userID uuid.UUID) ([]*models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if err := u.checkWebhookAccess(ctx, podcastID, userID); err != nil {
+		return nil, err
+	}
+	
+	return u.repo.GetWebhooksByPodcastID(ctx, podcastID)
+}
+
+// DeleteWebhook deletes a webhook of a podcast, dropping its pending deliveries
+func (u *usecase) DeleteWebhook(ctx context.Context, podcastID, webhookID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if _, err := u.podcastWebhook(ctx, podcastID, webhookID, userID); err != nil {
+		return err
+	}
+	
+	return u.repo.DeleteWebhook(ctx, webhookID)
+}
+
+// GetWebhookDeliveries gets the delivery log of a webhook of a podcast, newest first
+func (u *usecase) GetWebhookDeliveries(ctx context.Context, podcastID, webhookID, userID uuid.UUID, page, pageSize int) ([]*models.WebhookDelivery, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if _, err := u.podcastWebhook(ctx, podcastID, webhookID, userID); err != nil {
+		return nil, 0, err
+	}
+	
+	return u.repo.GetWebhookDeliveries(ctx, webhookID, page, pageSize)
+}
+
+// checkWebhookAccess makes sure the user may manage the webhooks of a podcast, which only its owner can
+func (u *usecase) checkWebhookAccess(ctx context.Context, podcastID, userID uuid.UUID) error {
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return err
+	}
+	
+	if podcast.PodcasterID != userID {
+		return models.ErrNotAuthorized
+	}
+	
+	return nil
+}
+
+// podcastWebhook gets a webhook the user may manage, which must belong to the given podcast
+func (u *usecase) podcastWebhook(ctx context.Context, podcastID, webhookID, userID uuid.UUID) (*models.Webhook, error) {
+	if err := u.checkWebhookAccess(ctx, podcastID, userID); err != nil {
+		return nil, err
+	}
+	
+	webhook, err := u.repo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.PodcastID != podcastID {
+		return nil, models.ErrWebhookNotFound
+	}
+	
+	return webhook, nil
+}
+
+// generateWebhookSecret generates a random secret for signing webhook payloads
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// GetEpisodeByID gets an episode by ID
+func (u *usecase) GetEpisodeByID(ctx context.Context, id uuid.UUID, viewer models.Viewer) (*models.EpisodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Get podcast details
+	podcast, err := u.repo.GetPodcastByID(ctx, episode.PodcastID)
+	if err != nil {
+		return nil, err
+	}
+	if !canViewPodcast(podcast, viewer) {
+		return nil, models.ErrEpisodeNotFound
+	}
+	listenStats, err := u.episodeListenStats(ctx, []*models.Episode{episode})
+	if err != nil {
+		return nil, err
+	}
+	
+	// Create episode response
+	episodeResponse := &models.EpisodeResponse{
+		Episode:         *episode,
+		PodcastTitle:    podcast.Title,
+		PodcastAuthor:   podcast.Author,
+		PodcastImageURL: u.podcastCoverURL(podcast),
+	}
+	u.applyDefaultEpisodeCover(episodeResponse, podcast.Category)
+	u.applyListenStats(episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
+	
+	// Keep the metadata of taken down episodes but never hand out their audio
+	if episode.Status == "taken_down" {
+		episodeResponse.AudioURL = ""
+	}
+	
+	return episodeResponse, nil
+}
+
+// GetEpisodesByIDs gets the episodes with the given IDs, in the order of the IDs. Unknown IDs are skipped.
+func (u *usecase) GetEpisodesByIDs(ctx context.Context, ids []uuid.UUID, viewer models.Viewer) ([]*models.EpisodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if len(ids) > maxBatchEpisodes {
+		return nil, models.ErrTooManyEpisodeIDs
+	}
+	if len(ids) == 0 {
+		return []*models.EpisodeResponse{}, nil
+	}
+	
+	episodes, err := u.repo.GetEpisodesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	listenStats, err := u.episodeListenStats(ctx, episodes)
+	if err != nil {
+		return nil, err
+	}
+	
+	byID := make(map[uuid.UUID]*models.Episode, len(episodes))
+	for _, episode := range episodes {
+		byID[episode.ID] = episode
+	}
+	
+	// Episodes of a batch usually share a few podcasts, so look each up once
+	podcasts := make(map[uuid.UUID]*models.Podcast)
+	episodeResponses := make([]*models.EpisodeResponse, 0, len(episodes))
+	for _, id := range ids {
+		episode, ok := byID[id]
+		if !ok {
+			continue
+		}
+		delete(byID, id) // skip repeated IDs
+		
+		podcast, ok := podcasts[episode.PodcastID]
+		if !ok {
+			podcast, err = u.repo.GetPodcastByID(ctx, episode.PodcastID)
+			if err != nil {
+				return nil, err
+			}
+			podcasts[episode.PodcastID] = podcast
+		}
+		
+		episodeResponse := &models.EpisodeResponse{
+			Episode:         *episode,
+			PodcastTitle:    podcast.Title,
+			PodcastAuthor:   podcast.Author,
+			PodcastImageURL: u.podcastCoverURL(podcast),
+		}
+		u.applyDefaultEpisodeCover(episodeResponse, podcast.Category)
+		u.applyListenStats(episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
+		if episode.Status == "taken_down" {
+			episodeResponse.AudioURL = ""
+		}
+		episodeResponses = append(episodeResponses, episodeResponse)
+	}
+	
+	return episodeResponses, nil
+}
+
+// GetEpisodesByPodcastID gets episodes by podcast ID, optionally only those of the given episode type
+func (u *usecase) GetEpisodesByPodcastID(ctx context.Context, podcastID uuid.UUID, episodeType string, viewer models.Viewer, page, pageSize int) ([]*models.EpisodeResponse, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	episodes, totalCount, err := u.repo.GetEpisodesByPodcastID(ctx, podcastID, episodeType, hideExplicit, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	// Get podcast details
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canViewPodcast(podcast, viewer) {
+		return nil, 0, models.ErrPodcastNotFound
+	}
+	
+	episodeResponses, err := u.podcastEpisodeResponses(ctx, episodes, podcast, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	return episodeResponses, totalCount, nil
+}
+
+// GetEpisodesByPodcastIDAfter gets the page of a podcast's episodes following the cursor, newest
+// first by creation time, along with the cursor of the next page if there is one
+func (u *usecase) GetEpisodesByPodcastIDAfter(ctx context.Context, podcastID uuid.UUID, episodeType string, viewer models.Viewer, after *pagination.Cursor, pageSize int) ([]*models.EpisodeResponse, *pagination.Cursor, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	// Fetch one episode more than the page holds to tell whether there is a next page
+	episodes, err := u.repo.GetEpisodesByPodcastIDAfter(ctx, podcastID, episodeType, hideExplicit, after, pageSize+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	var next *pagination.Cursor
+	if len(episodes) > pageSize {
+		episodes = episodes[:pageSize]
+		last := episodes[len(episodes)-1]
+		next = &pagination.Cursor{Time: last.CreatedAt, ID: last.ID.String()}
+	}
+	
+	// Get podcast details
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !canViewPodcast(podcast, viewer) {
+		return nil, nil, models.ErrPodcastNotFound
+	}
+	
+	episodeResponses, err := u.podcastEpisodeResponses(ctx, episodes, podcast, viewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	
+	return episodeResponses, next, nil
+}
+
+// podcastEpisodeResponses converts episodes of the podcast to episode responses for the viewer
+func (u *usecase) podcastEpisodeResponses(ctx context.Context, episodes []*models.Episode, podcast *models.Podcast, viewer models.Viewer) ([]*models.EpisodeResponse, error) {
+	listenStats, err := u.episodeListenStats(ctx, episodes)
+	if err != nil {
+		return nil, err
+	}
+	
+	episodeResponses := make([]*models.EpisodeResponse, 0, len(episodes))
+	for _, episode := range episodes {
+		episodeResponse := &models.EpisodeResponse{
+			Episode:         *episode,
+			PodcastTitle:    podcast.Title,
+			PodcastAuthor:   podcast.Author,
+			PodcastImageURL: u.podcastCoverURL(podcast),
+		}
+		u.applyDefaultEpisodeCover(episodeResponse, podcast.Category)
+		u.applyListenStats(episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
+		episodeResponses = append(episodeResponses, episodeResponse)
+	}
+	return episodeResponses, nil
+}
+
+// SearchEpisodes searches episodes across podcasts
+func (u *usecase) SearchEpisodes(ctx context.Context, params models.EpisodeSearchParams, viewer models.Viewer) ([]*models.EpisodeResponse, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	params.HideExplicit = hideExplicit
+	
+	episodes, totalCount, err := u.repo.ListEpisodes(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	listenStats, err := u.episodeListenStats(ctx, episodes)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	// Convert episodes to episode responses, fetching each podcast once
+	podcasts := make(map[uuid.UUID]*models.Podcast)
+	episodeResponses := make([]*models.EpisodeResponse, 0, len(episodes))
+	for _, episode := range episodes {
+		podcast, ok := podcasts[episode.PodcastID]
+		if !ok {
+			podcast, err = u.repo.GetPodcastByID(ctx, episode.PodcastID)
+			if err != nil {
+				return nil, 0, err
+			}
+			podcasts[episode.PodcastID] = podcast
+		}
+		
+		episodeResponse := &models.EpisodeResponse{
+			Episode:         *episode,
+			PodcastTitle:    podcast.Title,
+			PodcastAuthor:   podcast.Author,
+			PodcastImageURL: u.podcastCoverURL(podcast),
+		}
+		u.applyDefaultEpisodeCover(episodeResponse, podcast.Category)
+		u.applyListenStats(episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
+		episodeResponses = append(episodeResponses, episodeResponse)
+	}
+	
+	return episodeResponses, totalCount, nil
+}
+
+// Search searches podcasts and episodes together, best matches first
+func (u *usecase) Search(ctx context.Context, params models.SearchParams, viewer models.Viewer) ([]*models.SearchResult, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Query == "" {
+		return nil, 0, models.ErrEmptyQuery
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	
+	hideExplicit, err := u.hidesExplicit(ctx, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	params.HideExplicit = hideExplicit
+	params.Language, err = u.listingLanguage(ctx, params.Language, viewer)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	results, totalCount, err := u.repo.Search(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	for _, result := range results {
+		result.ImageURL = u.coverImageOrDefault(result.ImageURL, result.Category)
+	}
+	
+	return results, totalCount, nil
+}
+
+// BulkDeleteEpisodes archives the selected episodes of a podcast owned by the podcaster
+func (u *usecase) BulkDeleteEpisodes(ctx context.Context, podcastID, podcasterID uuid.UUID, req *models.BulkDeleteEpisodesRequest) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Refuse an empty selection rather than archiving the whole podcast
+	if len(req.EpisodeIDs) == 0 && req.PublishedBefore == nil {
+		return 0, models.ErrNoEpisodesSelected
+	}
+	
+	// Get podcast
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return 0, err
+	}
+	
+	// Check if user is authorized to delete episodes of this podcast
+	if podcast.PodcasterID != podcasterID {
+		return 0, models.ErrNotAuthorized
+	}
+	
+	auditEntry := auditModels.NewAuditEntry(podcasterID, auditModels.ActionEpisodeBulkDelete, auditModels.TargetPodcast, podcastID.String(), nil, req)
+	return u.repo.ArchiveEpisodes(ctx, podcastID, req.EpisodeIDs, req.PublishedBefore, auditEntry)
+}
+
+// GetEpisodeStreamURL gets the audio URL to stream an episode from
+func (u *usecase) GetEpisodeStreamURL(ctx context.Context, id uuid.UUID, viewer models.Viewer) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	
+	if episode.Status == "taken_down" {
+		return "", models.ErrEpisodeTakenDown
+	}
+	if episode.Status != "active" || episode.AudioURL == "" {
+		return "", models.ErrEpisodeNotFound
+	}
+	
+	podcast, err := u.repo.GetPodcastByID(ctx, episode.PodcastID)
+	if err != nil {
+		return "", err
+	}
+	if !canViewPodcast(podcast, viewer) {
+		return "", models.ErrEpisodeNotFound
+	}
+	
+	return episode.AudioURL, nil
+}
+
+// RefreshEpisode re-reads an episode's metadata from its podcast's feed on behalf of the podcast's owner
+func (u *usecase) RefreshEpisode(ctx context.Context, id, userID uuid.UUID) (*models.EpisodeResponse, error) {
+	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Check if user is authorized to refresh episodes of this podcast
+	isAuthorized, err := u.repo.IsUserAuthorizedForPodcast(ctx, episode.PodcastID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, models.ErrNotAuthorized
+	}
+	
+	// The feed fetch is bounded by the parser's own timeout rather than the usecase timeout
+	if _, err := u.syncService.RefreshEpisode(ctx, id); err != nil {
+		return nil, err
+	}
+	
+	return u.GetEpisodeByID(ctx, id, models.Viewer{UserID: userID})
+}
+
+// SetEpisodeChapters replaces an episode's chapters with ones set by its podcast's owner.
+// Chapters from the feed no longer override them on sync.
+func (u *usecase) SetEpisodeChapters(ctx context.Context, id, userID uuid.UUID, chapters models.Chapters) (*models.EpisodeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	
+	isAuthorized, err := u.repo.IsUserAuthorizedForPodcast(ctx, episode.PodcastID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuthorized {
+		return nil, models.ErrNotAuthorized
+	}
+	
+	chapters, err = normalizeChapters(chapters, episode.Duration)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := u.repo.SetEpisodeChapters(ctx, id, chapters); err != nil {
+		return nil, err
+	}
+	
+	return u.GetEpisodeByID(ctx, id, models.Viewer{UserID: userID})
+}
+
+// SearchTranscript finds the places in an episode's transcript matching a query. Matches in
+// timed transcripts carry their start time; all of them carry their offset in the transcript text.
+func (u *usecase) SearchTranscript(ctx context.Context, id uuid.UUID, query string, page, pageSize int) ([]*models.TranscriptMatch, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, models.ErrEmptyQuery
+	}
+	if utf8.RuneCountInString(query) > maxTranscriptQueryLength {
+		query = string([]rune(query)[:maxTranscriptQueryLength])
+	}
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if episode.Status != "active" {
+		return nil, 0, models.ErrEpisodeNotFound
+	}
+	
+	return u.repo.SearchTranscript(ctx, id, query, page, pageSize)
+}
+
+// normalizeChapters validates chapters set by hand and sorts them by start time. Chapters must
+// have a title and start within the episode, and their links must be web URLs.
+func normalizeChapters(chapters models.Chapters, duration int) (models.Chapters, error) {
+	if len(chapters) > maxChapters {
+		return nil, models.ErrTooManyChapters
+	}
+	
+	normalized := make(models.Chapters, 0, len(chapters))
+	for _, chapter := range chapters {
+		chapter.Title = strings.TrimSpace(chapter.Title)
+		if chapter.Title == "" || utf8.RuneCountInString(chapter.Title) > maxChapterTitleLength {
+			return nil, models.ErrInvalidChapters
+		}
+		if chapter.StartTime < 0 || (duration > 0 && chapter.StartTime >= float64(duration)) {
+			return nil, models.ErrInvalidChapters
+		}
+		if !isWebURL(chapter.ImageURL) || !isWebURL(chapter.URL) {
+			return nil, models.ErrInvalidChapters
+		}
+		normalized = append(normalized, chapter)
+	}
+	
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].StartTime < normalized[j].StartTime
+	})
+	
+	return normalized, nil
+}
+
+// isWebURL reports whether an optional link is empty or an absolute http(s) URL
+func isWebURL(link string) bool {
+	if link == "" {
+		return true
+	}
+	parsed, err := url.Parse(link)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// GetEpisodeOEmbed builds the oEmbed response for an episode share URL on our site.
+// The embedded player is shrunk to fit maxWidth and maxHeight when they are set.
+func (u *usecase) GetEpisodeOEmbed(ctx context.Context, shareURL string, maxWidth, maxHeight int) (*models.OEmbedResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	episodeID, err := u.parseEpisodeShareURL(shareURL)
+	if err != nil {
+		return nil, err
+	}
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Only active episodes can be embedded
+	if episode.Status != "active" {
+		return nil, models.ErrEpisodeNotFound
+	}
+	
+	podcast, err := u.repo.GetPodcastByID(ctx, episode.PodcastID)
+	if err != nil {
+		return nil, err
+	}
+	
+	width := u.cfg.Content.EmbedWidth
+	if maxWidth > 0 && width > maxWidth {
+		width = maxWidth
+	}
+	height := u.cfg.Content.EmbedHeight
+	if maxHeight > 0 && height > maxHeight {
+		height = maxHeight
+	}
+	
+	playerURL := u.cfg.Content.EmbedPlayerURL + "/" + episode.ID.String()
+	
+	thumbnailURL := episode.CoverImageURL
+	if thumbnailURL == "" {
+		thumbnailURL = u.coverImageOrDefault(u.podcastCoverURL(podcast), podcast.Category)
+	}
+	
+	return &models.OEmbedResponse{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        episode.Title,
+		AuthorName:   podcast.Title,
+		ProviderName: "Podcast Platform",
+		ProviderURL:  u.cfg.Content.SiteURL,
+		HTML: fmt.Sprintf(
+			`<iframe src="%s" width="%d" height="%d" frameborder="0" scrolling="no" allow="autoplay" title="%s"></iframe>`,
+			html.EscapeString(playerURL), width, height, html.EscapeString(episode.Title),
+		),
+		Width:        width,
+		Height:       height,
+		ThumbnailURL: thumbnailURL,
+	}, nil
+}
+
+// TakeDownEpisode disables an episode's audio while keeping its record, recording who took it down and why
+func (u *usecase) TakeDownEpisode(ctx context.Context, id, adminID uuid.UUID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	auditEntry := auditModels.NewAuditEntry(
+		adminID,
+		auditModels.ActionEpisodeTakedown,
+		auditModels.TargetEpisode,
+		id.String(),
+		nil,
+		map[string]string{"status": "taken_down", "reason": reason},
+	)
+	
+	return u.repo.TakeDownEpisode(ctx, &models.EpisodeTakedown{
+		EpisodeID:   id,
+		TakenDownBy: adminID,
+		Reason:      reason,
+	}, auditEntry)
+}
+
+// GetOrCreateShortLink returns the short link of an episode or podcast, creating it on first use.
+// The returned flag reports whether a new link was created.
+func (u *usecase) GetOrCreateShortLink(ctx context.Context, req *models.CreateShortLinkRequest) (*models.ShortLinkResponse, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Make sure the target exists and is public
+	switch req.TargetType {
+	case "episode":
+		episode, err := u.repo.GetEpisodeByID(ctx, req.TargetID)
+		if err != nil {
+			return nil, false, err
+		}
+		if episode.Status != "active" {
+			return nil, false, models.ErrEpisodeNotFound
+		}
+	case "podcast":
+		if _, err := u.repo.GetPodcastByID(ctx, req.TargetID); err != nil {
+			return nil, false, err
+		}
+	default:
+		return nil, false, models.ErrInvalidTargetType
+	}
+	
+	link, err := u.repo.GetShortLinkByTarget(ctx, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, false, err
+	}
+	if link != nil {
+		return u.shortLinkResponse(link), false, nil
+	}
+	
+	for attempt := 0; attempt < shortLinkCodeAttempts; attempt++ {
+		code, err := generateShortLinkCode()
+		if err != nil {
+			return nil, false, err
+		}
+		
+		link = &models.ShortLink{
+			Code:       code,
+			TargetType: req.TargetType,
+			TargetID:   req.TargetID,
+		}
+		created, err := u.repo.CreateShortLink(ctx, link)
+		if err != nil {
+			return nil, false, err
+		}
+		if created {
+			return u.shortLinkResponse(link), true, nil
+		}
+		
+		// Either the code collided or a concurrent request linked the same target
+		existing, err := u.repo.GetShortLinkByTarget(ctx, req.TargetType, req.TargetID)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			return u.shortLinkResponse(existing), false, nil
+		}
+	}
+	
+	return nil, false, errors.New("failed to generate a unique short link code")
+}
+
+// ResolveShortLink returns the canonical URL a short link points to and records the click
+func (u *usecase) ResolveShortLink(ctx context.Context, code, referrer, userAgent string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	link, err := u.repo.GetShortLinkByCode(ctx, code)
+	if err != nil {
+		return "", err
+	}
+	
+	// A failure to track the click must not break the redirect
+	_ = u.repo.RecordShortLinkClick(ctx, &models.ShortLinkClick{
+		ShortLinkID: link.ID,
+		Referrer:    referrer,
+		UserAgent:   userAgent,
+	})
+	
+	return u.cfg.Content.SiteURL + "/" + link.TargetType + "s/" + link.TargetID.String(), nil
+}
+
+// GetCategories gets all categories
+func (u *usecase) GetCategories(ctx context.Context) ([]*models.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetCategories(ctx)
+}
+
+// AddComment adds a comment to an episode, running it through the comment filter first.
+// Depending on the configured action, comments tripping the filter are rejected or
+// stored as flagged, hidden from listeners until a moderator reviews them.
+func (u *usecase) AddComment(ctx context.Context, userID uuid.UUID, req *models.CreateCommentRequest) (*models.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, models.ErrCommentEmpty
+	}
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		return nil, models.ErrCommentTooLong
+	}
+	
+	// Only active episodes can be commented on
+	episode, err := u.repo.GetEpisodeByID(ctx, req.EpisodeID)
+	if err != nil {
+		return nil, err
+	}
+	if episode.Status != "active" {
+		return nil, models.ErrEpisodeNotFound
+	}
+	
+	comment := &models.Comment{
+		UserID:    userID,
+		EpisodeID: req.EpisodeID,
+		Content:   content,
+		Status:    "active",
+	}
+	
+	if reason := u.commentFilter.Check(content); reason != "" {
+		if u.cfg.Content.CommentFilterAction == moderation.ActionReject {
+			return nil, models.ErrCommentRejected
+		}
+		comment.Status = "flagged"
+		comment.FlagReason = reason
+	}
+	
+	if err := u.repo.AddComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	
+	return comment, nil
+}
+
+// SaveReview rates and reviews a podcast. A listener has one review per podcast, which saving
+// again replaces. Reviews tripping the content filter are rejected or held for review like comments.
+func (u *usecase) SaveReview(ctx context.Context, podcastID, userID uuid.UUID, req *models.SaveReviewRequest) (*models.PodcastReview, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, models.ErrInvalidRating
+	}
+	content := strings.TrimSpace(req.Content)
+	if utf8.RuneCountInString(content) > maxReviewLength {
+		return nil, models.ErrReviewTooLong
+	}
+	
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return nil, err
+	}
+	if podcast.PodcasterID == userID {
+		return nil, models.ErrOwnPodcastReview
+	}
+	
+	review := &models.PodcastReview{
+		PodcastID: podcastID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Content:   content,
+		Status:    "active",
+	}
+	
+	if content != "" {
+		if reason := u.commentFilter.Check(content); reason != "" {
+			if u.cfg.Content.CommentFilterAction == moderation.ActionReject {
+				return nil, models.ErrReviewRejected
+			}
+			review.Status = "flagged"
+			review.FlagReason = reason
+		}
+	}
+	
+	if err := u.repo.SaveReview(ctx, review); err != nil {
+		return nil, err
+	}
+	
+	return review, nil
+}
+
+// ListReviews lists the visible reviews of a podcast, most recently written or edited first
+func (u *usecase) ListReviews(ctx context.Context, podcastID uuid.UUID, page, pageSize int) ([]*models.PodcastReview, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetReviewsByPodcastID(ctx, podcastID, page, pageSize)
+}
+
+// DeleteReview deletes a listener's own review of a podcast
+func (u *usecase) DeleteReview(ctx context.Context, podcastID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.DeleteReview(ctx, podcastID, userID)
+}
+
+// ListComments lists the visible comments of an episode, newest first
+func (u *usecase) ListComments(ctx context.Context, episodeID uuid.UUID, page, pageSize int) ([]*models.Comment, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetCommentsByEpisodeID(ctx, episodeID, page, pageSize)
+}
+
+// DeleteComment deletes a comment on behalf of its author
+func (u *usecase) DeleteComment(ctx context.Context, commentID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.DeleteComment(ctx, commentID, userID)
+}
+
+// ListCommentsForModeration lists comments of any status for moderators
+func (u *usecase) ListCommentsForModeration(ctx context.Context, params models.CommentModerationParams) ([]*models.Comment, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	
+	return u.repo.ListCommentsForModeration(ctx, params)
+}
+
+// GetCommentCountsByStatus counts the comments of each status, e.g. for a badge of comments awaiting review
+func (u *usecase) GetCommentCountsByStatus(ctx context.Context) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetCommentCountsByStatus(ctx)
+}
+
+// ModerateComment sets the status of any comment on behalf of an admin: hidden comments
+// disappear from episodes, flagged ones wait in the review queue and active ones are shown
+func (u *usecase) ModerateComment(ctx context.Context, commentID, adminID uuid.UUID, status string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	switch status {
+	case "active", "hidden", "flagged":
+	default:
+		return models.ErrInvalidCommentStatus
+	}
+	
+	auditEntry := auditModels.NewAuditEntry(
+		adminID,
+		auditModels.ActionCommentModerate,
+		auditModels.TargetComment,
+		commentID.String(),
+		nil,
+		map[string]string{"status": status},
+	)
+	
+	return u.repo.SetCommentStatus(ctx, commentID, status, auditEntry)
+}
+
+// RemoveComment deletes any comment on behalf of an admin
+func (u *usecase) RemoveComment(ctx context.Context, commentID, adminID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	auditEntry := auditModels.NewAuditEntry(adminID, auditModels.ActionCommentRemove, auditModels.TargetComment, commentID.String(), nil, nil)
+	return u.repo.RemoveComment(ctx, commentID, auditEntry)
+}
+
+// ReportContent records a user's report of a comment or podcast for the admins to review.
+// Users can report each comment or podcast once.
+func (u *usecase) ReportContent(ctx context.Context, reporterID uuid.UUID, targetType string, targetID uuid.UUID, req *models.CreateReportRequest) (*models.ContentReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Only content the user can see can be reported
+	switch targetType {
+	case "comment":
+		comment, err := u.repo.GetCommentByID(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if comment.Status != "active" {
+			return nil, models.ErrCommentNotFound
+		}
+	case "podcast":
+		podcast, err := u.repo.GetPodcastByID(ctx, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if !canViewPodcast(podcast, models.Viewer{UserID: reporterID}) {
+			return nil, models.ErrPodcastNotFound
+		}
+	default:
+		return nil, models.ErrInvalidTargetType
+	}
+	
+	report := &models.ContentReport{
+		ReporterID: reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     req.Reason,
+		Details:    strings.TrimSpace(req.Details),
+	}
+	
+	created, err := u.repo.CreateReport(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, models.ErrAlreadyReported
+	}
+	
+	return report, nil
+}
+
+// ListReports lists content reports for the admins, oldest first unless sorted otherwise
+func (u *usecase) ListReports(ctx context.Context, params models.ReportListParams) ([]*models.ContentReport, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 20
+	}
+	
+	return u.repo.ListReports(ctx, params)
+}
+
+// GetReportCountsByStatus counts the reports of each status, e.g. for a badge of reports awaiting review
+func (u *usecase) GetReportCountsByStatus(ctx context.Context) (map[string]int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetReportCountsByStatus(ctx)
+}
+
+// ResolveReport closes an open report on behalf of an admin, as reviewed when they acted on
+// it or dismissed when they didn't
+func (u *usecase) ResolveReport(ctx context.Context, reportID, adminID uuid.UUID, status string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if status != "reviewed" && status != "dismissed" {
+		return models.ErrInvalidReportStatus
+	}
+	
+	auditEntry := auditModels.NewAuditEntry(
+		adminID,
+		auditModels.ActionReportResolve,
+		auditModels.TargetReport,
+		reportID.String(),
+		nil,
+		map[string]string{"status": status},
+	)
+	
+	return u.repo.ResolveReport(ctx, reportID, status, adminID, auditEntry)
+}
+
+// SuspendPodcast hides a podcast and its episodes from everyone but its owner and admins
+func (u *usecase) SuspendPodcast(ctx context.Context, podcastID, adminID uuid.UUID, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	auditEntry := auditModels.NewAuditEntry(
+		adminID,
+		auditModels.ActionPodcastSuspend,
+		auditModels.TargetPodcast,
+		podcastID.String(),
+		nil,
+		map[string]string{"status": "suspended", "reason": reason},
+	)
+	
+	return u.repo.SuspendPodcast(ctx, podcastID, auditEntry)
+}
+
+// UnsuspendPodcast makes a suspended podcast public again
+func (u *usecase) UnsuspendPodcast(ctx context.Context, podcastID, adminID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	auditEntry := auditModels.NewAuditEntry(
+		adminID,
+		auditModels.ActionPodcastUnsuspend,
+		auditModels.TargetPodcast,
+		podcastID.String(),
+		nil,
+		map[string]string{"status": "active"},
+	)
+	
+	return u.repo.UnsuspendPodcast(ctx, podcastID, auditEntry)
+}
+
+// canViewPodcast reports whether a viewer may see a podcast. Suspended podcasts are only
+// visible to their owner and admins, and podcasts of deleted accounts only to admins.
+func canViewPodcast(podcast *models.Podcast, viewer models.Viewer) bool {
+	switch podcast.Status {
+	case "suspended":
+		return viewer.IsAdmin || (viewer.UserID != uuid.Nil && viewer.UserID == podcast.PodcasterID)
+	case "deleted":
+		return viewer.IsAdmin
+	default:
+		return true
+	}
+}
+
+// hidesExplicit reports whether explicit content is left out for a viewer: as the request asks
+// if it overrides the preference, else as the signed in user chose. Anonymous viewers see it all.
+func (u *usecase) hidesExplicit(ctx context.Context, viewer models.Viewer) (bool, error) {
+	if viewer.HideExplicit != nil {
+		return *viewer.HideExplicit, nil
+	}
+	if viewer.UserID == uuid.Nil {
+		return false, nil
+	}
+	return u.repo.GetHideExplicit(ctx, viewer.UserID)
+}
+
+// languageTagPattern matches BCP 47 style language tags, such as "ar" and "ar-SD"
+var languageTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// listingLanguage resolves the language filter of a podcast listing or search. Without one,
+// signed in users get podcasts in their preferred language, in any of its varieties, so
+// "ar-sd" lists all Arabic podcasts. AllLanguages lifts the filter.
+func (u *usecase) listingLanguage(ctx context.Context, language string, viewer models.Viewer) (string, error) {
+	switch {
+	case strings.EqualFold(language, models.AllLanguages):
+		return "", nil
+	case language != "":
+		if !languageTagPattern.MatchString(language) {
+			return "", models.ErrInvalidLanguage
+		}
+		return language, nil
+	case viewer.UserID == uuid.Nil:
+		return "", nil
+	}
+	
+	preferredLanguage, err := u.repo.GetPreferredLanguage(ctx, viewer.UserID)
+	if err != nil {
+		return "", err
+	}
+	return strings.SplitN(preferredLanguage, "-", 2)[0], nil
+}
+
+// SubscribeToPodcast subscribes a listener to a podcast. Subscribing again is not an error;
+// it reports false as no new subscription was created.
+func (u *usecase) SubscribeToPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Check if podcast exists and the listener can see it
+	podcast, err := u.repo.GetPodcastByID(ctx, podcastID)
+	if err != nil {
+		return false, err
+	}
+	if !canViewPodcast(podcast, models.Viewer{UserID: listenerID}) {
+		return false, models.ErrPodcastNotFound
+	}
+	
+	// Subscribe to podcast
+	return u.repo.SubscribeToPodcast(ctx, listenerID, podcastID)
+}
+
+// UnsubscribeFromPodcast unsubscribes a listener from a podcast
+func (u *usecase) UnsubscribeFromPodcast(ctx context.Context, listenerID, podcastID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.UnsubscribeFromPodcast(ctx, listenerID, podcastID)
+}
+
+// GetSubscribedPodcasts gets podcasts subscribed by a listener
+func (u *usecase) GetSubscribedPodcasts(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PodcastResponse, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	viewer := models.Viewer{UserID: listenerID}
+	
+	podcasts, totalCount, err := u.repo.GetSubscribedPodcasts(ctx, listenerID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	// Convert podcasts to podcast responses
+	podcastResponses := make([]*models.PodcastResponse, 0, len(podcasts))
+	for _, podcast := range podcasts {
+		podcastResponse := &models.PodcastResponse{
+			Podcast:         *podcast,
+			EpisodeCount:    podcast.EpisodeCount,
+			SubscriberCount: podcast.SubscriberCount,
+		}
+		u.applyDefaultPodcastCover(podcastResponse)
+		podcastResponse.ListenCount, podcastResponse.ListenCountBadge = u.visibleListenCount(podcast.ListenCount, podcastResponse.PodcasterID, viewer)
+		podcastResponses = append(podcastResponses, podcastResponse)
+	}
+	
+	if err := u.applyRatings(ctx, podcastResponses); err != nil {
+		return nil, 0, err
+	}
+	
+	return podcastResponses, totalCount, nil
+}
+
+// IsSubscribed checks if a listener is subscribed to a podcast
+func (u *usecase) IsSubscribed(ctx context.Context, listenerID, podcastID uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.IsSubscribed(ctx, listenerID, podcastID)
+}
+
+// ImportSubscriptions subscribes a listener to the feeds of an OPML file, creating
+// podcasts for feeds not in the catalog yet. Feeds are fetched a few at a time, each
+// bounded by the parser's timeout rather than the usecase timeout.
+func (u *usecase) ImportSubscriptions(ctx context.Context, listenerID uuid.UUID, opml io.Reader) (*models.OPMLImportResult, error) {
+	feedURLs, err := rss.ParseOPML(opml)
+	if err != nil {
+		return nil, models.ErrInvalidOPML
+	}
+	if len(feedURLs) == 0 {
+		return nil, models.ErrNoFeedsToImport
+	}
+	if len(feedURLs) > u.cfg.Content.OPMLImportMaxFeeds {
+		return nil, models.ErrTooManyFeeds
+	}
+	
+	concurrency := u.cfg.Content.OPMLImportConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	
+	type feedOutcome struct {
+		rssURL   string
+		imported bool
+		err      error
+	}
+	
+	outcomes := make(chan feedOutcome, len(feedURLs))
+	slots := make(chan struct{}, concurrency)
+	for _, feedURL := range feedURLs {
+		slots <- struct{}{}
+		go func(feedURL string) {
+			defer func() { <-slots }()
+			imported, err := u.importFeed(ctx, listenerID, feedURL)
+			outcomes <- feedOutcome{rssURL: feedURL, imported: imported, err: err}
+		}(feedURL)
+	}
+	
+	result := &models.OPMLImportResult{}
+	for range feedURLs {
+		outcome := <-outcomes
+		switch {
+		case outcome.err != nil:
+			logger.FromContext(ctx).Warn("Failed to import feed",
+				logger.Field("rss_url", outcome.rssURL),
+				logger.Field("error", outcome.err))
+			result.Failed++
+			result.Failures = append(result.Failures, models.OPMLImportFailure{
+				RSSUrl: outcome.rssURL,
+				Error:  importFailureMessage(outcome.err),
+			})
+		case outcome.imported:
+			result.Imported++
+		default:
+			result.Skipped++
+		}
+	}
+	
+	return result, nil
+}
+
+// importFailureMessage describes why a feed couldn't be imported. Other errors, such as those
+// of the database, aren't shown to the listener.
+func importFailureMessage(err error) string {
+	switch {
+	case errors.Is(err, rss.ErrInvalidFeedURL):
+		return "Invalid RSS URL"
+	case errors.Is(err, models.ErrPodcastNotFound):
+		return "Podcast not found in the catalog"
+	case errors.Is(err, models.ErrFeedFetch):
+		return "Failed to parse RSS feed: " + rss.DescribeFailure(err)
+	default:
+		return "Failed to import feed"
+	}
+}
+
+// importFeed subscribes a listener to one imported feed, reporting false when
+// they were already subscribed
+func (u *usecase) importFeed(ctx context.Context, listenerID uuid.UUID, feedURL string) (bool, error) {
+	podcast, err := u.findOrCreateImportedPodcast(ctx, feedURL)
+	if err != nil {
+		return false, err
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.SubscribeToPodcast(ctx, listenerID, podcast.ID)
+}
+
+// findOrCreateImportedPodcast gets the podcast of an imported feed, creating it from
+// the feed on behalf of the configured import account when it is not in the catalog
+func (u *usecase) findOrCreateImportedPodcast(ctx context.Context, feedURL string) (*models.Podcast, error) {
+	feedURL, err := rss.NormalizeFeedURL(feedURL)
+	if err != nil {
+		return nil, err
+	}
+	
+	lookupCtx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	podcast, err := u.repo.GetPodcastByRSSURL(lookupCtx, feedURL)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	if podcast != nil {
+		return podcast, nil
+	}
+	
+	ownerID, err := uuid.Parse(u.cfg.Content.OPMLImportOwnerID)
+	if err != nil {
+		return nil, models.ErrPodcastNotFound
+	}
+	
+	feed, err := u.syncService.ParseFeed(ctx, feedURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", models.ErrFeedFetch, err)
+	}
+	
+	podcast, err = u.CreatePodcast(ctx, ownerID, &models.CreatePodcastRequest{RSSUrl: feedURL}, feed)
+	if err != nil {
+		// A concurrent import may have created the same podcast in the meantime
+		lookupCtx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+		existing, lookupErr := u.repo.GetPodcastByRSSURL(lookupCtx, feedURL)
+		cancel()
+		if lookupErr == nil && existing != nil {
+			return existing, nil
+		}
+		return nil, err
+	}
+	
+	// Sync the episodes here rather than in the background so that the fetch counts
+	// against the import's concurrency; failed syncs are retried by the scheduler
+	u.syncService.SyncPodcast(ctx, podcast.ID)
+	
+	return podcast, nil
+}
+
+// SavePlaybackPosition saves the playback position for an episode
+func (u *usecase) SavePlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID, position int, completed bool) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Check if episode exists
+	_, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+	
+	return u.repo.SavePlaybackPosition(ctx, listenerID, episodeID, position, completed)
+}
+
+// GetPlaybackPosition gets the playback position for an episode
+func (u *usecase) GetPlaybackPosition(ctx context.Context, listenerID, episodeID uuid.UUID) (int, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetPlaybackPosition(ctx, listenerID, episodeID)
+}
+
+// MarkEpisodePlayed marks an episode as played by a listener, which takes it off their
+// continue-listening shelf but keeps it in their listening history
+func (u *usecase) MarkEpisodePlayed(ctx context.Context, listenerID, episodeID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	episode, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
+	}
+	
+	return u.repo.MarkEpisodePlayed(ctx, listenerID, episodeID, episode.Duration)
+}
+
+// MarkEpisodeUnplayed resets a listener's progress in an episode, removing it from both their
+// continue-listening shelf and their listening history
+func (u *usecase) MarkEpisodeUnplayed(ctx context.Context, listenerID, episodeID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if _, err := u.repo.GetEpisodeByID(ctx, episodeID); err != nil {
+		return err
+	}
+	
+	return u.repo.DeletePlaybackPosition(ctx, listenerID, episodeID)
+}
+
+// GetPlaybackPositions gets the playback positions of several episodes at once, e.g. to render
+// a row of episodes with their progress. Episodes the listener hasn't played are left out.
+func (u *usecase) GetPlaybackPositions(ctx context.Context, listenerID uuid.UUID, episodeIDs []uuid.UUID) ([]*models.PlaybackPosition, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if len(episodeIDs) > maxBatchEpisodes {
+		return nil, models.ErrTooManyEpisodeIDs
+	}
+	if len(episodeIDs) == 0 {
+		return []*models.PlaybackPosition{}, nil
+	}
+	
+	return u.repo.GetPlaybackPositions(ctx, listenerID, episodeIDs)
+}
+
+// GetListeningHistory gets the listening history for a listener
+func (u *usecase) GetListeningHistory(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.PlaybackHistory, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetListeningHistory(ctx, listenerID, page, pageSize)
+}
+
+// GetContinueListening gets the episodes a listener started but didn't finish. Unlike the
+// listening history, finished episodes are left out.
+func (u *usecase) GetContinueListening(ctx context.Context, listenerID uuid.UUID, page, pageSize int) ([]*models.ContinueListeningItem, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetContinueListening(ctx, listenerID, page, pageSize)
+}
+
+// GetNotifications gets a user's notifications, newest first
+func (u *usecase) GetNotifications(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*models.Notification, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.GetNotifications(ctx, userID, page, pageSize)
+}
+
+// MarkNotificationRead marks one of a user's notifications as read
+func (u *usecase) MarkNotificationRead(ctx context.Context, notificationID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	return u.repo.MarkNotificationRead(ctx, notificationID, userID)
+}
+
+// LikeEpisode likes an episode
+func (u *usecase) LikeEpisode(ctx context.Context, listenerID, episodeID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	// Check if episode exists
+	_, err := u.repo.GetEpisodeByID(ctx, episodeID)
+	if err != nil {
+		return err
 	}
 	
 	return u.repo.LikeEpisode(ctx, listenerID, episodeID)
@@ -491,10 +2305,16 @@ func (u *usecase) GetLikedEpisodes(ctx context.Context, listenerID uuid.UUID, pa
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
+	viewer := models.Viewer{UserID: listenerID}
+	
 	episodes, totalCount, err := u.repo.GetLikedEpisodes(ctx, listenerID, page, pageSize)
 	if err != nil {
 		return nil, 0, err
 	}
+	listenStats, err := u.episodeListenStats(ctx, episodes)
+	if err != nil {
+		return nil, 0, err
+	}
 	
 	// Convert episodes to episode responses
 	episodeResponses := make([]*models.EpisodeResponse, 0, len(episodes))
@@ -509,10 +2329,201 @@ func (u *usecase) GetLikedEpisodes(ctx context.Context, listenerID uuid.UUID, pa
 			Episode:         *episode,
 			PodcastTitle:    podcast.Title,
 			PodcastAuthor:   podcast.Author,
-			PodcastImageURL: podcast.CoverImageURL,
+			PodcastImageURL: u.podcastCoverURL(podcast),
 		}
+		u.applyDefaultEpisodeCover(episodeResponse, podcast.Category)
+		u.applyListenStats(episodeResponse, listenStats[episode.ID], podcast.PodcasterID, viewer)
 		episodeResponses = append(episodeResponses, episodeResponse)
 	}
 	
 	return episodeResponses, totalCount, nil
+}
+
+// coverImageOrDefault returns the cover URL, falling back to the configured category or default cover when it is empty
+func (u *usecase) coverImageOrDefault(coverImageURL, category string) string {
+	if coverImageURL != "" || u.cfg == nil {
+		return coverImageURL
+	}
+	if categoryCover, ok := u.cfg.Content.CategoryCoverImageURLs[strings.ToLower(category)]; ok {
+		return categoryCover
+	}
+	return u.cfg.Content.DefaultCoverImageURL
+}
+
+// podcastCoverURL returns the cover of a podcast. Uploaded covers are re-resolved from their
+// storage path, as the presigned URL stored with the podcast expires.
+func (u *usecase) podcastCoverURL(podcast *models.Podcast) string {
+	if podcast.CoverImagePath != "" && u.storage != nil {
+		if url := u.storage.GetFileURL(podcast.CoverImagePath); url != "" {
+			return url
+		}
+	}
+	return podcast.CoverImageURL
+}
+
+// applyDefaultPodcastCover fills in the current cover, or a fallback, on a podcast response without touching the stored podcast
+func (u *usecase) applyDefaultPodcastCover(podcastResponse *models.PodcastResponse) {
+	podcastResponse.CoverImageURL = u.coverImageOrDefault(u.podcastCoverURL(&podcastResponse.Podcast), podcastResponse.Category)
+}
+
+// applyDefaultEpisodeCover fills in fallback covers on an episode response, preferring the podcast artwork
+func (u *usecase) applyDefaultEpisodeCover(episodeResponse *models.EpisodeResponse, category string) {
+	episodeResponse.PodcastImageURL = u.coverImageOrDefault(episodeResponse.PodcastImageURL, category)
+	if episodeResponse.CoverImageURL == "" {
+		episodeResponse.CoverImageURL = episodeResponse.PodcastImageURL
+	}
+}
+
+// applyRatings fills in the average rating and review count of podcast responses, in one query for all of them
+func (u *usecase) applyRatings(ctx context.Context, podcastResponses []*models.PodcastResponse) error {
+	if len(podcastResponses) == 0 {
+		return nil
+	}
+	
+	podcastIDs := make([]uuid.UUID, 0, len(podcastResponses))
+	for _, podcastResponse := range podcastResponses {
+		podcastIDs = append(podcastIDs, podcastResponse.ID)
+	}
+	
+	ratings, err := u.repo.GetPodcastRatings(ctx, podcastIDs)
+	if err != nil {
+		return err
+	}
+	
+	for _, podcastResponse := range podcastResponses {
+		rating := ratings[podcastResponse.ID]
+		podcastResponse.AverageRating = math.Round(rating.AverageRating*100) / 100
+		podcastResponse.ReviewCount = rating.ReviewCount
+	}
+	
+	return nil
+}
+
+// recordAudit records an audit entry for a mutation that has already been applied.
+// A failure to record is logged rather than reported to the caller, as the mutation itself succeeded.
+func (u *usecase) recordAudit(ctx context.Context, entry *auditModels.AuditEntry) {
+	if u.audit == nil {
+		return
+	}
+	if err := u.audit.Record(ctx, entry); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record audit entry",
+			logger.Field("action", entry.Action),
+			logger.Field("target_id", entry.TargetID),
+			logger.Field("error", err))
+	}
+}
+
+// parseEpisodeShareURL extracts the episode ID from a share URL of the form SiteURL/episodes/{id}.
+// URLs on other hosts or paths are rejected.
+func (u *usecase) parseEpisodeShareURL(shareURL string) (uuid.UUID, error) {
+	site, err := url.Parse(u.cfg.Content.SiteURL)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	
+	parsed, err := url.Parse(shareURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || !strings.EqualFold(parsed.Host, site.Host) {
+		return uuid.Nil, models.ErrUnsupportedURL
+	}
+	
+	prefix := strings.TrimRight(site.Path, "/") + "/episodes/"
+	if !strings.HasPrefix(parsed.Path, prefix) {
+		return uuid.Nil, models.ErrUnsupportedURL
+	}
+	
+	episodeID, err := uuid.Parse(strings.TrimSuffix(strings.TrimPrefix(parsed.Path, prefix), "/"))
+	if err != nil {
+		return uuid.Nil, models.ErrUnsupportedURL
+	}
+	
+	return episodeID, nil
+}
+
+// shortLinkResponse adds the shareable URL to a short link
+func (u *usecase) shortLinkResponse(link *models.ShortLink) *models.ShortLinkResponse {
+	return &models.ShortLinkResponse{
+		ShortLink: *link,
+		URL:       u.cfg.Content.ShortLinkBaseURL + "/" + link.Code,
+	}
+}
+
+const (
+	shortLinkCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	shortLinkCodeLength   = 7
+	shortLinkCodeAttempts = 5
+)
+
+// generateShortLinkCode generates a random base62 short link code
+func generateShortLinkCode() (string, error) {
+	buf := make([]byte, shortLinkCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	
+	// The slight modulo bias is fine, codes only need to be hard to guess
+	code := make([]byte, shortLinkCodeLength)
+	for i, b := range buf {
+		code[i] = shortLinkCodeAlphabet[int(b)%len(shortLinkCodeAlphabet)]
+	}
+	
+	return string(code), nil
+}
+
+// episodeListenStats gets the listen stats of the episodes in one query
+func (u *usecase) episodeListenStats(ctx context.Context, episodes []*models.Episode) (map[uuid.UUID]models.EpisodeListenStats, error) {
+	if len(episodes) == 0 {
+		return nil, nil
+	}
+	
+	episodeIDs := make([]uuid.UUID, 0, len(episodes))
+	for _, episode := range episodes {
+		episodeIDs = append(episodeIDs, episode.ID)
+	}
+	
+	return u.repo.GetEpisodeListenStats(ctx, episodeIDs)
+}
+
+// applyListenStats sets an episode's average completion and the listen count the viewer may see
+func (u *usecase) applyListenStats(episodeResponse *models.EpisodeResponse, stats models.EpisodeListenStats, podcasterID uuid.UUID, viewer models.Viewer) {
+	episodeResponse.AverageCompletion = int(math.Round(stats.AverageCompletion))
+	episodeResponse.ListenCount, episodeResponse.ListenCountBadge = u.visibleListenCount(stats.ListenCount, podcasterID, viewer)
+}
+
+// visibleListenCount returns the listen count and badge a viewer may see. When badges are
+// enabled, only the podcast's owner and admins get the exact count.
+func (u *usecase) visibleListenCount(listenCount int, podcasterID uuid.UUID, viewer models.Viewer) (int, string) {
+	if u.cfg == nil || !u.cfg.Content.ListenCountBadges {
+		return listenCount, ""
+	}
+	
+	badge := listenCountBadge(listenCount)
+	if viewer.IsAdmin || (viewer.UserID != uuid.Nil && viewer.UserID == podcasterID) {
+		return listenCount, badge
+	}
+	
+	return 0, badge
+}
+
+// listenCountBadgeThresholds are the badge buckets, largest first
+var listenCountBadgeThresholds = []struct {
+	min   int
+	label string
+}{
+	{1000000, "1M+"},
+	{100000, "100K+"},
+	{10000, "10K+"},
+	{1000, "1K+"},
+	{100, "100+"},
+	{10, "10+"},
+}
+
+// listenCountBadge buckets a listen count into a badge such as "10K+".
+// Counts below the smallest bucket get no badge.
+func listenCountBadge(listenCount int) string {
+	for _, threshold := range listenCountBadgeThresholds {
+		if listenCount >= threshold.min {
+			return threshold.label
+		}
+	}
+	return ""
 }
\ No newline at end of file
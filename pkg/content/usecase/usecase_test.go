@@ -0,0 +1,77 @@
+// pkg/content/usecase/usecase_test.go
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/rss"
+)
+
+func TestListingLanguage(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+		wantErr  error
+	}{
+		{language: "ar", want: "ar"},
+		{language: "ar-SD", want: "ar-SD"},
+		{language: "ALL", want: ""},
+		{language: "", want: ""},
+		{language: "%", wantErr: models.ErrInvalidLanguage},
+		{language: "a_", wantErr: models.ErrInvalidLanguage},
+		{language: "ar-%", wantErr: models.ErrInvalidLanguage},
+		{language: "ar sd", wantErr: models.ErrInvalidLanguage},
+	}
+
+	// An anonymous viewer, so no preferred language is looked up
+	u := &usecase{}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			got, err := u.listingLanguage(context.Background(), tt.language, models.Viewer{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listingLanguage(%q) error = %v, want %v", tt.language, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("listingLanguage(%q) = %q, want %q", tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportFailureMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "invalid URL", err: rss.ErrInvalidFeedURL, want: "Invalid RSS URL"},
+		{name: "not in catalog", err: models.ErrPodcastNotFound, want: "Podcast not found in the catalog"},
+		{
+			name: "feed host error",
+			err:  fmt.Errorf("%w: %w", models.ErrFeedFetch, &rss.StatusError{StatusCode: 404}),
+			want: "Failed to parse RSS feed: the feed host answered with HTTP 404",
+		},
+		{
+			name: "database error",
+			err:  errors.New(`pq: duplicate key value violates unique constraint "podcasts_rss_url_key"`),
+			want: "Failed to import feed",
+		},
+		{
+			name: "dial error outside a feed fetch",
+			err:  errors.New("dial tcp 10.0.0.5:5432: connect: connection refused"),
+			want: "Failed to import feed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := importFailureMessage(tt.err); got != tt.want {
+				t.Errorf("importFailureMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// pkg/content/webhook/service.go
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/publicnet"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/models"
+	"github.com/MHK-26/pod_platfrom_go/pkg/content/repository/postgres"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of the request body
+// keyed with the webhook's secret, prefixed with "sha256=".
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	deliveryBatchSize   = 50
+	deliveryConcurrency = 5
+	// deliveryLease is how long claimed deliveries are kept from other workers, which must
+	// outlast a batch of deliveries timing out
+	deliveryLease = 5 * time.Minute
+	// maxRetryDelay bounds the doubling delay between attempts
+	maxRetryDelay = 24 * time.Hour
+	// maxErrorLength bounds the error kept in the delivery log
+	maxErrorLength = 500
+)
+
+// Service queues podcast events for the podcast's webhooks and delivers them
+type Service interface {
+	// Dispatch queues an event for every webhook of the podcast subscribed to it
+	Dispatch(ctx context.Context, podcastID uuid.UUID, event string, data interface{}) error
+
+	// DeliverPending POSTs the deliveries that are due, scheduling failed ones for a retry.
+	// Returns the number of deliveries attempted.
+	DeliverPending(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo       postgres.Repository
+	cfg        *config.Config
+	httpClient *http.Client
+}
+
+// NewService creates a new webhook service
+func NewService(repo postgres.Repository, cfg *config.Config) Service {
+	return &service{
+		repo: repo,
+		cfg:  cfg,
+		httpClient: &http.Client{
+			Timeout: cfg.Content.WebhookTimeout,
+			// Webhooks may not reach into the platform's own network
+			Transport: publicnet.NewTransport(30 * time.Second),
+			// A redirect would turn the POST into a GET, so it counts as a failed delivery
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Dispatch queues an event for every webhook of the podcast subscribed to it
+func (s *service) Dispatch(ctx context.Context, podcastID uuid.UUID, event string, data interface{}) error {
+	payload, err := json.Marshal(models.WebhookEvent{
+		ID:        uuid.New(),
+		Event:     event,
+		PodcastID: podcastID,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	_, err = s.repo.CreateWebhookDeliveries(ctx, podcastID, event, payload)
+	return err
+}
+
+// DeliverPending POSTs the deliveries that are due, a few at a time
+func (s *service) DeliverPending(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	deliveries, err := s.repo.ClaimDueWebhookDeliveries(ctx, now, now.Add(deliveryLease), deliveryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	jobs := make(chan *models.WebhookDelivery)
+	var wg sync.WaitGroup
+	for i := 0; i < deliveryConcurrency && i < len(deliveries); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				s.deliver(ctx, delivery)
+			}
+		}()
+	}
+	for _, delivery := range deliveries {
+		jobs <- delivery
+	}
+	close(jobs)
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt at a delivery and records its outcome. Any 2xx answer counts as delivered.
+func (s *service) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	statusCode, err := s.post(ctx, delivery)
+
+	now := time.Now().UTC()
+	delivery.Attempts++
+	if statusCode != 0 {
+		delivery.ResponseStatus = &statusCode
+	} else {
+		delivery.ResponseStatus = nil
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = nil
+	case delivery.Attempts >= s.cfg.Content.WebhookMaxAttempts:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = errorMessage(err)
+	default:
+		delivery.NextAttemptAt = now.Add(s.retryBackoff(delivery.Attempts))
+		delivery.LastError = errorMessage(err)
+	}
+
+	if err := s.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		logger.FromContext(ctx).Error("Failed to record webhook delivery",
+			logger.Field("delivery_id", delivery.ID),
+			logger.Field("error", err))
+	}
+}
+
+// post sends a delivery's signed payload to its webhook, returning the status code answered with, if any
+func (s *service) post(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Sudanese Podcast Platform Webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// retryBackoff returns the delay before the next attempt at a delivery that failed attempts times,
+// doubling from the base delay up to a day
+func (s *service) retryBackoff(attempts int) time.Duration {
+	delay := s.cfg.Content.WebhookRetryBaseDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
+
+// Sign returns the signature header value of a payload, for receivers to check with their copy of the secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// errorMessage shortens an error for the delivery log
+func errorMessage(err error) *string {
+	message := err.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return &message
+}
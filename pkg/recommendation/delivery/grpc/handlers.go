@@ -3,6 +3,7 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
@@ -32,12 +33,9 @@ func (h *Handler) GetPersonalizedRecommendations(ctx context.Context, req *pb.Ge
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid user ID: %v", err)
 	}
 
-	var excludedIDs []uuid.UUID
-	for _, idStr := range req.ExcludedIds {
-		id, err := uuid.Parse(idStr)
-		if err == nil {
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(req.ExcludedIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid excluded ID: %v", err)
 	}
 
 	// Prepare request
@@ -64,12 +62,9 @@ func (h *Handler) GetSimilarPodcasts(ctx context.Context, req *pb.GetSimilarPodc
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid podcast ID: %v", err)
 	}
 
-	var excludedIDs []uuid.UUID
-	for _, idStr := range req.ExcludedIds {
-		id, err := uuid.Parse(idStr)
-		if err == nil {
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(req.ExcludedIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid excluded ID: %v", err)
 	}
 
 	// Prepare request
@@ -97,12 +92,9 @@ func (h *Handler) GetSimilarEpisodes(ctx context.Context, req *pb.GetSimilarEpis
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid episode ID: %v", err)
 	}
 
-	var excludedIDs []uuid.UUID
-	for _, idStr := range req.ExcludedIds {
-		id, err := uuid.Parse(idStr)
-		if err == nil {
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(req.ExcludedIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid excluded ID: %v", err)
 	}
 
 	// Prepare request
@@ -125,12 +117,9 @@ func (h *Handler) GetSimilarEpisodes(ctx context.Context, req *pb.GetSimilarEpis
 
 // GetTrendingPodcasts gets trending podcasts
 func (h *Handler) GetTrendingPodcasts(ctx context.Context, req *pb.GetTrendingPodcastsRequest) (*pb.GetRecommendationsResponse, error) {
-	var excludedIDs []uuid.UUID
-	for _, idStr := range req.ExcludedIds {
-		id, err := uuid.Parse(idStr)
-		if err == nil {
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(req.ExcludedIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid excluded ID: %v", err)
 	}
 
 	// Prepare request
@@ -157,12 +146,9 @@ func (h *Handler) GetPopularInCategory(ctx context.Context, req *pb.GetPopularIn
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid category ID: %v", err)
 	}
 
-	var excludedIDs []uuid.UUID
-	for _, idStr := range req.ExcludedIds {
-		id, err := uuid.Parse(idStr)
-		if err == nil {
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(req.ExcludedIds)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid excluded ID: %v", err)
 	}
 
 	// Prepare request
@@ -182,6 +168,19 @@ func (h *Handler) GetPopularInCategory(ctx context.Context, req *pb.GetPopularIn
 	return convertToGRPCResponse(response), nil
 }
 
+// Helper function to convert excluded IDs from strings to UUIDs, rejecting invalid ones
+func parseExcludedIDs(excludedIDsStr []string) ([]uuid.UUID, error) {
+	excludedIDs := make([]uuid.UUID, 0, len(excludedIDsStr))
+	for _, idStr := range excludedIDsStr {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid UUID", idStr)
+		}
+		excludedIDs = append(excludedIDs, id)
+	}
+	return excludedIDs, nil
+}
+
 // Helper function to convert model response to gRPC response
 func convertToGRPCResponse(response *models.RecommendationResponse) *pb.GetRecommendationsResponse {
 	var items []*pb.RecommendedItem
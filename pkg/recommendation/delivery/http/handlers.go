@@ -2,7 +2,9 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -32,6 +34,7 @@ func NewHandler(usecase usecase.Usecase) *Handler {
 // @Security BearerAuth
 // @Param limit query int false "Number of recommendations to return (default 10, max 50)"
 // @Param excluded_ids query []string false "IDs to exclude from recommendations"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.RecommendationResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
@@ -53,22 +56,18 @@ func (h *Handler) GetPersonalizedRecommendations(c *gin.Context) {
 
 	// Parse query parameters
 	limit := utils.GetIntQueryParam(c, "limit", 10)
-	excludedIDsStr := c.QueryArray("excluded_ids")
-	
-	// Convert excluded IDs from strings to UUIDs
-	var excludedIDs []uuid.UUID
-	for _, idStr := range excludedIDsStr {
-		id, err := uuid.Parse(idStr)
-		if err == nil { // Skip invalid UUIDs
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(c.QueryArray("excluded_ids"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid excluded ID: "+err.Error())
+		return
 	}
 
 	// Prepare request
 	req := &models.RecommendationRequest{
-		UserID:      userIDParsed,
-		Limit:       limit,
-		ExcludedIDs: excludedIDs,
+		UserID:       userIDParsed,
+		Limit:        limit,
+		ExcludedIDs:  excludedIDs,
+		HideExplicit: hideExplicitParam(c),
 	}
 
 	// Get recommendations
@@ -81,6 +80,54 @@ func (h *Handler) GetPersonalizedRecommendations(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// SubmitFeedback godoc
+// @Summary Give feedback on a recommendation
+// @Description Record a like, dislike, not_interested or hide signal on a recommended podcast or episode. Hidden and not interested podcasts are no longer recommended, and dislikes lower the scores of podcasts in the same categories.
+// @Tags recommendations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.FeedbackRequest true "Feedback Request"
+// @Success 200 {object} models.RecommendationFeedback
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /recommendations/feedback [post]
+func (h *Handler) SubmitFeedback(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Invalid user ID")
+		return
+	}
+
+	var req models.FeedbackRequest
+	if !utils.BindJSON(c, &req) {
+		return
+	}
+
+	feedback, err := h.usecase.SubmitFeedback(c.Request.Context(), userIDParsed, &req)
+	if err != nil {
+		switch err.Error() {
+		case "invalid item type":
+			utils.RespondWithError(c, http.StatusBadRequest, "Type must be podcast or episode")
+		case "invalid feedback signal":
+			utils.RespondWithError(c, http.StatusBadRequest, "Signal must be like, dislike, not_interested or hide")
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to save feedback")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, feedback)
+}
+
 // GetSimilarPodcasts godoc
 // @Summary Get similar podcasts
 // @Description Get podcasts similar to the specified podcast
@@ -90,6 +137,7 @@ func (h *Handler) GetPersonalizedRecommendations(c *gin.Context) {
 // @Param podcast_id path string true "Podcast ID"
 // @Param limit query int false "Number of recommendations to return (default 10, max 50)"
 // @Param excluded_ids query []string false "IDs to exclude from recommendations"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.RecommendationResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -109,23 +157,20 @@ func (h *Handler) GetSimilarPodcasts(c *gin.Context) {
 
 	// Parse query parameters
 	limit := utils.GetIntQueryParam(c, "limit", 10)
-	excludedIDsStr := c.QueryArray("excluded_ids")
-	
-	// Convert excluded IDs from strings to UUIDs
-	var excludedIDs []uuid.UUID
-	for _, idStr := range excludedIDsStr {
-		id, err := uuid.Parse(idStr)
-		if err == nil { // Skip invalid UUIDs
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(c.QueryArray("excluded_ids"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid excluded ID: "+err.Error())
+		return
 	}
 
 	// Prepare request
 	req := &models.SimilarContentRequest{
-		ContentID:   podcastID,
-		ContentType: "podcast",
-		Limit:       limit,
-		ExcludedIDs: excludedIDs,
+		UserID:       optionalUserID(c),
+		ContentID:    podcastID,
+		ContentType:  "podcast",
+		Limit:        limit,
+		ExcludedIDs:  excludedIDs,
+		HideExplicit: hideExplicitParam(c),
 	}
 
 	// Get similar podcasts
@@ -140,13 +185,14 @@ func (h *Handler) GetSimilarPodcasts(c *gin.Context) {
 
 // GetSimilarEpisodes godoc
 // @Summary Get similar episodes
-// @Description Get episodes similar to the specified episode
+// @Description Get episodes similar to the specified episode. For signed in listeners, episodes they finished or played past the configured progress are left out.
 // @Tags recommendations
 // @Accept json
 // @Produce json
 // @Param episode_id path string true "Episode ID"
 // @Param limit query int false "Number of recommendations to return (default 10, max 50)"
 // @Param excluded_ids query []string false "IDs to exclude from recommendations"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.RecommendationResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -166,23 +212,21 @@ func (h *Handler) GetSimilarEpisodes(c *gin.Context) {
 
 	// Parse query parameters
 	limit := utils.GetIntQueryParam(c, "limit", 10)
-	excludedIDsStr := c.QueryArray("excluded_ids")
-	
-	// Convert excluded IDs from strings to UUIDs
-	var excludedIDs []uuid.UUID
-	for _, idStr := range excludedIDsStr {
-		id, err := uuid.Parse(idStr)
-		if err == nil { // Skip invalid UUIDs
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(c.QueryArray("excluded_ids"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid excluded ID: "+err.Error())
+		return
 	}
 
 	// Prepare request
+	// Signed in listeners don't get episodes they already heard
 	req := &models.SimilarContentRequest{
-		ContentID:   episodeID,
-		ContentType: "episode",
-		Limit:       limit,
-		ExcludedIDs: excludedIDs,
+		UserID:       optionalUserID(c),
+		ContentID:    episodeID,
+		ContentType:  "episode",
+		Limit:        limit,
+		ExcludedIDs:  excludedIDs,
+		HideExplicit: hideExplicitParam(c),
 	}
 
 	// Get similar episodes
@@ -204,6 +248,7 @@ func (h *Handler) GetSimilarEpisodes(c *gin.Context) {
 // @Param time_range query string false "Time range (daily, weekly, monthly) (default: weekly)"
 // @Param limit query int false "Number of podcasts to return (default 10, max 50)"
 // @Param excluded_ids query []string false "IDs to exclude from recommendations"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.RecommendationResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -216,22 +261,19 @@ func (h *Handler) GetTrendingPodcasts(c *gin.Context) {
 	}
 
 	limit := utils.GetIntQueryParam(c, "limit", 10)
-	excludedIDsStr := c.QueryArray("excluded_ids")
-	
-	// Convert excluded IDs from strings to UUIDs
-	var excludedIDs []uuid.UUID
-	for _, idStr := range excludedIDsStr {
-		id, err := uuid.Parse(idStr)
-		if err == nil { // Skip invalid UUIDs
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(c.QueryArray("excluded_ids"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid excluded ID: "+err.Error())
+		return
 	}
 
 	// Prepare request
 	req := &models.TrendingRequest{
-		TimeRange:   timeRange,
-		Limit:       limit,
-		ExcludedIDs: excludedIDs,
+		UserID:       optionalUserID(c),
+		TimeRange:    timeRange,
+		Limit:        limit,
+		ExcludedIDs:  excludedIDs,
+		HideExplicit: hideExplicitParam(c),
 	}
 
 	// Get trending podcasts
@@ -253,6 +295,7 @@ func (h *Handler) GetTrendingPodcasts(c *gin.Context) {
 // @Param category_id path string true "Category ID"
 // @Param limit query int false "Number of podcasts to return (default 10, max 50)"
 // @Param excluded_ids query []string false "IDs to exclude from recommendations"
+// @Param hide_explicit query bool false "Leave out explicit content (default: the signed in user's hide_explicit preference)"
 // @Success 200 {object} models.RecommendationResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
@@ -272,22 +315,19 @@ func (h *Handler) GetPopularInCategory(c *gin.Context) {
 
 	// Parse query parameters
 	limit := utils.GetIntQueryParam(c, "limit", 10)
-	excludedIDsStr := c.QueryArray("excluded_ids")
-	
-	// Convert excluded IDs from strings to UUIDs
-	var excludedIDs []uuid.UUID
-	for _, idStr := range excludedIDsStr {
-		id, err := uuid.Parse(idStr)
-		if err == nil { // Skip invalid UUIDs
-			excludedIDs = append(excludedIDs, id)
-		}
+	excludedIDs, err := parseExcludedIDs(c.QueryArray("excluded_ids"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid excluded ID: "+err.Error())
+		return
 	}
 
 	// Prepare request
 	req := &models.CategoryPopularRequest{
-		CategoryID:  categoryID,
-		Limit:       limit,
-		ExcludedIDs: excludedIDs,
+		UserID:       optionalUserID(c),
+		CategoryID:   categoryID,
+		Limit:        limit,
+		ExcludedIDs:  excludedIDs,
+		HideExplicit: hideExplicitParam(c),
 	}
 
 	// Get popular podcasts in category
@@ -300,21 +340,58 @@ func (h *Handler) GetPopularInCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// parseExcludedIDs converts excluded IDs from strings to UUIDs, rejecting invalid ones
+func parseExcludedIDs(excludedIDsStr []string) ([]uuid.UUID, error) {
+	excludedIDs := make([]uuid.UUID, 0, len(excludedIDsStr))
+	for _, idStr := range excludedIDsStr {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid UUID", idStr)
+		}
+		excludedIDs = append(excludedIDs, id)
+	}
+	return excludedIDs, nil
+}
+
+// optionalUserID gets the ID of the signed in user set by the optional auth middleware, or nil
+func optionalUserID(c *gin.Context) *uuid.UUID {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+	userIDParsed, err := uuid.Parse(userID.(string))
+	if err != nil {
+		return nil
+	}
+	return &userIDParsed
+}
+
+// hideExplicitParam gets the hide_explicit query parameter, which clients filtering explicit
+// content themselves use to override the user's preference. It is nil when not given.
+func hideExplicitParam(c *gin.Context) *bool {
+	hideExplicit, err := strconv.ParseBool(c.Query("hide_explicit"))
+	if err != nil {
+		return nil
+	}
+	return &hideExplicit
+}
+
 // RegisterRoutes registers all the recommendation routes
-func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, optionalAuthMiddleware gin.HandlerFunc) {
 	recommendations := router.Group("/recommendations")
 	{
 		// Public routes
-		recommendations.GET("/similar/podcasts/:podcast_id", h.GetSimilarPodcasts)
-		recommendations.GET("/similar/episodes/:episode_id", h.GetSimilarEpisodes)
-		recommendations.GET("/trending", h.GetTrendingPodcasts)
-		recommendations.GET("/categories/:category_id/popular", h.GetPopularInCategory)
+		recommendations.GET("/similar/podcasts/:podcast_id", optionalAuthMiddleware, h.GetSimilarPodcasts)
+		recommendations.GET("/similar/episodes/:episode_id", optionalAuthMiddleware, h.GetSimilarEpisodes)
+		recommendations.GET("/trending", optionalAuthMiddleware, h.GetTrendingPodcasts)
+		recommendations.GET("/categories/:category_id/popular", optionalAuthMiddleware, h.GetPopularInCategory)
 		
 		// Protected routes
 		protected := recommendations.Group("")
 		protected.Use(authMiddleware)
 		{
 			protected.GET("/personalized", h.GetPersonalizedRecommendations)
+			protected.POST("/feedback", h.SubmitFeedback)
 		}
 	}
 }
\ No newline at end of file
@@ -17,6 +17,10 @@ type RecommendedItem struct {
 	PodcastID   uuid.UUID `json:"podcast_id,omitempty" db:"podcast_id"`
 	PodcastTitle string   `json:"podcast_title,omitempty" db:"podcast_title"`
 	Score       float64   `json:"score" db:"score"`
+
+	// Used to diversify results; only set by queries that diversify
+	PodcasterID uuid.UUID `json:"-" db:"podcaster_id"`
+	Category    string    `json:"-" db:"category"`
 }
 
 // UserPreference represents a user's content preference
@@ -27,6 +31,49 @@ type UserPreference struct {
 	LastUpdated time.Time `json:"last_updated" db:"last_updated"`
 }
 
+// Feedback signals a user can give on a recommended item
+const (
+	FeedbackLike          = "like"
+	FeedbackDislike       = "dislike"
+	FeedbackNotInterested = "not_interested"
+	FeedbackHide          = "hide"
+)
+
+// RecommendationFeedback represents a user's explicit feedback on a recommended item
+type RecommendationFeedback struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	ItemID    uuid.UUID `json:"item_id" db:"item_id"`
+	ItemType  string    `json:"type" db:"item_type"` // podcast or episode
+	Signal    string    `json:"signal" db:"signal"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// PersonalizationWeights tunes how explicit signals shift personalized recommendation scores
+type PersonalizationWeights struct {
+	Preference     float64       // Score per unit of explicit category preference weight
+	DislikePenalty float64       // Score removed per disliked item sharing a category
+	Ratings        RatingWeights // Rating boost of the trending podcasts filling up short results
+}
+
+// RatingWeights tunes how podcast ratings shift trending and popular scores. A podcast's rating
+// is a Bayesian average: its reviews plus PriorReviews phantom reviews at the average rating of
+// all podcasts, so a handful of 5-star reviews can't outrank a show rated well by many listeners.
+// The listen-based score is then scaled by 1 + Weight * (rating - average) / 4, which leaves
+// unrated podcasts as they are and moves the best and worst rated by at most Weight.
+type RatingWeights struct {
+	Weight       float64 // Largest fraction of a listen-based score a rating adds or removes; zero ignores ratings
+	PriorReviews float64 // Phantom reviews at the average rating added to every podcast's reviews
+}
+
+// ListenedFilter leaves episodes a listener already heard out of recommendations. Completed
+// episodes are always left out; so are unfinished ones played past ProgressRatio of their
+// duration, unless it is zero.
+type ListenedFilter struct {
+	ListenerID    uuid.UUID
+	ProgressRatio float64
+}
+
 // SimilarityScore represents similarity between content items
 type SimilarityScore struct {
 	ItemID1    uuid.UUID `json:"item_id1" db:"item_id1"`
@@ -43,35 +90,50 @@ type TrendingItem struct {
 	Score       float64   `json:"score" db:"score"`
 	TimeRange   string    `json:"time_range" db:"time_range"` // daily, weekly, monthly
 	LastUpdated time.Time `json:"last_updated" db:"last_updated"`
+	ExpiresAt   time.Time `json:"expires_at" db:"expires_at"` // when the live query takes over again
 }
 
 // RecommendationRequest represents a request for recommendations
 type RecommendationRequest struct {
-	UserID      uuid.UUID   `json:"user_id" validate:"required"`
-	Limit       int         `json:"limit" validate:"min=1,max=50"`
-	ExcludedIDs []uuid.UUID `json:"excluded_ids"`
+	UserID       uuid.UUID   `json:"user_id" validate:"required"`
+	Limit        int         `json:"limit" validate:"min=1,max=50"`
+	ExcludedIDs  []uuid.UUID `json:"excluded_ids"`
+	HideExplicit *bool       `json:"hide_explicit,omitempty"` // Overrides the user's hide_explicit preference
+}
+
+// FeedbackRequest represents feedback on a recommended item
+type FeedbackRequest struct {
+	ItemID uuid.UUID `json:"item_id" validate:"required"`
+	Type   string    `json:"type" validate:"required,oneof=podcast episode"`
+	Signal string    `json:"signal" validate:"required,oneof=like dislike not_interested hide"`
 }
 
 // SimilarContentRequest represents a request for similar content
 type SimilarContentRequest struct {
-	ContentID   uuid.UUID   `json:"content_id" validate:"required"`
-	ContentType string      `json:"content_type" validate:"required,oneof=podcast episode"`
-	Limit       int         `json:"limit" validate:"min=1,max=50"`
-	ExcludedIDs []uuid.UUID `json:"excluded_ids"`
+	UserID       *uuid.UUID  `json:"user_id,omitempty"` // Set for authenticated requests, to leave out content they already heard or hide
+	ContentID    uuid.UUID   `json:"content_id" validate:"required"`
+	ContentType  string      `json:"content_type" validate:"required,oneof=podcast episode"`
+	Limit        int         `json:"limit" validate:"min=1,max=50"`
+	ExcludedIDs  []uuid.UUID `json:"excluded_ids"`
+	HideExplicit *bool       `json:"hide_explicit,omitempty"` // Overrides the user's hide_explicit preference
 }
 
 // TrendingRequest represents a request for trending content
 type TrendingRequest struct {
-	TimeRange   string      `json:"time_range" validate:"required,oneof=daily weekly monthly"`
-	Limit       int         `json:"limit" validate:"min=1,max=50"`
-	ExcludedIDs []uuid.UUID `json:"excluded_ids"`
+	UserID       *uuid.UUID  `json:"user_id,omitempty"` // Set for authenticated requests, to apply their hide_explicit preference
+	TimeRange    string      `json:"time_range" validate:"required,oneof=daily weekly monthly"`
+	Limit        int         `json:"limit" validate:"min=1,max=50"`
+	ExcludedIDs  []uuid.UUID `json:"excluded_ids"`
+	HideExplicit *bool       `json:"hide_explicit,omitempty"` // Overrides the user's hide_explicit preference
 }
 
 // CategoryPopularRequest represents a request for popular content in a category
 type CategoryPopularRequest struct {
-	CategoryID  uuid.UUID   `json:"category_id" validate:"required"`
-	Limit       int         `json:"limit" validate:"min=1,max=50"`
-	ExcludedIDs []uuid.UUID `json:"excluded_ids"`
+	UserID       *uuid.UUID  `json:"user_id,omitempty"` // Set for authenticated requests, to apply their hide_explicit preference
+	CategoryID   uuid.UUID   `json:"category_id" validate:"required"`
+	Limit        int         `json:"limit" validate:"min=1,max=50"`
+	ExcludedIDs  []uuid.UUID `json:"excluded_ids"`
+	HideExplicit *bool       `json:"hide_explicit,omitempty"` // Overrides the user's hide_explicit preference
 }
 
 // RecommendationResponse represents a response with recommended items
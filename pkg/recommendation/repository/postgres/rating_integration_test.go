@@ -0,0 +1,195 @@
+//go:build integration
+
+// pkg/recommendation/repository/postgres/rating_integration_test.go
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
+)
+
+// rankingSchema holds just the columns the trending and popular queries read, so the tests
+// don't depend on the migrations having run
+const rankingSchema = `
+	CREATE TABLE podcasts (
+		id UUID PRIMARY KEY,
+		title TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		cover_image_url TEXT NOT NULL DEFAULT '',
+		explicit BOOLEAN NOT NULL DEFAULT FALSE,
+		status TEXT NOT NULL DEFAULT 'active',
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE TABLE podcast_categories (podcast_id UUID NOT NULL, category_id UUID NOT NULL);
+	CREATE TABLE episodes (id UUID PRIMARY KEY, podcast_id UUID NOT NULL);
+	CREATE TABLE listen_events (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		listener_id UUID,
+		episode_id UUID NOT NULL,
+		started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE TABLE podcast_reviews (
+		podcast_id UUID NOT NULL,
+		rating SMALLINT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'active'
+	);
+	CREATE TABLE trending_items (
+		id UUID NOT NULL,
+		type TEXT NOT NULL,
+		time_range TEXT NOT NULL,
+		score DOUBLE PRECISION NOT NULL,
+		last_updated TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+	);`
+
+// newIntegrationRepository connects to TEST_DATABASE_URL and creates the ranking tables in a
+// schema of their own, dropped when the test ends
+func newIntegrationRepository(t *testing.T) (*repository, *sqlx.DB) {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	// search_path is set per connection, so keep to one
+	db.SetMaxOpenConns(1)
+
+	schema := "ranking_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	t.Cleanup(func() {
+		db.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", schema))
+		db.Close()
+	})
+
+	for _, statement := range []string{
+		fmt.Sprintf("CREATE SCHEMA %s", schema),
+		fmt.Sprintf("SET search_path TO %s, public", schema),
+		rankingSchema,
+	} {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to set up the test schema: %v", err)
+		}
+	}
+
+	return &repository{db: db}, db
+}
+
+// rankedPodcast is a podcast seeded with its listens in the last day and its review ratings
+type rankedPodcast struct {
+	title   string
+	listens int
+	ratings []int
+}
+
+// seedRankedPodcasts creates the podcasts in a category, each with one episode. All listens are
+// by the same listener, so the trending query's listener factor is the same for every podcast.
+func seedRankedPodcasts(t *testing.T, db *sqlx.DB, categoryID uuid.UUID, podcasts []rankedPodcast) {
+	t.Helper()
+
+	listenerID := uuid.New()
+	for _, podcast := range podcasts {
+		podcastID, episodeID := uuid.New(), uuid.New()
+		exec := func(query string, args ...interface{}) {
+			if _, err := db.Exec(query, args...); err != nil {
+				t.Fatalf("failed to seed %s: %v", podcast.title, err)
+			}
+		}
+
+		exec(`INSERT INTO podcasts (id, title) VALUES ($1, $2)`, podcastID, podcast.title)
+		exec(`INSERT INTO podcast_categories (podcast_id, category_id) VALUES ($1, $2)`, podcastID, categoryID)
+		exec(`INSERT INTO episodes (id, podcast_id) VALUES ($1, $2)`, episodeID, podcastID)
+		exec(`INSERT INTO listen_events (listener_id, episode_id, started_at)
+			SELECT $1, $2, CURRENT_TIMESTAMP - INTERVAL '1 hour' FROM generate_series(1, $3)`,
+			listenerID, episodeID, podcast.listens)
+		for _, rating := range podcast.ratings {
+			exec(`INSERT INTO podcast_reviews (podcast_id, rating) VALUES ($1, $2)`, podcastID, rating)
+		}
+	}
+}
+
+// repeatRating returns n reviews of the same rating
+func repeatRating(rating, n int) []int {
+	ratings := make([]int, n)
+	for i := range ratings {
+		ratings[i] = rating
+	}
+	return ratings
+}
+
+// rankingPodcasts is a listen ranking that ratings should reorder. The most listened podcast is
+// rated poorly by many, the next is rated well by many, and the least listened has a single
+// 5-star review. With the ratings' average of about 4.08 and 5 prior reviews, their Bayesian
+// ratings are about 2.54, 4.66 and 4.23.
+var rankingPodcasts = []rankedPodcast{
+	{title: "many-low", listens: 20, ratings: repeatRating(1, 5)},
+	{title: "many-high", listens: 19, ratings: append(repeatRating(5, 16), repeatRating(4, 4)...)},
+	{title: "few-high", listens: 18, ratings: []int{5}},
+}
+
+func TestRankingsWithAndWithoutRatings(t *testing.T) {
+	repo, db := newIntegrationRepository(t)
+	categoryID := uuid.New()
+	seedRankedPodcasts(t, db, categoryID, rankingPodcasts)
+
+	tests := []struct {
+		name    string
+		ratings models.RatingWeights
+		want    []string
+	}{
+		{
+			name: "without ratings",
+			want: []string{"many-low", "many-high", "few-high"},
+		},
+		{
+			// Scores become about 17.7, 19.8 and 18.2: the poorly rated podcast drops to last,
+			// and a single 5-star review isn't enough to overtake the well rated one
+			name:    "with ratings",
+			ratings: models.RatingWeights{Weight: 0.3, PriorReviews: 5},
+			want:    []string{"many-high", "few-high", "many-low"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+", trending", func(t *testing.T) {
+			items, err := repo.GetTrendingPodcasts(context.Background(), "daily", len(rankingPodcasts), tt.ratings, nil, false)
+			if err != nil {
+				t.Fatalf("GetTrendingPodcasts() error = %v", err)
+			}
+			if got := rankedTitles(items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTrendingPodcasts() = %v, want %v", got, tt.want)
+			}
+		})
+
+		t.Run(tt.name+", popular in category", func(t *testing.T) {
+			items, err := repo.GetPopularInCategory(context.Background(), categoryID, len(rankingPodcasts), tt.ratings, nil, false)
+			if err != nil {
+				t.Fatalf("GetPopularInCategory() error = %v", err)
+			}
+			if got := rankedTitles(items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetPopularInCategory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// rankedTitles returns the titles of items in order, for comparing rankings
+func rankedTitles(items []models.RecommendedItem) []string {
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
@@ -3,34 +3,51 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/database"
 	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
 )
 
 // Repository defines the methods for the recommendation repository
 type Repository interface {
 	// User-based recommendations
-	GetPersonalizedRecommendations(ctx context.Context, userID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error)
+	GetPersonalizedRecommendations(ctx context.Context, userID uuid.UUID, limit int, weights models.PersonalizationWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error)
 	
 	// Similar content recommendations
-	GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error)
-	GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error)
+	GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID, limit int, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error)
+	GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID, limit int, excludedIDs []uuid.UUID, listened *models.ListenedFilter, hideExplicit bool) ([]models.RecommendedItem, error)
+	RefreshSimilarPodcasts(ctx context.Context) (int, error)
 	
 	// Popular content recommendations
-	GetTrendingPodcasts(ctx context.Context, timeRange string, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error)
-	GetPopularInCategory(ctx context.Context, categoryID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error)
+	GetTrendingPodcasts(ctx context.Context, timeRange string, limit int, ratings models.RatingWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error)
+	GetPopularInCategory(ctx context.Context, categoryID uuid.UUID, limit int, ratings models.RatingWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error)
+	RefreshTrendingPodcasts(ctx context.Context, timeRange string, ratings models.RatingWeights, ttl time.Duration) (int, error)
 	
 	// User preferences management
 	UpdateUserPreference(ctx context.Context, userID uuid.UUID, categoryID uuid.UUID, weight float64) error
 	GetUserPreferences(ctx context.Context, userID uuid.UUID) ([]models.UserPreference, error)
+	
+	// Recommendation feedback
+	SaveFeedback(ctx context.Context, feedback *models.RecommendationFeedback) error
+	
+	// User settings
+	GetHideExplicit(ctx context.Context, userID uuid.UUID) (bool, error)
 }
 
 type repository struct {
 	db *sqlx.DB
+	
+	// trigramUnavailable is set once similarity() turned out to be missing, as pg_trgm isn't installed
+	trigramUnavailable atomic.Bool
 }
 
 // NewRepository creates a new recommendation repository
@@ -38,8 +55,13 @@ func NewRepository(db *sqlx.DB) Repository {
 	return &repository{db: db}
 }
 
-// GetPersonalizedRecommendations gets personalized recommendations for a user
-func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error) {
+// GetPersonalizedRecommendations gets personalized recommendations for a user. Podcasts are scored
+// on the categories the user engaged with (listens and subscriptions) and the user's explicit
+// category preferences, less a penalty for categories of disliked items. Podcasts the user hid or
+// marked as not interested are never recommended.
+func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID uuid.UUID, limit int, weights models.PersonalizationWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error) {
+	defer database.TrackSlowQuery("recommendation.GetPersonalizedRecommendations")()
+
 	// In a production scenario, this would use a sophisticated recommendation algorithm
 	// For now, we'll implement a simpler version based on categories the user has engaged with
 	
@@ -48,15 +70,16 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 	var excludeCondition string
 	if len(excludedIDs) > 0 {
 		excludedIDsParam = excludedIDs
-		excludeCondition = "AND p.id != ANY($3)"
+		excludeCondition = "AND p.id != ANY($5)"
 	} else {
 		excludedIDsParam = nil
 		excludeCondition = ""
 	}
+	excludeCondition += explicitCondition("p", hideExplicit)
 	
-	// Base the recommendations on user's listening history categories and subscriptions
+	// Base the recommendations on user's listening history categories, subscriptions and explicit preferences
 	query := fmt.Sprintf(`
-		WITH user_categories AS (
+		WITH engaged_categories AS (
 			-- Categories from user's listen history
 			SELECT DISTINCT c.id AS category_id
 			FROM listen_events le
@@ -75,6 +98,30 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 			JOIN podcast_categories pc ON p.id = pc.podcast_id
 			JOIN categories c ON pc.category_id = c.id
 			WHERE s.listener_id = $1
+		),
+		
+		preferred_categories AS (
+			-- Categories the user explicitly boosted or demoted
+			SELECT category_id, weight
+			FROM user_preferences
+			WHERE user_id = $1
+		),
+		
+		user_categories AS (
+			-- Candidates come from engaged and boosted categories; demoted ones only lower scores
+			SELECT category_id FROM engaged_categories
+			UNION
+			SELECT category_id FROM preferred_categories WHERE weight > 0
+		),
+		
+		disliked_categories AS (
+			-- Categories of disliked podcasts and of the podcasts of disliked episodes
+			SELECT pc.category_id, COUNT(*)::float AS dislikes
+			FROM recommendation_feedback rf
+			LEFT JOIN episodes e ON rf.item_type = 'episode' AND e.id = rf.item_id
+			JOIN podcast_categories pc ON pc.podcast_id = CASE WHEN rf.item_type = 'podcast' THEN rf.item_id ELSE e.podcast_id END
+			WHERE rf.user_id = $1 AND rf.signal = 'dislike'
+			GROUP BY pc.category_id
 		)
 		
 		SELECT 
@@ -85,13 +132,27 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 			p.cover_image_url AS image_url,
 			p.id AS podcast_id,
 			p.title AS podcast_title,
-			-- Simple scoring based on number of matching categories and listen counts
+			p.podcaster_id,
+			COALESCE(p.category, '') AS category,
+			-- Scoring based on matching engaged categories, preference weights and listen counts
 			(
 				SELECT COUNT(*)::float 
 				FROM podcast_categories pc2 
-				JOIN user_categories uc ON pc2.category_id = uc.category_id
+				JOIN engaged_categories ec ON pc2.category_id = ec.category_id
 				WHERE pc2.podcast_id = p.id
 			) * 10 +
+			(
+				SELECT COALESCE(SUM(pf.weight), 0)::float
+				FROM podcast_categories pc3
+				JOIN preferred_categories pf ON pc3.category_id = pf.category_id
+				WHERE pc3.podcast_id = p.id
+			) * $3 -
+			(
+				SELECT COALESCE(SUM(dc.dislikes), 0)::float
+				FROM podcast_categories pc4
+				JOIN disliked_categories dc ON pc4.category_id = dc.category_id
+				WHERE pc4.podcast_id = p.id
+			) * $4 +
 			(
 				SELECT COALESCE(COUNT(le.id), 0)::float
 				FROM listen_events le
@@ -105,6 +166,11 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 		WHERE p.id NOT IN (
 			SELECT podcast_id FROM subscriptions WHERE listener_id = $1
 		)
+		-- Exclude podcasts the user dismissed
+		AND p.id NOT IN (
+			SELECT item_id FROM recommendation_feedback
+			WHERE user_id = $1 AND item_type = 'podcast' AND signal IN ('hide', 'not_interested')
+		)
 		-- Exclude specified podcasts
 		%s
 		AND p.status = 'active'
@@ -117,9 +183,9 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 	var err error
 	
 	if len(excludedIDs) > 0 {
-		err = r.db.SelectContext(ctx, &items, query, userID, limit, excludedIDsParam)
+		err = r.db.SelectContext(ctx, &items, query, userID, limit, weights.Preference, weights.DislikePenalty, excludedIDsParam)
 	} else {
-		err = r.db.SelectContext(ctx, &items, query, userID, limit)
+		err = r.db.SelectContext(ctx, &items, query, userID, limit, weights.Preference, weights.DislikePenalty)
 	}
 	
 	if err != nil {
@@ -129,17 +195,29 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 	// If we couldn't find enough recommendations based on user behavior,
 	// supplement with trending podcasts
 	if len(items) < limit {
-		trendings, err := r.GetTrendingPodcasts(ctx, "weekly", limit-len(items), excludedIDs)
+		trendings, err := r.GetTrendingPodcasts(ctx, "weekly", limit-len(items), weights.Ratings, excludedIDs, hideExplicit)
 		if err != nil {
 			return items, nil // Return what we have even if trending query fails
 		}
 		
-		// Add trending items, but avoid duplicates
+		// Add trending items, but avoid duplicates and podcasts the user dismissed
 		existingIDs := make(map[uuid.UUID]bool)
 		for _, item := range items {
 			existingIDs[item.ID] = true
 		}
 		
+		var dismissedIDs []uuid.UUID
+		dismissedQuery := `
+			SELECT item_id FROM recommendation_feedback
+			WHERE user_id = $1 AND item_type = 'podcast' AND signal IN ('hide', 'not_interested')
+		`
+		if err := r.db.SelectContext(ctx, &dismissedIDs, dismissedQuery, userID); err != nil {
+			return items, nil // Return what we have rather than risk showing dismissed podcasts
+		}
+		for _, id := range dismissedIDs {
+			existingIDs[id] = true
+		}
+		
 		for _, trending := range trendings {
 			if !existingIDs[trending.ID] {
 				items = append(items, trending)
@@ -151,8 +229,12 @@ func (r *repository) GetPersonalizedRecommendations(ctx context.Context, userID
 	return items, nil
 }
 
-// GetSimilarPodcasts gets podcasts similar to a specified podcast
-func (r *repository) GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error) {
+// GetSimilarPodcasts gets podcasts similar to a specified podcast. The similar podcasts stored by
+// RefreshSimilarPodcasts are read when the podcast has them and isn't waiting to be recomputed;
+// otherwise they are scored on the spot.
+func (r *repository) GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID, limit int, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error) {
+	defer database.TrackSlowQuery("recommendation.GetSimilarPodcasts")()
+
 	// Build the exclusion list for the query
 	var excludedIDsParam interface{}
 	var excludeCondition string
@@ -163,13 +245,70 @@ func (r *repository) GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID
 		excludedIDsParam = nil
 		excludeCondition = "AND p2.id != $1"
 	}
+	excludeCondition += explicitCondition("p2", hideExplicit)
+	
+	var precomputed bool
+	err := r.db.GetContext(ctx, &precomputed, `
+		SELECT EXISTS (
+			SELECT 1 FROM similarity_scores WHERE item_type = 'podcast' AND item_id1 = $1
+		) AND NOT EXISTS (
+			SELECT 1 FROM similarity_stale_podcasts WHERE podcast_id = $1
+		)
+	`, podcastID)
+	if err != nil {
+		return nil, err
+	}
 	
-	// Find similar podcasts based on category overlap
-	query := fmt.Sprintf(`
+	var query string
+	if precomputed {
+		query = fmt.Sprintf(`
+			SELECT 
+				p2.id,
+				'podcast' AS type,
+				p2.title,
+				p2.description,
+				p2.cover_image_url AS image_url,
+				p2.id AS podcast_id,
+				p2.title AS podcast_title,
+				p2.podcaster_id,
+				COALESCE(p2.category, '') AS category,
+				ss.score
+			FROM similarity_scores ss
+			JOIN podcasts p2 ON p2.id = ss.item_id2
+			WHERE ss.item_type = 'podcast' AND ss.item_id1 = $1
+			%s
+			AND p2.status = 'active'
+			ORDER BY ss.score DESC
+			LIMIT $2
+		`, excludeCondition)
+	} else {
+		query = liveSimilarPodcastsQuery(excludeCondition)
+	}
+	
+	var items []models.RecommendedItem
+	
+	if len(excludedIDs) > 0 {
+		err = r.db.SelectContext(ctx, &items, query, podcastID, limit, excludedIDsParam)
+	} else {
+		err = r.db.SelectContext(ctx, &items, query, podcastID, limit)
+	}
+	
+	return items, err
+}
+
+// liveSimilarPodcastsQuery returns the query finding the podcasts similar to podcast $1 based on
+// category and keyword overlap, limited to $2. condition is added to its WHERE clause.
+func liveSimilarPodcastsQuery(condition string) string {
+	return fmt.Sprintf(`
 		WITH podcast_cats AS (
 			SELECT category_id
 			FROM podcast_categories
 			WHERE podcast_id = $1
+		),
+		source AS (
+			SELECT keywords
+			FROM podcasts
+			WHERE id = $1
 		)
 		
 		SELECT 
@@ -180,56 +319,167 @@ func (r *repository) GetSimilarPodcasts(ctx context.Context, podcastID uuid.UUID
 			p2.cover_image_url AS image_url,
 			p2.id AS podcast_id,
 			p2.title AS podcast_title,
-			-- Score based on category overlap
-			(
-				SELECT COUNT(*)::float 
+			p2.podcaster_id,
+			COALESCE(p2.category, '') AS category,
+			%s AS score
+		FROM podcasts p2
+		CROSS JOIN source s
+		WHERE (
+			EXISTS (
+				SELECT 1 
 				FROM podcast_categories pc2 
 				JOIN podcast_cats pc ON pc2.category_id = pc.category_id
 				WHERE pc2.podcast_id = p2.id
-			) / 
-			(
-				SELECT COUNT(*)::float 
-				FROM podcast_categories 
-				WHERE podcast_id = p2.id
-			) * 100 AS score
-		FROM podcasts p2
-		WHERE EXISTS (
-			SELECT 1 
-			FROM podcast_categories pc2 
-			JOIN podcast_cats pc ON pc2.category_id = pc.category_id
-			WHERE pc2.podcast_id = p2.id
+			)
+			OR p2.keywords && s.keywords
 		)
 		%s
 		AND p2.status = 'active'
 		ORDER BY score DESC
 		LIMIT $2
-	`, excludeCondition)
+	`, similarPodcastScore("$1", "s.keywords"), condition)
+}
+
+// similarPodcastScore returns the SQL scoring podcast p2's similarity to a source podcast, given
+// the expressions of the source's ID and keywords: the share of p2's categories the source is in,
+// plus the share of the source's keywords p2 also has
+func similarPodcastScore(sourceID, sourceKeywords string) string {
+	return fmt.Sprintf(`COALESCE((
+				SELECT COUNT(*)::float 
+				FROM podcast_categories pc2 
+				JOIN podcast_categories spc ON pc2.category_id = spc.category_id
+				WHERE pc2.podcast_id = p2.id AND spc.podcast_id = %[1]s
+			) / 
+			NULLIF((
+				SELECT COUNT(*)::float 
+				FROM podcast_categories 
+				WHERE podcast_id = p2.id
+			), 0), 0) * 100 +
+			cardinality(ARRAY(SELECT unnest(p2.keywords) INTERSECT SELECT unnest(%[2]s)))::float /
+			GREATEST(cardinality(%[2]s), 1) * 50`, sourceID, sourceKeywords)
+}
+
+// similarPodcastsPerSource is how many similar podcasts RefreshSimilarPodcasts keeps per podcast,
+// enough to fill a page after diversification and the exclusions of a request
+const similarPodcastsPerSource = 200
+
+// similarityBatchSize is how many podcasts RefreshSimilarPodcasts recomputes per transaction
+const similarityBatchSize = 500
+
+// RefreshSimilarPodcasts recomputes the stored similar podcasts of the podcasts queued as stale,
+// and of the podcasts whose similar podcasts they may enter or leave: those sharing a category
+// or keyword with them and those currently listing them. It returns how many podcasts were
+// recomputed. Podcasts queued while it runs stay queued for the next run.
+func (r *repository) RefreshSimilarPodcasts(ctx context.Context) (int, error) {
+	defer database.TrackSlowQuery("recommendation.RefreshSimilarPodcasts")()
+
+	startedAt := time.Now().UTC()
 	
-	var items []models.RecommendedItem
-	var err error
+	var stale []uuid.UUID
+	err := r.db.SelectContext(ctx, &stale, `SELECT podcast_id FROM similarity_stale_podcasts WHERE marked_at <= $1`, startedAt)
+	if err != nil {
+		return 0, err
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
 	
-	if len(excludedIDs) > 0 {
-		err = r.db.SelectContext(ctx, &items, query, podcastID, limit, excludedIDsParam)
-	} else {
-		err = r.db.SelectContext(ctx, &items, query, podcastID, limit)
+	var affected []uuid.UUID
+	err = r.db.SelectContext(ctx, &affected, `
+		SELECT id FROM podcasts WHERE id = ANY($1)
+		UNION
+		SELECT item_id1 FROM similarity_scores WHERE item_type = 'podcast' AND item_id2 = ANY($1)
+		UNION
+		SELECT pc.podcast_id
+		FROM podcast_categories pc
+		WHERE pc.category_id IN (SELECT category_id FROM podcast_categories WHERE podcast_id = ANY($1))
+		UNION
+		SELECT p.id
+		FROM podcasts p
+		WHERE p.keywords && ARRAY(SELECT DISTINCT unnest(keywords) FROM podcasts WHERE id = ANY($1))
+	`, pq.Array(stale))
+	if err != nil {
+		return 0, err
 	}
 	
-	return items, err
+	// The similar podcasts of removed podcasts go along with them
+	_, err = r.db.ExecContext(ctx, `
+		DELETE FROM similarity_scores
+		WHERE item_type = 'podcast' AND item_id1 = ANY($1)
+		AND NOT EXISTS (SELECT 1 FROM podcasts WHERE id = item_id1)
+	`, pq.Array(stale))
+	if err != nil {
+		return 0, err
+	}
+	
+	for start := 0; start < len(affected); start += similarityBatchSize {
+		end := start + similarityBatchSize
+		if end > len(affected) {
+			end = len(affected)
+		}
+		if err := r.refreshSimilarPodcastsBatch(ctx, affected[start:end], startedAt); err != nil {
+			return 0, err
+		}
+	}
+	
+	_, err = r.db.ExecContext(ctx, `DELETE FROM similarity_stale_podcasts WHERE podcast_id = ANY($1) AND marked_at <= $2`, pq.Array(stale), startedAt)
+	if err != nil {
+		return 0, err
+	}
+	
+	return len(affected), nil
 }
 
-// GetSimilarEpisodes gets episodes similar to a specified episode
-func (r *repository) GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error) {
-	// Build the exclusion list for the query
-	var excludedIDsParam interface{}
-	var excludeCondition string
-	if len(excludedIDs) > 0 {
-		excludedIDsParam = excludedIDs
-		excludeCondition = "AND e2.id != ANY($3) AND e2.id != $1"
-	} else {
-		excludedIDsParam = nil
-		excludeCondition = "AND e2.id != $1"
+// refreshSimilarPodcastsBatch replaces the stored similar podcasts of the given podcasts
+func (r *repository) refreshSimilarPodcastsBatch(ctx context.Context, podcastIDs []uuid.UUID, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	
+	_, err = tx.ExecContext(ctx, `DELETE FROM similarity_scores WHERE item_type = 'podcast' AND item_id1 = ANY($1)`, pq.Array(podcastIDs))
+	if err != nil {
+		return err
 	}
 	
+	query := fmt.Sprintf(`
+		INSERT INTO similarity_scores (item_id1, item_id2, item_type, score, last_updated)
+		SELECT src.id, sim.id, 'podcast', sim.score, $2
+		FROM podcasts src
+		CROSS JOIN LATERAL (
+			SELECT p2.id, %s AS score
+			FROM podcasts p2
+			WHERE p2.id != src.id
+			AND p2.status != 'deleted'
+			AND (
+				EXISTS (
+					SELECT 1
+					FROM podcast_categories pc2
+					JOIN podcast_categories spc ON pc2.category_id = spc.category_id
+					WHERE pc2.podcast_id = p2.id AND spc.podcast_id = src.id
+				)
+				OR p2.keywords && src.keywords
+			)
+			ORDER BY score DESC
+			LIMIT $3
+		) sim
+		WHERE src.id = ANY($1)
+	`, similarPodcastScore("src.id", "src.keywords"))
+	
+	_, err = tx.ExecContext(ctx, query, pq.Array(podcastIDs), now, similarPodcastsPerSource)
+	if err != nil {
+		return err
+	}
+	
+	return tx.Commit()
+}
+
+// GetSimilarEpisodes gets episodes similar to a specified episode. When a listener filter is given,
+// episodes the listener already finished or got far enough into are left out.
+func (r *repository) GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID, limit int, excludedIDs []uuid.UUID, listened *models.ListenedFilter, hideExplicit bool) ([]models.RecommendedItem, error) {
+	defer database.TrackSlowQuery("recommendation.GetSimilarEpisodes")()
+
 	// Get the source episode details first
 	sourceEpisodeQuery := `
 		SELECT e.podcast_id, e.title
@@ -244,8 +494,69 @@ func (r *repository) GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID
 		return nil, err
 	}
 	
-	// Find similar episodes based on same podcast and title similarity
-	query := fmt.Sprintf(`
+	conditions := []string{"AND e2.id != $1"}
+	args := []interface{}{episodeID, limit, sourcePodcastID}
+	if hideExplicit {
+		conditions = append(conditions, "AND NOT e2.explicit")
+	}
+	
+	// Build the exclusion list for the query
+	if len(excludedIDs) > 0 {
+		args = append(args, excludedIDs)
+		conditions = append(conditions, fmt.Sprintf("AND e2.id != ANY($%d)", len(args)))
+	}
+	
+	// Leave out episodes the listener already heard
+	if listened != nil {
+		args = append(args, listened.ListenerID, listened.ProgressRatio)
+		conditions = append(conditions, fmt.Sprintf(`AND NOT EXISTS (
+				SELECT 1 FROM playback_history ph
+				WHERE ph.listener_id = $%d AND ph.episode_id = e2.id
+				AND (ph.completed OR ($%d::float > 0 AND e2.duration > 0 AND ph.position >= e2.duration * $%d::float))
+			)`, len(args)-1, len(args), len(args)))
+	}
+	
+	if !r.trigramUnavailable.Load() {
+		query := similarEpisodesQuery(len(args)+1, strings.Join(conditions, "\n\t\t\t"))
+		
+		var items []models.RecommendedItem
+		err = r.db.SelectContext(ctx, &items, query, append(args, sourceTitle)...)
+		if !isUndefinedFunction(err, "similarity") {
+			return items, err
+		}
+		
+		// similarity() comes with the pg_trgm extension (see migration 000043). Without it titles
+		// aren't compared, until the service is restarted with the extension installed.
+		r.trigramUnavailable.Store(true)
+	}
+	
+	query := similarEpisodesQuery(0, strings.Join(conditions, "\n\t\t\t"))
+	
+	var items []models.RecommendedItem
+	err = r.db.SelectContext(ctx, &items, query, args...)
+	return items, err
+}
+
+// similarEpisodesQuery returns the query finding the episodes similar to episode $1 based on being
+// from the same podcast $3, title similarity and shared keywords, limited to $2. Titles are compared
+// with pg_trgm's similarity() to the source title in parameter titleParam, unless it is zero.
+// conditions are added to its WHERE clause.
+func similarEpisodesQuery(titleParam int, conditions string) string {
+	titleScore := ""
+	titleMatch := ""
+	if titleParam > 0 {
+		titleScore = fmt.Sprintf(`
+			-- Simple text similarity score (placeholder for more sophisticated algorithm)
+			(similarity(e2.title, $%d) * 50) +`, titleParam)
+		titleMatch = fmt.Sprintf(" OR similarity(e2.title, $%d) > 0.2", titleParam)
+	}
+	
+	return fmt.Sprintf(`
+		WITH source AS (
+			SELECT keywords
+			FROM episodes
+			WHERE id = $1
+		)
 		SELECT 
 			e2.id,
 			'episode' AS type,
@@ -256,94 +567,97 @@ func (r *repository) GetSimilarEpisodes(ctx context.Context, episodeID uuid.UUID
 			p.title AS podcast_title,
 			-- Score based on same podcast and title similarity
 			CASE
-				WHEN e2.podcast_id = $4 THEN 50
+				WHEN e2.podcast_id = $3 THEN 50
 				ELSE 10
-			END +
-			-- Simple text similarity score (placeholder for more sophisticated algorithm)
-			(similarity(e2.title, $5) * 50) AS score
+			END +%s
+			-- Share of the source episode's keywords the episode also has
+			(cardinality(ARRAY(SELECT unnest(e2.keywords) INTERSECT SELECT unnest(s.keywords)))::float /
+			GREATEST(cardinality(s.keywords), 1) * 40) AS score
 		FROM episodes e2
 		JOIN podcasts p ON e2.podcast_id = p.id
+		CROSS JOIN source s
 		WHERE 
-			-- Either from the same podcast or contains similar words in title
-			(e2.podcast_id = $4 OR similarity(e2.title, $5) > 0.2)
+			-- From the same podcast, with similar words in the title or sharing keywords
+			(e2.podcast_id = $3%s OR e2.keywords && s.keywords)
 			%s
 			AND e2.status = 'active'
 		ORDER BY score DESC
 		LIMIT $2
-	`, excludeCondition)
-	
-	var items []models.RecommendedItem
-	
-	if len(excludedIDs) > 0 {
-		err = r.db.SelectContext(ctx, &items, query, episodeID, limit, excludedIDsParam, sourcePodcastID, sourceTitle)
-	} else {
-		err = r.db.SelectContext(ctx, &items, query, episodeID, limit, sourcePodcastID, sourceTitle)
-	}
-	
-	return items, err
+	`, titleScore, titleMatch, conditions)
 }
 
-// GetTrendingPodcasts gets trending podcasts
-func (r *repository) GetTrendingPodcasts(ctx context.Context, timeRange string, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error) {
-	// Determine the time filter based on time range
-	var timeFilter string
-	switch timeRange {
-	case "daily":
-		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '1 day'"
-	case "weekly":
-		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '7 days'"
-	case "monthly":
-		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '30 days'"
-	default:
-		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '7 days'"
-	}
+// isUndefinedFunction reports whether err is Postgres' undefined_function error for the named
+// function, which calls to functions of extensions that aren't installed fail with
+func isUndefinedFunction(err error, name string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "42883" && strings.Contains(pqErr.Message, name+"(")
+}
+
+// GetTrendingPodcasts gets trending podcasts. Listen-based scores are scaled by the podcasts' ratings.
+// Scores precomputed by RefreshTrendingPodcasts are used while they haven't expired; otherwise
+// they are computed from the listen events.
+func (r *repository) GetTrendingPodcasts(ctx context.Context, timeRange string, limit int, ratings models.RatingWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error) {
+	defer database.TrackSlowQuery("recommendation.GetTrendingPodcasts")()
+
+	timeRange = trendingTimeRange(timeRange)
 	
 	// Build the exclusion list for the query
 	var excludedIDsParam interface{}
 	var excludeCondition string
 	if len(excludedIDs) > 0 {
 		excludedIDsParam = excludedIDs
-		excludeCondition = "AND p.id != ANY($3)"
+		excludeCondition = "AND p.id != ANY($4)"
 	} else {
 		excludedIDsParam = nil
 		excludeCondition = ""
 	}
+	excludeCondition += explicitCondition("p", hideExplicit)
 	
-	// Query trending podcasts based on listen events
-	query := fmt.Sprintf(`
-		SELECT 
-			p.id,
-			'podcast' AS type,
-			p.title,
-			p.description,
-			p.cover_image_url AS image_url,
-			p.id AS podcast_id,
-			p.title AS podcast_title,
-			-- Score based on listen count and recency
-			COUNT(le.id) * 
-			(1.0 + 0.1 * (
-				SELECT COUNT(DISTINCT le2.listener_id) 
-				FROM listen_events le2 
-				JOIN episodes e2 ON le2.episode_id = e2.id 
-				WHERE e2.podcast_id = p.id %s
-			)) AS score
-		FROM listen_events le
-		JOIN episodes e ON le.episode_id = e.id
-		JOIN podcasts p ON e.podcast_id = p.id
-		WHERE 1=1 %s %s
-		AND p.status = 'active'
-		GROUP BY p.id, p.title, p.description, p.cover_image_url
-		ORDER BY score DESC
-		LIMIT $2
-	`, timeFilter, timeFilter, excludeCondition)
+	var precomputed bool
+	err := r.db.GetContext(ctx, &precomputed, `
+		SELECT EXISTS (
+			SELECT 1 FROM trending_items
+			WHERE time_range = $1 AND type = 'podcast' AND expires_at > CURRENT_TIMESTAMP
+		)
+	`, timeRange)
+	if err != nil {
+		return nil, err
+	}
 	
 	var items []models.RecommendedItem
-	var err error
 	
-	if len(excludedIDs) > 0 {
-		err = r.db.SelectContext(ctx, &items, query, limit, excludedIDsParam)
+	if precomputed {
+		query := fmt.Sprintf(`
+			SELECT 
+				p.id,
+				'podcast' AS type,
+				p.title,
+				p.description,
+				p.cover_image_url AS image_url,
+				p.id AS podcast_id,
+				p.title AS podcast_title,
+				ti.score
+			FROM trending_items ti
+			JOIN podcasts p ON p.id = ti.id
+			WHERE ti.time_range = $1 AND ti.type = 'podcast' AND ti.expires_at > CURRENT_TIMESTAMP
+			AND p.status = 'active' %s
+			ORDER BY ti.score DESC
+			LIMIT $2
+		`, strings.Replace(excludeCondition, "$4", "$3", 1))
+		
+		if len(excludedIDs) > 0 {
+			err = r.db.SelectContext(ctx, &items, query, timeRange, limit, excludedIDsParam)
+		} else {
+			err = r.db.SelectContext(ctx, &items, query, timeRange, limit)
+		}
 	} else {
-		err = r.db.SelectContext(ctx, &items, query, limit)
+		query := liveTrendingQuery(timeRange, excludeCondition)
+		
+		if len(excludedIDs) > 0 {
+			err = r.db.SelectContext(ctx, &items, query, limit, ratings.Weight, ratings.PriorReviews, excludedIDsParam)
+		} else {
+			err = r.db.SelectContext(ctx, &items, query, limit, ratings.Weight, ratings.PriorReviews)
+		}
 	}
 	
 	if err != nil {
@@ -367,7 +681,7 @@ func (r *repository) GetTrendingPodcasts(ctx context.Context, timeRange string,
 			WHERE p.status = 'active' %s
 			ORDER BY p.created_at DESC
 			LIMIT $1
-		`, excludeCondition)
+		`, strings.Replace(excludeCondition, "$4", "$2", 1))
 		
 		var recentItems []models.RecommendedItem
 		
@@ -396,21 +710,128 @@ func (r *repository) GetTrendingPodcasts(ctx context.Context, timeRange string,
 	return items, nil
 }
 
-// GetPopularInCategory gets popular content in a category
-func (r *repository) GetPopularInCategory(ctx context.Context, categoryID uuid.UUID, limit int, excludedIDs []uuid.UUID) ([]models.RecommendedItem, error) {
+// trendingItemsPerRange is how many podcasts RefreshTrendingPodcasts keeps per time range, enough
+// to fill a page after the exclusions of a request
+const trendingItemsPerRange = 1000
+
+// RefreshTrendingPodcasts computes the trending podcasts of a time range into trending_items,
+// replacing the previous scores, and returns how many were stored. GetTrendingPodcasts serves
+// them until ttl has passed.
+func (r *repository) RefreshTrendingPodcasts(ctx context.Context, timeRange string, ratings models.RatingWeights, ttl time.Duration) (int, error) {
+	defer database.TrackSlowQuery("recommendation.RefreshTrendingPodcasts")()
+
+	timeRange = trendingTimeRange(timeRange)
+	now := time.Now().UTC()
+	
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	
+	_, err = tx.ExecContext(ctx, `DELETE FROM trending_items WHERE time_range = $1 AND type = 'podcast'`, timeRange)
+	if err != nil {
+		return 0, err
+	}
+	
+	query := fmt.Sprintf(`
+		INSERT INTO trending_items (id, type, time_range, score, last_updated, expires_at)
+		SELECT t.id, 'podcast', $4, t.score, $5, $6
+		FROM (%s) t
+	`, liveTrendingQuery(timeRange, ""))
+	
+	result, err := tx.ExecContext(ctx, query, trendingItemsPerRange, ratings.Weight, ratings.PriorReviews, timeRange, now, now.Add(ttl))
+	if err != nil {
+		return 0, err
+	}
+	
+	stored, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	
+	return int(stored), nil
+}
+
+// trendingTimeRange returns the time range trending podcasts are computed over, weekly unless
+// daily or monthly is asked for
+func trendingTimeRange(timeRange string) string {
+	switch timeRange {
+	case "daily", "monthly":
+		return timeRange
+	default:
+		return "weekly"
+	}
+}
+
+// liveTrendingQuery returns the query scoring podcasts by their listen events in the time range,
+// boosted by their rating. It takes the limit, rating weight and prior reviews as $1 to $3, and
+// condition is added to its WHERE clause.
+func liveTrendingQuery(timeRange, condition string) string {
+	var timeFilter string
+	switch timeRange {
+	case "daily":
+		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '1 day'"
+	case "monthly":
+		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '30 days'"
+	default:
+		timeFilter = "AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '7 days'"
+	}
+	
+	return fmt.Sprintf(`
+		WITH %s
+		SELECT 
+			p.id,
+			'podcast' AS type,
+			p.title,
+			p.description,
+			p.cover_image_url AS image_url,
+			p.id AS podcast_id,
+			p.title AS podcast_title,
+			-- Score based on listen count and recency
+			COUNT(le.id) * 
+			(1.0 + 0.1 * (
+				SELECT COUNT(DISTINCT le2.listener_id) 
+				FROM listen_events le2 
+				JOIN episodes e2 ON le2.episode_id = e2.id 
+				WHERE e2.podcast_id = p.id %s
+			)) * %s AS score
+		FROM listen_events le
+		JOIN episodes e ON le.episode_id = e.id
+		JOIN podcasts p ON e.podcast_id = p.id
+		LEFT JOIN podcast_ratings pr ON pr.podcast_id = p.id
+		CROSS JOIN rating_prior rp
+		WHERE 1=1 %s %s
+		AND p.status = 'active'
+		GROUP BY p.id, p.title, p.description, p.cover_image_url, pr.reviews, pr.total, rp.mean
+		ORDER BY score DESC
+		LIMIT $1
+	`, ratingCTEs, timeFilter, ratingBoost("$2", "$3"), timeFilter, condition)
+}
+
+// GetPopularInCategory gets popular content in a category. Listen-based scores are scaled by the podcasts' ratings.
+func (r *repository) GetPopularInCategory(ctx context.Context, categoryID uuid.UUID, limit int, ratings models.RatingWeights, excludedIDs []uuid.UUID, hideExplicit bool) ([]models.RecommendedItem, error) {
+	defer database.TrackSlowQuery("recommendation.GetPopularInCategory")()
+
 	// Build the exclusion list for the query
 	var excludedIDsParam interface{}
 	var excludeCondition string
 	if len(excludedIDs) > 0 {
 		excludedIDsParam = excludedIDs
-		excludeCondition = "AND p.id != ANY($3)"
+		excludeCondition = "AND p.id != ANY($5)"
 	} else {
 		excludedIDsParam = nil
 		excludeCondition = ""
 	}
+	excludeCondition += explicitCondition("p", hideExplicit)
 	
-	// Query popular podcasts in the given category
+	// Query popular podcasts in the given category, boosted by their rating
 	query := fmt.Sprintf(`
+		WITH %s
 		SELECT 
 			p.id,
 			'podcast' AS type,
@@ -426,27 +847,63 @@ func (r *repository) GetPopularInCategory(ctx context.Context, categoryID uuid.U
 				JOIN episodes e ON le.episode_id = e.id
 				WHERE e.podcast_id = p.id
 				AND le.started_at > CURRENT_TIMESTAMP - INTERVAL '30 days'
-			) AS score
+			) * %s AS score
 		FROM podcasts p
 		JOIN podcast_categories pc ON p.id = pc.podcast_id
+		LEFT JOIN podcast_ratings pr ON pr.podcast_id = p.id
+		CROSS JOIN rating_prior rp
 		WHERE pc.category_id = $1 %s
 		AND p.status = 'active'
 		ORDER BY score DESC
 		LIMIT $2
-	`, excludeCondition)
+	`, ratingCTEs, ratingBoost("$3", "$4"), excludeCondition)
 	
 	var items []models.RecommendedItem
 	var err error
 	
 	if len(excludedIDs) > 0 {
-		err = r.db.SelectContext(ctx, &items, query, categoryID, limit, excludedIDsParam)
+		err = r.db.SelectContext(ctx, &items, query, categoryID, limit, ratings.Weight, ratings.PriorReviews, excludedIDsParam)
 	} else {
-		err = r.db.SelectContext(ctx, &items, query, categoryID, limit)
+		err = r.db.SelectContext(ctx, &items, query, categoryID, limit, ratings.Weight, ratings.PriorReviews)
 	}
 	
 	return items, err
 }
 
+// ratingCTEs are the common table expressions ratingBoost reads: rating_prior, the average
+// rating of all podcasts, and podcast_ratings, the review count and rating total of each podcast.
+// Queries join them as rp and pr.
+const ratingCTEs = `
+		rating_prior AS (
+			SELECT COALESCE(AVG(rating), 3)::float AS mean
+			FROM podcast_reviews
+			WHERE status = 'active'
+		),
+		podcast_ratings AS (
+			SELECT podcast_id, COUNT(*)::float AS reviews, SUM(rating)::float AS total
+			FROM podcast_reviews
+			WHERE status = 'active'
+			GROUP BY podcast_id
+		)`
+
+// ratingBoost returns the SQL factor scaling a podcast's score by its Bayesian average rating,
+// as described on models.RatingWeights, given the placeholders of the weight and prior reviews
+func ratingBoost(weightParam, priorParam string) string {
+	return fmt.Sprintf(
+		"(1.0 + %[1]s::float * (COALESCE((rp.mean * %[2]s::float + pr.total) / NULLIF(%[2]s::float + pr.reviews, 0), rp.mean) - rp.mean) / 4.0)",
+		weightParam, priorParam,
+	)
+}
+
+// explicitCondition returns the SQL condition leaving out explicit podcasts or episodes of the
+// given table alias when hideExplicit is set
+func explicitCondition(alias string, hideExplicit bool) string {
+	if !hideExplicit {
+		return ""
+	}
+	return fmt.Sprintf(" AND NOT %s.explicit", alias)
+}
+
 // UpdateUserPreference updates a user's category preference
 func (r *repository) UpdateUserPreference(ctx context.Context, userID uuid.UUID, categoryID uuid.UUID, weight float64) error {
 	query := `
@@ -456,7 +913,7 @@ func (r *repository) UpdateUserPreference(ctx context.Context, userID uuid.UUID,
 		DO UPDATE SET weight = $3, last_updated = $4
 	`
 	
-	now := time.Now()
+	now := time.Now().UTC()
 	_, err := r.db.ExecContext(ctx, query, userID, categoryID, weight, now)
 	return err
 }
@@ -473,4 +930,32 @@ func (r *repository) GetUserPreferences(ctx context.Context, userID uuid.UUID) (
 	var preferences []models.UserPreference
 	err := r.db.SelectContext(ctx, &preferences, query, userID)
 	return preferences, err
-}
\ No newline at end of file
+}
+
+// SaveFeedback records a user's feedback on a recommended item, replacing earlier feedback on it
+func (r *repository) SaveFeedback(ctx context.Context, feedback *models.RecommendationFeedback) error {
+	query := `
+		INSERT INTO recommendation_feedback (id, user_id, item_id, item_type, signal, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, item_type, item_id)
+		DO UPDATE SET signal = $5, created_at = $6
+		RETURNING id
+	`
+	
+	if feedback.ID == uuid.Nil {
+		feedback.ID = uuid.New()
+	}
+	feedback.CreatedAt = time.Now().UTC()
+	
+	return r.db.QueryRowContext(ctx, query, feedback.ID, feedback.UserID, feedback.ItemID, feedback.ItemType, feedback.Signal, feedback.CreatedAt).Scan(&feedback.ID)
+}
+
+// GetHideExplicit gets whether a user chose to leave explicit content out of recommendations
+func (r *repository) GetHideExplicit(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var hideExplicit bool
+	err := r.db.GetContext(ctx, &hideExplicit, `SELECT hide_explicit FROM users WHERE id = $1`, userID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return hideExplicit, err
+}
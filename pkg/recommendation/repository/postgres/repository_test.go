@@ -0,0 +1,119 @@
+// pkg/recommendation/repository/postgres/repository_test.go
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// newMockRepository returns a repository backed by sqlmock, failing the test on unmet expectations
+func newMockRepository(t *testing.T) (*repository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+		db.Close()
+	})
+
+	return &repository{db: sqlx.NewDb(db, "postgres")}, mock
+}
+
+// errNoTrigram is the error similarity() calls fail with when pg_trgm isn't installed
+var errNoTrigram = &pq.Error{Code: "42883", Message: "function similarity(character varying, text) does not exist"}
+
+var similarEpisodeColumns = []string{"id", "type", "title", "description", "image_url", "podcast_id", "podcast_title", "score"}
+
+func TestGetSimilarEpisodesWithoutTrigram(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	episodeID, podcastID, similarID := uuid.New(), uuid.New(), uuid.New()
+	similarRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(similarEpisodeColumns).
+			AddRow(similarID, "episode", "Part 2", "", "", podcastID, "Nile Stories", 50.0)
+	}
+	expectSourceEpisode := func() {
+		mock.ExpectQuery(`SELECT e.podcast_id, e.title\s+FROM episodes e`).
+			WithArgs(episodeID).
+			WillReturnRows(sqlmock.NewRows([]string{"podcast_id", "title"}).AddRow(podcastID, "Part 1"))
+	}
+
+	// The title comparison fails, so the episodes are matched on podcast and keywords alone,
+	// without the source title as a parameter
+	expectSourceEpisode()
+	mock.ExpectQuery(`similarity\(e2.title, \$4\)`).
+		WithArgs(episodeID, 10, podcastID, "Part 1").
+		WillReturnError(errNoTrigram)
+	mock.ExpectQuery(`FROM episodes e2`).
+		WithArgs(episodeID, 10, podcastID).
+		WillReturnRows(similarRows())
+
+	// Later calls don't try similarity() again
+	expectSourceEpisode()
+	mock.ExpectQuery(`FROM episodes e2`).
+		WithArgs(episodeID, 10, podcastID).
+		WillReturnRows(similarRows())
+
+	for i := 0; i < 2; i++ {
+		items, err := repo.GetSimilarEpisodes(context.Background(), episodeID, 10, nil, nil, false)
+		if err != nil {
+			t.Fatalf("GetSimilarEpisodes() call %d error = %v", i+1, err)
+		}
+		if len(items) != 1 || items[0].ID != similarID {
+			t.Errorf("GetSimilarEpisodes() call %d = %+v, want episode %s", i+1, items, similarID)
+		}
+	}
+}
+
+func TestGetSimilarEpisodesKeepsOtherErrors(t *testing.T) {
+	repo, mock := newMockRepository(t)
+	episodeID, podcastID := uuid.New(), uuid.New()
+	queryErr := &pq.Error{Code: "42883", Message: "function unnest(integer) does not exist"}
+
+	mock.ExpectQuery(`SELECT e.podcast_id, e.title\s+FROM episodes e`).
+		WithArgs(episodeID).
+		WillReturnRows(sqlmock.NewRows([]string{"podcast_id", "title"}).AddRow(podcastID, "Part 1"))
+	mock.ExpectQuery(`similarity\(e2.title, \$4\)`).
+		WithArgs(episodeID, 10, podcastID, "Part 1").
+		WillReturnError(queryErr)
+
+	_, err := repo.GetSimilarEpisodes(context.Background(), episodeID, 10, nil, nil, false)
+	if !errors.Is(err, queryErr) {
+		t.Errorf("GetSimilarEpisodes() error = %v, want %v", err, queryErr)
+	}
+	if repo.trigramUnavailable.Load() {
+		t.Error("trigramUnavailable is set after an error other than similarity() missing")
+	}
+}
+
+func TestIsUndefinedFunction(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "similarity missing", err: errNoTrigram, want: true},
+		{name: "other function missing", err: &pq.Error{Code: "42883", Message: "function unnest(integer) does not exist"}},
+		{name: "other error", err: &pq.Error{Code: "42P01", Message: "relation \"similarity(\" does not exist"}},
+		{name: "not a Postgres error", err: errors.New("function similarity(text, text) does not exist")},
+		{name: "no error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isUndefinedFunction(tt.err, "similarity"); got != tt.want {
+				t.Errorf("isUndefinedFunction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// pkg/recommendation/usecase/diversity.go
+package usecase
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
+)
+
+// diversify re-ranks recommendations so no more than maxPerPodcaster items from one podcaster and
+// maxPerCategory items from one category lead the list. Items over a cap keep their relative order
+// after the others, so nothing is dropped and the top pick always stays first. A cap of zero or
+// less is not applied, and items without a podcaster or category don't count towards that cap.
+func diversify(items []models.RecommendedItem, maxPerPodcaster, maxPerCategory int) []models.RecommendedItem {
+	if len(items) < 2 || (maxPerPodcaster <= 0 && maxPerCategory <= 0) {
+		return items
+	}
+
+	podcasterCounts := make(map[uuid.UUID]int)
+	categoryCounts := make(map[string]int)
+	diversified := make([]models.RecommendedItem, 0, len(items))
+	var deferred []models.RecommendedItem
+
+	for _, item := range items {
+		category := strings.ToLower(item.Category)
+
+		overPodcasterCap := maxPerPodcaster > 0 && item.PodcasterID != uuid.Nil && podcasterCounts[item.PodcasterID] >= maxPerPodcaster
+		overCategoryCap := maxPerCategory > 0 && category != "" && categoryCounts[category] >= maxPerCategory
+		if overPodcasterCap || overCategoryCap {
+			deferred = append(deferred, item)
+			continue
+		}
+
+		if item.PodcasterID != uuid.Nil {
+			podcasterCounts[item.PodcasterID]++
+		}
+		if category != "" {
+			categoryCounts[category]++
+		}
+		diversified = append(diversified, item)
+	}
+
+	return append(diversified, deferred...)
+}
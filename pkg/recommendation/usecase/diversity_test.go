@@ -0,0 +1,88 @@
+// pkg/recommendation/usecase/diversity_test.go
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
+)
+
+// itemTitles returns the titles of items in order, for comparing rankings
+func itemTitles(items []models.RecommendedItem) []string {
+	titles := make([]string, 0, len(items))
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestDiversify(t *testing.T) {
+	prolific, other, third := uuid.New(), uuid.New(), uuid.New()
+
+	// One podcaster's news podcasts dominate the top of the candidates
+	candidates := []models.RecommendedItem{
+		{Title: "news-1", PodcasterID: prolific, Category: "News"},
+		{Title: "news-2", PodcasterID: prolific, Category: "News"},
+		{Title: "news-3", PodcasterID: prolific, Category: "news"},
+		{Title: "news-4", PodcasterID: prolific, Category: "News"},
+		{Title: "history-1", PodcasterID: other, Category: "History"},
+		{Title: "news-5", PodcasterID: third, Category: "News"},
+		{Title: "music-1", PodcasterID: third, Category: "Music"},
+		{Title: "unknown-1"},
+	}
+
+	tests := []struct {
+		name            string
+		maxPerPodcaster int
+		maxPerCategory  int
+		want            []string
+	}{
+		{
+			name:            "podcaster cap",
+			maxPerPodcaster: 2,
+			want:            []string{"news-1", "news-2", "history-1", "news-5", "music-1", "unknown-1", "news-3", "news-4"},
+		},
+		{
+			name:           "category cap, case-insensitive",
+			maxPerCategory: 2,
+			want:           []string{"news-1", "news-2", "history-1", "music-1", "unknown-1", "news-3", "news-4", "news-5"},
+		},
+		{
+			name:            "both caps",
+			maxPerPodcaster: 1,
+			maxPerCategory:  2,
+			want:            []string{"news-1", "history-1", "news-5", "unknown-1", "news-2", "news-3", "news-4", "music-1"},
+		},
+		{
+			name: "no caps",
+			want: []string{"news-1", "news-2", "news-3", "news-4", "history-1", "news-5", "music-1", "unknown-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := itemTitles(diversify(candidates, tt.maxPerPodcaster, tt.maxPerCategory))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diversify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiversifyKeepsCandidates(t *testing.T) {
+	podcasterID := uuid.New()
+	candidates := []models.RecommendedItem{
+		{Title: "a", PodcasterID: podcasterID, Category: "News"},
+		{Title: "b", PodcasterID: podcasterID, Category: "News"},
+		{Title: "c", PodcasterID: podcasterID, Category: "News"},
+	}
+
+	// Nothing else to move up, so the order is unchanged and nothing is dropped
+	got := itemTitles(diversify(candidates, 1, 1))
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diversify() = %v, want %v", got, want)
+	}
+}
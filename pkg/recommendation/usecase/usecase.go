@@ -3,14 +3,21 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/cache"
 	"github.com/MHK-26/pod_platfrom_go/pkg/common/config"
+	"github.com/MHK-26/pod_platfrom_go/pkg/common/logger"
 	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/models"
 	"github.com/MHK-26/pod_platfrom_go/pkg/recommendation/repository/postgres"
 )
 
+// maxRecommendationLimit is the most items a single recommendation request returns
+const maxRecommendationLimit = 50
+
 // Usecase defines the methods for the recommendation usecase
 type Usecase interface {
 	// User-based recommendations
@@ -19,26 +26,33 @@ type Usecase interface {
 	// Similar content recommendations
 	GetSimilarPodcasts(ctx context.Context, req *models.SimilarContentRequest) (*models.RecommendationResponse, error)
 	GetSimilarEpisodes(ctx context.Context, req *models.SimilarContentRequest) (*models.RecommendationResponse, error)
+	RefreshSimilarPodcasts(ctx context.Context) error
 	
 	// Popular content recommendations
 	GetTrendingPodcasts(ctx context.Context, req *models.TrendingRequest) (*models.RecommendationResponse, error)
 	GetPopularInCategory(ctx context.Context, req *models.CategoryPopularRequest) (*models.RecommendationResponse, error)
+	RefreshTrending(ctx context.Context) error
 	
 	// User preferences management
 	UpdateUserPreference(ctx context.Context, userID uuid.UUID, categoryID uuid.UUID, weight float64) error
 	GetUserPreferences(ctx context.Context, userID uuid.UUID) ([]models.UserPreference, error)
+	
+	// Recommendation feedback
+	SubmitFeedback(ctx context.Context, userID uuid.UUID, req *models.FeedbackRequest) (*models.RecommendationFeedback, error)
 }
 
 type usecase struct {
 	repo           postgres.Repository
+	cache          cache.Cache
 	cfg            *config.Config
 	contextTimeout time.Duration
 }
 
 // NewUsecase creates a new recommendation usecase
-func NewUsecase(repo postgres.Repository, cfg *config.Config, timeout time.Duration) Usecase {
+func NewUsecase(repo postgres.Repository, resultCache cache.Cache, cfg *config.Config, timeout time.Duration) Usecase {
 	return &usecase{
 		repo:           repo,
+		cache:          resultCache,
 		cfg:            cfg,
 		contextTimeout: timeout,
 	}
@@ -55,15 +69,39 @@ func (u *usecase) GetPersonalizedRecommendations(ctx context.Context, req *model
 	}
 	
 	// Cap the limit
-	if req.Limit > 50 {
-		req.Limit = 50
+	if req.Limit > maxRecommendationLimit {
+		req.Limit = maxRecommendationLimit
 	}
 	
-	items, err := u.repo.GetPersonalizedRecommendations(ctx, req.UserID, req.Limit, req.ExcludedIDs)
+	// Cap the exclusion list
+	req.ExcludedIDs = u.capExcludedIDs(req.ExcludedIDs)
+	
+	hideExplicit, err := u.hidesExplicit(ctx, &req.UserID, req.HideExplicit)
 	if err != nil {
 		return nil, err
 	}
 	
+	items, err := u.cachedRecommendations(ctx, explicitCacheKey(personalizedCacheKey(req.UserID), hideExplicit), u.cfg.Recommendation.PersonalizedCacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+		items, err := u.repo.GetPersonalizedRecommendations(ctx, req.UserID, candidatePoolSize(limit), u.personalizationWeights(), req.ExcludedIDs, hideExplicit)
+		if err != nil {
+			return nil, err
+		}
+		return u.diversify(items), nil
+	})
+	if err != nil {
+		// Fall back to trending podcasts so the home feed still renders
+		logger.Error("Personalized recommendations failed, falling back to trending",
+			logger.Field("user_id", req.UserID),
+			logger.Field("error", err))
+		
+		items, err = u.cachedRecommendations(ctx, explicitCacheKey("recommendations:trending:weekly", hideExplicit), u.cfg.Recommendation.CacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+			return u.repo.GetTrendingPodcasts(ctx, "weekly", limit, u.ratingWeights(), req.ExcludedIDs, hideExplicit)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	
 	return &models.RecommendationResponse{Items: items}, nil
 }
 
@@ -78,11 +116,26 @@ func (u *usecase) GetSimilarPodcasts(ctx context.Context, req *models.SimilarCon
 	}
 	
 	// Cap the limit
-	if req.Limit > 50 {
-		req.Limit = 50
+	if req.Limit > maxRecommendationLimit {
+		req.Limit = maxRecommendationLimit
 	}
 	
-	items, err := u.repo.GetSimilarPodcasts(ctx, req.ContentID, req.Limit, req.ExcludedIDs)
+	// Cap the exclusion list
+	req.ExcludedIDs = u.capExcludedIDs(req.ExcludedIDs)
+	
+	hideExplicit, err := u.hidesExplicit(ctx, req.UserID, req.HideExplicit)
+	if err != nil {
+		return nil, err
+	}
+	
+	key := explicitCacheKey(fmt.Sprintf("recommendations:similar_podcasts:%s", req.ContentID), hideExplicit)
+	items, err := u.cachedRecommendations(ctx, key, u.cfg.Recommendation.CacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+		items, err := u.repo.GetSimilarPodcasts(ctx, req.ContentID, candidatePoolSize(limit), req.ExcludedIDs, hideExplicit)
+		if err != nil {
+			return nil, err
+		}
+		return u.diversify(items), nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -101,11 +154,32 @@ func (u *usecase) GetSimilarEpisodes(ctx context.Context, req *models.SimilarCon
 	}
 	
 	// Cap the limit
-	if req.Limit > 50 {
-		req.Limit = 50
+	if req.Limit > maxRecommendationLimit {
+		req.Limit = maxRecommendationLimit
 	}
 	
-	items, err := u.repo.GetSimilarEpisodes(ctx, req.ContentID, req.Limit, req.ExcludedIDs)
+	// Cap the exclusion list
+	req.ExcludedIDs = u.capExcludedIDs(req.ExcludedIDs)
+	
+	hideExplicit, err := u.hidesExplicit(ctx, req.UserID, req.HideExplicit)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Authenticated listeners get their own results, leaving out episodes they already heard
+	key := explicitCacheKey(fmt.Sprintf("recommendations:similar_episodes:%s", req.ContentID), hideExplicit)
+	var listened *models.ListenedFilter
+	if req.UserID != nil {
+		key += ":" + req.UserID.String()
+		listened = &models.ListenedFilter{
+			ListenerID:    *req.UserID,
+			ProgressRatio: u.cfg.Recommendation.ListenedProgressRatio,
+		}
+	}
+	
+	items, err := u.cachedRecommendations(ctx, key, u.cfg.Recommendation.CacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+		return u.repo.GetSimilarEpisodes(ctx, req.ContentID, limit, req.ExcludedIDs, listened, hideExplicit)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -113,6 +187,19 @@ func (u *usecase) GetSimilarEpisodes(ctx context.Context, req *models.SimilarCon
 	return &models.RecommendationResponse{Items: items}, nil
 }
 
+// RefreshSimilarPodcasts recomputes the stored similar podcasts of podcasts created or changed
+// since the last run, along with the podcasts affected by the change. Like RefreshTrending it is
+// meant to be run on a schedule and isn't bound by the usecase timeout.
+func (u *usecase) RefreshSimilarPodcasts(ctx context.Context) error {
+	recomputed, err := u.repo.RefreshSimilarPodcasts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to compute similar podcasts: %w", err)
+	}
+	logger.Info("Computed similar podcasts", logger.Field("podcasts", recomputed))
+	
+	return nil
+}
+
 // GetTrendingPodcasts gets trending podcasts
 func (u *usecase) GetTrendingPodcasts(ctx context.Context, req *models.TrendingRequest) (*models.RecommendationResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
@@ -124,11 +211,22 @@ func (u *usecase) GetTrendingPodcasts(ctx context.Context, req *models.TrendingR
 	}
 	
 	// Cap the limit
-	if req.Limit > 50 {
-		req.Limit = 50
+	if req.Limit > maxRecommendationLimit {
+		req.Limit = maxRecommendationLimit
+	}
+	
+	// Cap the exclusion list
+	req.ExcludedIDs = u.capExcludedIDs(req.ExcludedIDs)
+	
+	hideExplicit, err := u.hidesExplicit(ctx, req.UserID, req.HideExplicit)
+	if err != nil {
+		return nil, err
 	}
 	
-	items, err := u.repo.GetTrendingPodcasts(ctx, req.TimeRange, req.Limit, req.ExcludedIDs)
+	key := explicitCacheKey(fmt.Sprintf("recommendations:trending:%s", req.TimeRange), hideExplicit)
+	items, err := u.cachedRecommendations(ctx, key, u.cfg.Recommendation.CacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+		return u.repo.GetTrendingPodcasts(ctx, req.TimeRange, limit, u.ratingWeights(), req.ExcludedIDs, hideExplicit)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -147,11 +245,22 @@ func (u *usecase) GetPopularInCategory(ctx context.Context, req *models.Category
 	}
 	
 	// Cap the limit
-	if req.Limit > 50 {
-		req.Limit = 50
+	if req.Limit > maxRecommendationLimit {
+		req.Limit = maxRecommendationLimit
 	}
 	
-	items, err := u.repo.GetPopularInCategory(ctx, req.CategoryID, req.Limit, req.ExcludedIDs)
+	// Cap the exclusion list
+	req.ExcludedIDs = u.capExcludedIDs(req.ExcludedIDs)
+	
+	hideExplicit, err := u.hidesExplicit(ctx, req.UserID, req.HideExplicit)
+	if err != nil {
+		return nil, err
+	}
+	
+	key := explicitCacheKey(fmt.Sprintf("recommendations:popular_in_category:%s", req.CategoryID), hideExplicit)
+	items, err := u.cachedRecommendations(ctx, key, u.cfg.Recommendation.CacheTTL, req.Limit, req.ExcludedIDs, func(limit int) ([]models.RecommendedItem, error) {
+		return u.repo.GetPopularInCategory(ctx, req.CategoryID, limit, u.ratingWeights(), req.ExcludedIDs, hideExplicit)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -159,12 +268,39 @@ func (u *usecase) GetPopularInCategory(ctx context.Context, req *models.Category
 	return &models.RecommendationResponse{Items: items}, nil
 }
 
+// trendingTimeRanges are the time ranges trending podcasts are requested for
+var trendingTimeRanges = []string{"daily", "weekly", "monthly"}
+
+// RefreshTrending precomputes the trending podcasts of every time range, which are served
+// instead of the live query until the configured trending TTL has passed. It is meant to be run
+// on a schedule and isn't bound by the usecase timeout, as it aggregates all recent listen events.
+func (u *usecase) RefreshTrending(ctx context.Context) error {
+	for _, timeRange := range trendingTimeRanges {
+		stored, err := u.repo.RefreshTrendingPodcasts(ctx, timeRange, u.ratingWeights(), u.cfg.Recommendation.TrendingTTL)
+		if err != nil {
+			return fmt.Errorf("failed to compute %s trending podcasts: %w", timeRange, err)
+		}
+		logger.Info("Computed trending podcasts",
+			logger.Field("time_range", timeRange),
+			logger.Field("podcasts", stored))
+	}
+	
+	return nil
+}
+
 // UpdateUserPreference updates a user's category preference
 func (u *usecase) UpdateUserPreference(ctx context.Context, userID uuid.UUID, categoryID uuid.UUID, weight float64) error {
 	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
 	defer cancel()
 	
-	return u.repo.UpdateUserPreference(ctx, userID, categoryID, weight)
+	if err := u.repo.UpdateUserPreference(ctx, userID, categoryID, weight); err != nil {
+		return err
+	}
+	
+	// The cached recommendations no longer reflect the user's preferences
+	u.invalidatePersonalized(ctx, userID)
+	
+	return nil
 }
 
 // GetUserPreferences gets a user's category preferences
@@ -173,4 +309,158 @@ func (u *usecase) GetUserPreferences(ctx context.Context, userID uuid.UUID) ([]m
 	defer cancel()
 	
 	return u.repo.GetUserPreferences(ctx, userID)
-}
\ No newline at end of file
+}
+
+// SubmitFeedback records a user's feedback on a recommended item
+func (u *usecase) SubmitFeedback(ctx context.Context, userID uuid.UUID, req *models.FeedbackRequest) (*models.RecommendationFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, u.contextTimeout)
+	defer cancel()
+	
+	if req.Type != "podcast" && req.Type != "episode" {
+		return nil, errors.New("invalid item type")
+	}
+	
+	switch req.Signal {
+	case models.FeedbackLike, models.FeedbackDislike, models.FeedbackNotInterested, models.FeedbackHide:
+	default:
+		return nil, errors.New("invalid feedback signal")
+	}
+	
+	feedback := &models.RecommendationFeedback{
+		UserID:   userID,
+		ItemID:   req.ItemID,
+		ItemType: req.Type,
+		Signal:   req.Signal,
+	}
+	if err := u.repo.SaveFeedback(ctx, feedback); err != nil {
+		return nil, err
+	}
+	
+	// Dismissed and disliked items must not linger in the cached recommendations
+	u.invalidatePersonalized(ctx, userID)
+	
+	return feedback, nil
+}
+
+// personalizationWeights returns the configured weights of explicit signals in personalized scoring
+func (u *usecase) personalizationWeights() models.PersonalizationWeights {
+	return models.PersonalizationWeights{
+		Preference:     u.cfg.Recommendation.PreferenceWeight,
+		DislikePenalty: u.cfg.Recommendation.DislikePenalty,
+		Ratings:        u.ratingWeights(),
+	}
+}
+
+// ratingWeights returns the configured weight of podcast ratings in trending and popular scoring
+func (u *usecase) ratingWeights() models.RatingWeights {
+	return models.RatingWeights{
+		Weight:       u.cfg.Recommendation.RatingWeight,
+		PriorReviews: u.cfg.Recommendation.RatingPriorReviews,
+	}
+}
+
+// capExcludedIDs truncates the exclusion list to the configured maximum
+func (u *usecase) capExcludedIDs(excludedIDs []uuid.UUID) []uuid.UUID {
+	maxExcluded := u.cfg.Recommendation.MaxExcludedIDs
+	if maxExcluded > 0 && len(excludedIDs) > maxExcluded {
+		return excludedIDs[:maxExcluded]
+	}
+	return excludedIDs
+}
+
+// personalizedCacheKey returns the cache key of a user's personalized recommendations
+func personalizedCacheKey(userID uuid.UUID) string {
+	return fmt.Sprintf("recommendations:personalized:%s", userID)
+}
+
+// explicitCacheKey returns the cache key of recommendations without explicit content when they
+// are asked for, so they don't share entries with the unfiltered ones
+func explicitCacheKey(key string, hideExplicit bool) string {
+	if hideExplicit {
+		return key + ":clean"
+	}
+	return key
+}
+
+// hidesExplicit reports whether explicit content is left out of recommendations: as the request
+// asks if it overrides the preference, else as the signed in user chose. Anonymous requests get it all.
+func (u *usecase) hidesExplicit(ctx context.Context, userID *uuid.UUID, override *bool) (bool, error) {
+	if override != nil {
+		return *override, nil
+	}
+	if userID == nil {
+		return false, nil
+	}
+	return u.repo.GetHideExplicit(ctx, *userID)
+}
+
+// cachedRecommendations serves recommendations from the cache, computing them with fetch on a miss.
+// Entries hold maxRecommendationLimit items so requests for any limit share them. Requests excluding
+// IDs bypass the cache, as their results depend on the caller's exclusions. fetch may return more
+// items than asked for; only the first limit are served.
+func (u *usecase) cachedRecommendations(ctx context.Context, key string, ttl time.Duration, limit int, excludedIDs []uuid.UUID, fetch func(limit int) ([]models.RecommendedItem, error)) ([]models.RecommendedItem, error) {
+	if u.cache == nil || ttl <= 0 || len(excludedIDs) > 0 {
+		items, err := fetch(limit)
+		if err != nil {
+			return nil, err
+		}
+		return firstItems(items, limit), nil
+	}
+	
+	var items []models.RecommendedItem
+	found, err := u.cache.Get(ctx, key, &items)
+	if err != nil {
+		// An unavailable cache only costs the query
+		logger.Warn("Recommendation cache lookup failed", logger.Field("key", key), logger.Field("error", err))
+	}
+	if found {
+		logger.Debug("Recommendation cache hit", logger.Field("key", key))
+		return firstItems(items, limit), nil
+	}
+	logger.Debug("Recommendation cache miss", logger.Field("key", key))
+	
+	items, err = fetch(maxRecommendationLimit)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := u.cache.Set(ctx, key, items, ttl); err != nil {
+		logger.Warn("Failed to cache recommendations", logger.Field("key", key), logger.Field("error", err))
+	}
+	
+	return firstItems(items, limit), nil
+}
+
+// invalidatePersonalized drops the cached personalized recommendations of a user
+func (u *usecase) invalidatePersonalized(ctx context.Context, userID uuid.UUID) {
+	if u.cache == nil {
+		return
+	}
+	for _, hideExplicit := range []bool{false, true} {
+		if err := u.cache.Delete(ctx, explicitCacheKey(personalizedCacheKey(userID), hideExplicit)); err != nil {
+			logger.Warn("Failed to invalidate cached recommendations", logger.Field("user_id", userID), logger.Field("error", err))
+		}
+	}
+}
+
+// diversify spreads recommendations over podcasters and categories using the configured caps
+func (u *usecase) diversify(items []models.RecommendedItem) []models.RecommendedItem {
+	return diversify(items, u.cfg.Recommendation.DiversityMaxPerPodcaster, u.cfg.Recommendation.DiversityMaxPerCategory)
+}
+
+// candidatePoolSize returns how many candidates to fetch for limit results, leaving diversification
+// room to pull up items from further down the ranking
+func candidatePoolSize(limit int) int {
+	if limit*3 > maxRecommendationLimit {
+		return maxRecommendationLimit
+	}
+	return limit * 3
+}
+
+// firstItems returns at most limit items
+func firstItems(items []models.RecommendedItem, limit int) []models.RecommendedItem {
+	if len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
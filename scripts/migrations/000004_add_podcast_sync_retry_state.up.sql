@@ -0,0 +1,9 @@
+-- Track consecutive sync failures so broken feeds are retried with backoff and eventually suspended
+ALTER TABLE podcasts
+    ADD COLUMN IF NOT EXISTS sync_failure_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_sync_retry_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS sync_suspended BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Retry scheduler looks up feeds whose retry is due
+CREATE INDEX IF NOT EXISTS idx_podcasts_next_sync_retry_at ON podcasts(next_sync_retry_at) WHERE next_sync_retry_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_podcasts_sync_suspended ON podcasts(sync_suspended) WHERE sync_suspended;
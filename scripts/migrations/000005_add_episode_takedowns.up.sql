@@ -0,0 +1,16 @@
+-- Allow episodes to be taken down (e.g. DMCA) while keeping their record and analytics
+ALTER TABLE episodes DROP CONSTRAINT IF EXISTS episodes_status_check;
+ALTER TABLE episodes ADD CONSTRAINT episodes_status_check
+    CHECK (status IN ('active', 'pending', 'rejected', 'archived', 'taken_down'));
+
+-- Audit trail of who took an episode down and why
+CREATE TABLE IF NOT EXISTS episode_takedowns (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    episode_id UUID NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    taken_down_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reason TEXT NOT NULL,
+    previous_status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_episode_takedowns_episode_id ON episode_takedowns(episode_id);
@@ -0,0 +1,22 @@
+-- Compact share codes for episodes and podcasts, one per target
+CREATE TABLE IF NOT EXISTS short_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(16) NOT NULL UNIQUE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('episode', 'podcast')),
+    target_id UUID NOT NULL,
+    click_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (target_type, target_id)
+);
+
+-- Individual short link clicks for share tracking
+CREATE TABLE IF NOT EXISTS short_link_clicks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    short_link_id UUID NOT NULL REFERENCES short_links(id) ON DELETE CASCADE,
+    referrer TEXT,
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_short_link_clicks_short_link_id ON short_link_clicks(short_link_id);
+CREATE INDEX IF NOT EXISTS idx_short_link_clicks_created_at ON short_link_clicks(created_at);
@@ -0,0 +1,2 @@
+-- Non-fatal feed problems noticed during a sync, e.g. an unexpected content type
+ALTER TABLE rss_sync_logs ADD COLUMN IF NOT EXISTS warnings TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Episode the podcaster pinned to the top of the podcast page, e.g. a trailer
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS pinned_episode_id UUID REFERENCES episodes(id) ON DELETE SET NULL;
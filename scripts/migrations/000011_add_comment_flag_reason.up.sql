@@ -0,0 +1,4 @@
+-- Why the comment filter flagged a comment for review
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS flag_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_comments_status ON comments(status);
@@ -0,0 +1,9 @@
+-- Episodes that dropped out of their feed are marked removed, keeping their playback history
+ALTER TABLE episodes DROP CONSTRAINT IF EXISTS episodes_status_check;
+ALTER TABLE episodes ADD CONSTRAINT episodes_status_check
+    CHECK (status IN ('active', 'pending', 'rejected', 'archived', 'taken_down', 'removed'));
+
+-- Pruning is opt-in, as some feeds only ever carry their latest items
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS prune_missing BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE rss_sync_logs ADD COLUMN IF NOT EXISTS episodes_removed INTEGER NOT NULL DEFAULT 0;
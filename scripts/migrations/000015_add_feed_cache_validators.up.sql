@@ -0,0 +1,8 @@
+-- HTTP cache validators of the last fetched feed, sent back so unchanged feeds answer 304
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS feed_etag TEXT NOT NULL DEFAULT '';
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS feed_last_modified TEXT NOT NULL DEFAULT '';
+
+-- Syncs of unchanged feeds are logged without being applied
+ALTER TABLE rss_sync_logs DROP CONSTRAINT IF EXISTS rss_sync_logs_status_check;
+ALTER TABLE rss_sync_logs ADD CONSTRAINT rss_sync_logs_status_check
+    CHECK (status IN ('success', 'not_modified', 'failure'));
@@ -0,0 +1,5 @@
+-- iTunes episode type, so trailers and bonus episodes can be told apart from regular ones
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS episode_type VARCHAR(10) NOT NULL DEFAULT 'full'
+    CHECK (episode_type IN ('full', 'trailer', 'bonus'));
+
+CREATE INDEX IF NOT EXISTS idx_episodes_podcast_id_episode_type ON episodes(podcast_id, episode_type);
@@ -0,0 +1,2 @@
+-- Transcript links from podcast:transcript tags, one per format, e.g. [{"url": "...", "type": "text/vtt"}]
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS transcripts JSONB NOT NULL DEFAULT '[]';
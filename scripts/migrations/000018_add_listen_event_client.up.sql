@@ -0,0 +1,3 @@
+-- Client app and device type classified from the user agent when a listen is tracked
+ALTER TABLE listen_events ADD COLUMN IF NOT EXISTS client_name VARCHAR(50) NOT NULL DEFAULT 'Unknown';
+ALTER TABLE listen_events ADD COLUMN IF NOT EXISTS device_type VARCHAR(20) NOT NULL DEFAULT 'unknown';
@@ -0,0 +1,8 @@
+-- Full-text search over episode titles and descriptions, titles weighted above descriptions
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_episodes_search_vector ON episodes USING GIN (search_vector);
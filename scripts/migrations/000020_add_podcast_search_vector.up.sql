@@ -0,0 +1,9 @@
+-- Full-text search over podcast titles, authors and descriptions, ranked in that order
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(author, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_podcasts_search_vector ON podcasts USING GIN (search_vector);
@@ -0,0 +1,3 @@
+-- Keyset indexes for cursor paginated listings, matching their (timestamp, id) ordering
+CREATE INDEX IF NOT EXISTS idx_episodes_podcast_created_at ON episodes(podcast_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_playback_history_listener_updated_at ON playback_history(listener_id, updated_at DESC, episode_id DESC);
@@ -0,0 +1,2 @@
+-- Storage path of an uploaded profile image, kept so the file can be removed when it is replaced
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_image_path VARCHAR(255) NOT NULL DEFAULT '';
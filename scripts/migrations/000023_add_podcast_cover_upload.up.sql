@@ -0,0 +1,7 @@
+-- Cover art uploaded by the podcaster. The feed's own image is remembered separately so a sync
+-- only replaces an uploaded cover once the feed image actually changes.
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS cover_image_path VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS cover_image_user_set BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS feed_cover_image_url TEXT NOT NULL DEFAULT '';
+
+UPDATE podcasts SET feed_cover_image_url = COALESCE(cover_image_url, '') WHERE rss_url != '';
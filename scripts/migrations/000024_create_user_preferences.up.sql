@@ -0,0 +1,9 @@
+-- Explicit category preferences set by listeners. Positive weights boost a category in
+-- personalized recommendations, negative weights demote it.
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    category_id UUID REFERENCES categories(id) ON DELETE CASCADE,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    last_updated TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, category_id)
+);
@@ -0,0 +1,12 @@
+-- Explicit feedback on recommended items, one signal per user and item (the latest wins)
+CREATE TABLE IF NOT EXISTS recommendation_feedback (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_id UUID NOT NULL,
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('podcast', 'episode')),
+    signal VARCHAR(20) NOT NULL CHECK (signal IN ('like', 'dislike', 'not_interested', 'hide')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, item_type, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recommendation_feedback_user_signal ON recommendation_feedback(user_id, signal);
@@ -0,0 +1,4 @@
+-- Feeds answering 410 Gone are logged apart from other failures, so operators can disable them
+ALTER TABLE rss_sync_logs DROP CONSTRAINT IF EXISTS rss_sync_logs_status_check;
+ALTER TABLE rss_sync_logs ADD CONSTRAINT rss_sync_logs_status_check
+    CHECK (status IN ('success', 'not_modified', 'failure', 'gone'));
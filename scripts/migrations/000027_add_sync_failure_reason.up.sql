@@ -0,0 +1,4 @@
+-- Failed syncs record why they failed, so the dashboard can tell timeouts from broken feeds
+ALTER TABLE rss_sync_logs ADD COLUMN IF NOT EXISTS failure_reason VARCHAR(20)
+    CHECK (failure_reason IN ('timeout', 'http_error', 'parse_error', 'empty_feed', 'no_rss_url', 'internal_error'));
+ALTER TABLE rss_sync_logs ADD COLUMN IF NOT EXISTS http_status INT;
@@ -0,0 +1,4 @@
+-- Serves the continue-listening shelf, which only lists episodes started but not finished
+CREATE INDEX IF NOT EXISTS idx_playback_history_listener_in_progress
+    ON playback_history(listener_id, updated_at DESC)
+    WHERE completed = false AND position > 0;
@@ -0,0 +1,15 @@
+-- Podcast-level star ratings with an optional review, one per user and podcast (editing replaces it)
+CREATE TABLE IF NOT EXISTS podcast_reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    podcast_id UUID NOT NULL REFERENCES podcasts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    content TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'hidden', 'flagged')),
+    flag_reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (podcast_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_podcast_reviews_podcast_status ON podcast_reviews(podcast_id, status, updated_at DESC);
@@ -0,0 +1,5 @@
+-- Episode chapters, e.g. [{"start_time": 0, "title": "Intro"}], read from the podcast:chapters
+-- file of the feed unless the podcaster set them by hand
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS chapters JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS chapters_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS chapters_user_set BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,14 @@
+-- Transcripts split into searchable segments: timed cues for VTT and SRT transcripts, paragraphs
+-- for plain text. The 'simple' configuration doesn't stem, so Arabic and English match alike.
+CREATE TABLE IF NOT EXISTS episode_transcript_segments (
+    episode_id UUID NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    start_time DOUBLE PRECISION, -- in seconds, for timed transcripts
+    end_time DOUBLE PRECISION,
+    char_offset INTEGER NOT NULL, -- in characters from the start of the transcript text
+    text TEXT NOT NULL,
+    tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', text)) STORED,
+    PRIMARY KEY (episode_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_episode_transcript_segments_tsv ON episode_transcript_segments USING GIN (tsv);
@@ -0,0 +1,7 @@
+-- Keywords from itunes:keywords, lowercased, for search and similar podcast and episode recommendations
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS keywords TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS keywords TEXT[] NOT NULL DEFAULT '{}';
+
+-- GIN indexes serve the keyword overlap (&&) and containment (@>) operators
+CREATE INDEX IF NOT EXISTS idx_podcasts_keywords ON podcasts USING GIN (keywords);
+CREATE INDEX IF NOT EXISTS idx_episodes_keywords ON episodes USING GIN (keywords);
@@ -0,0 +1,3 @@
+-- Enclosures of an episode's feed item other than the one chosen as its audio, e.g. an M4A
+-- version next to the MP3
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS alternate_enclosures JSONB NOT NULL DEFAULT '[]';
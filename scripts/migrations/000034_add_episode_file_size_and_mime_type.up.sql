@@ -0,0 +1,4 @@
+-- Size and MIME type of episode audio from feed enclosures, for showing download sizes.
+-- Zero and empty when the feed doesn't give them.
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS file_size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS mime_type VARCHAR(100) NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Admins can suspend a podcast, hiding it and its episodes from everyone but its owner
+ALTER TABLE podcasts DROP CONSTRAINT IF EXISTS podcasts_status_check;
+ALTER TABLE podcasts ADD CONSTRAINT podcasts_status_check
+    CHECK (status IN ('active', 'pending', 'rejected', 'archived', 'suspended'));
@@ -0,0 +1,18 @@
+-- Reports of abusive comments and podcasts, reviewed by admins
+CREATE TABLE IF NOT EXISTS content_reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('comment', 'podcast')),
+    target_id UUID NOT NULL,
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'sexual_content', 'violence', 'copyright', 'other')),
+    details TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'reviewed', 'dismissed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A user reports a comment or podcast only once
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_reports_reporter_target ON content_reports(reporter_id, target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_content_reports_status ON content_reports(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_content_reports_target ON content_reports(target_type, target_id);
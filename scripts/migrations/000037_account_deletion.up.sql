@@ -0,0 +1,14 @@
+-- Deleting an account keeps the podcasts, marked deleted, and the comments of the user,
+-- without an owner or author
+ALTER TABLE podcasts ALTER COLUMN podcaster_id DROP NOT NULL;
+ALTER TABLE podcasts DROP CONSTRAINT IF EXISTS podcasts_podcaster_id_fkey;
+ALTER TABLE podcasts ADD CONSTRAINT podcasts_podcaster_id_fkey
+    FOREIGN KEY (podcaster_id) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE podcasts DROP CONSTRAINT IF EXISTS podcasts_status_check;
+ALTER TABLE podcasts ADD CONSTRAINT podcasts_status_check
+    CHECK (status IN ('active', 'pending', 'rejected', 'archived', 'suspended', 'deleted'));
+
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_user_id_fkey;
+ALTER TABLE comments ADD CONSTRAINT comments_user_id_fkey
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+-- Episodes carry their own itunes:explicit flag. Existing episodes take their podcast's
+-- until the next sync reads it from the feed.
+ALTER TABLE episodes ADD COLUMN IF NOT EXISTS explicit BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE episodes e
+SET explicit = TRUE
+FROM podcasts p
+WHERE e.podcast_id = p.id AND p.explicit;
@@ -0,0 +1,3 @@
+-- Listeners can choose to have explicit podcasts and episodes left out of listings,
+-- search and recommendations
+ALTER TABLE users ADD COLUMN IF NOT EXISTS hide_explicit BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,14 @@
+-- Trending scores precomputed per time range by the recommendation service's -compute-trending
+-- job, so trending requests don't aggregate listen events. Rows past expires_at are ignored and
+-- the live query is used instead.
+CREATE TABLE IF NOT EXISTS trending_items (
+    id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('podcast', 'episode')),
+    time_range VARCHAR(20) NOT NULL CHECK (time_range IN ('daily', 'weekly', 'monthly')),
+    score DOUBLE PRECISION NOT NULL,
+    last_updated TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (time_range, type, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trending_items_score ON trending_items(time_range, type, score DESC);
@@ -0,0 +1,61 @@
+-- Similar podcasts precomputed by the recommendation service's -compute-similarity job. Each
+-- podcast (item_id1) keeps its best scoring similar podcasts (item_id2).
+CREATE TABLE IF NOT EXISTS similarity_scores (
+    item_id1 UUID NOT NULL,
+    item_id2 UUID NOT NULL,
+    item_type VARCHAR(20) NOT NULL CHECK (item_type IN ('podcast', 'episode')),
+    score DOUBLE PRECISION NOT NULL,
+    last_updated TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_type, item_id1, item_id2)
+);
+
+CREATE INDEX IF NOT EXISTS idx_similarity_scores_score ON similarity_scores(item_type, item_id1, score DESC);
+CREATE INDEX IF NOT EXISTS idx_similarity_scores_item2 ON similarity_scores(item_type, item_id2);
+
+-- Podcasts whose similar podcasts must be recomputed, queued whenever a podcast is created or
+-- its categories or keywords change
+CREATE TABLE IF NOT EXISTS similarity_stale_podcasts (
+    podcast_id UUID PRIMARY KEY,
+    marked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE OR REPLACE FUNCTION mark_podcast_similarity_stale() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'podcast_categories' THEN
+        IF TG_OP = 'DELETE' THEN
+            INSERT INTO similarity_stale_podcasts (podcast_id) VALUES (OLD.podcast_id)
+            ON CONFLICT (podcast_id) DO UPDATE SET marked_at = CURRENT_TIMESTAMP;
+            RETURN OLD;
+        END IF;
+        INSERT INTO similarity_stale_podcasts (podcast_id) VALUES (NEW.podcast_id)
+        ON CONFLICT (podcast_id) DO UPDATE SET marked_at = CURRENT_TIMESTAMP;
+        RETURN NEW;
+    END IF;
+
+    INSERT INTO similarity_stale_podcasts (podcast_id) VALUES (NEW.id)
+    ON CONFLICT (podcast_id) DO UPDATE SET marked_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS podcast_categories_similarity_stale ON podcast_categories;
+CREATE TRIGGER podcast_categories_similarity_stale
+    AFTER INSERT OR UPDATE OR DELETE ON podcast_categories
+    FOR EACH ROW EXECUTE FUNCTION mark_podcast_similarity_stale();
+
+DROP TRIGGER IF EXISTS podcasts_insert_similarity_stale ON podcasts;
+CREATE TRIGGER podcasts_insert_similarity_stale
+    AFTER INSERT ON podcasts
+    FOR EACH ROW EXECUTE FUNCTION mark_podcast_similarity_stale();
+
+-- Feed syncs rewrite the keywords of every podcast, so only actual changes count
+DROP TRIGGER IF EXISTS podcasts_keywords_similarity_stale ON podcasts;
+CREATE TRIGGER podcasts_keywords_similarity_stale
+    AFTER UPDATE OF keywords ON podcasts
+    FOR EACH ROW WHEN (OLD.keywords IS DISTINCT FROM NEW.keywords)
+    EXECUTE FUNCTION mark_podcast_similarity_stale();
+
+-- Existing podcasts are computed on the first run
+INSERT INTO similarity_stale_podcasts (podcast_id)
+SELECT id FROM podcasts
+ON CONFLICT (podcast_id) DO NOTHING;
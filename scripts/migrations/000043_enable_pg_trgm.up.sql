@@ -0,0 +1,3 @@
+-- Similar episodes compare titles with pg_trgm's similarity(). Without the extension the
+-- recommendation service only matches episodes on their podcast and keywords.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
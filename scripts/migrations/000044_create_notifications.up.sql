@@ -0,0 +1,14 @@
+-- In-app notifications for listeners, e.g. a new episode of a podcast they subscribe to.
+-- Each episode notifies a listener at most once, however often its feed is synced.
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('new_episode')),
+    podcast_id UUID NOT NULL REFERENCES podcasts(id) ON DELETE CASCADE,
+    episode_id UUID NOT NULL REFERENCES episodes(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_user_episode ON notifications(user_id, type, episode_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
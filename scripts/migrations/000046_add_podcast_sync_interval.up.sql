@@ -0,0 +1,3 @@
+-- Podcasts can override the default time between scheduled feed syncs (RSS_SYNC_INTERVAL),
+-- e.g. hourly for daily news shows and weekly for feeds that rarely change
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS sync_interval_minutes INTEGER CHECK (sync_interval_minutes > 0);
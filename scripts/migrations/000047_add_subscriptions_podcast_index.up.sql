@@ -0,0 +1,2 @@
+-- Subscriber counts are looked up by podcast, which the (listener_id, podcast_id) primary key doesn't cover
+CREATE INDEX IF NOT EXISTS idx_subscriptions_podcast_id ON subscriptions(podcast_id);
@@ -0,0 +1,6 @@
+-- Listens per podcast, copied from listen_events by the content service's -refresh-listen-counts
+-- job so listings can sort by listens without aggregating listen events. The count is as fresh
+-- as the last run of the job.
+ALTER TABLE podcasts ADD COLUMN IF NOT EXISTS listen_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_podcasts_listen_count ON podcasts(listen_count DESC, id);
@@ -0,0 +1,21 @@
+-- Index titles, authors and descriptions with the Arabic text search configuration alongside
+-- the English one, so Arabic words match whatever their prefixes and suffixes. Generated
+-- columns can't change their expression, so the search vectors are recreated.
+ALTER TABLE podcasts DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE podcasts ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')) || to_tsvector('arabic', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(author, '')) || to_tsvector('arabic', coalesce(author, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(description, '')) || to_tsvector('arabic', coalesce(description, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_podcasts_search_vector ON podcasts USING GIN (search_vector);
+
+ALTER TABLE episodes DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE episodes ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')) || to_tsvector('arabic', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')) || to_tsvector('arabic', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_episodes_search_vector ON episodes USING GIN (search_vector);